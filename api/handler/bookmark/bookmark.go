@@ -0,0 +1,121 @@
+package bookmark
+
+import (
+	"anchor-blog/api/handler"
+	bookmarksvc "anchor-blog/internal/service/bookmark"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BookmarkHandler struct {
+	bookmarkService *bookmarksvc.BookmarkService
+}
+
+func NewBookmarkHandler(bs *bookmarksvc.BookmarkService) *BookmarkHandler {
+	return &BookmarkHandler{
+		bookmarkService: bs,
+	}
+}
+
+type AddBookmarkRequest struct {
+	Folder string `json:"folder"` // optional
+}
+
+// Add saves a post to the current user's reading list
+func (h *BookmarkHandler) Add(c *gin.Context) {
+	postID := c.Param("id")
+
+	var req AddBookmarkRequest
+	// The body is optional; without it the bookmark is unfiled
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	bookmark, err := h.bookmarkService.AddBookmark(c.Request.Context(), userID.(string), postID, req.Folder)
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, MapBookmarkToDTO(bookmark))
+}
+
+// Remove takes a post off the current user's reading list
+func (h *BookmarkHandler) Remove(c *gin.Context) {
+	postID := c.Param("id")
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	if err := h.bookmarkService.RemoveBookmark(c.Request.Context(), userID.(string), postID); err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Bookmark removed successfully"})
+}
+
+// List returns the current user's bookmarks, newest first, optionally from one folder
+func (h *BookmarkHandler) List(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	page, _ := strconv.ParseInt(c.DefaultQuery("page", "1"), 10, 64)
+	limit, _ := strconv.ParseInt(c.DefaultQuery("limit", "20"), 10, 64)
+
+	saved, err := h.bookmarkService.ListBookmarks(c.Request.Context(), userID.(string), c.Query("folder"), page, limit)
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+
+	res := make([]*BookmarkDTO, len(saved))
+	for idx, item := range saved {
+		res[idx] = MapSavedPostToDTO(item)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"bookmarks": res,
+		"count":     len(res),
+		"page":      page,
+	})
+}
+
+// ListFolders returns the folders of the current user's reading list
+func (h *BookmarkHandler) ListFolders(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	folders, err := h.bookmarkService.ListFolders(c.Request.Context(), userID.(string))
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+
+	res := make([]*FolderDTO, len(folders))
+	for idx, folder := range folders {
+		res[idx] = &FolderDTO{Name: folder.Name, Count: folder.Count}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"folders": res})
+}
@@ -0,0 +1,36 @@
+package bookmark
+
+import (
+	"anchor-blog/api/handler/post"
+	"anchor-blog/internal/domain/entities"
+	bookmarksvc "anchor-blog/internal/service/bookmark"
+	"time"
+)
+
+type BookmarkDTO struct {
+	ID        string        `json:"id"`
+	PostID    string        `json:"post_id"`
+	Folder    string        `json:"folder"`
+	CreatedAt time.Time     `json:"created_at"`
+	Post      *post.PostDTO `json:"post,omitempty"`
+}
+
+type FolderDTO struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+func MapBookmarkToDTO(bookmark *entities.Bookmark) *BookmarkDTO {
+	return &BookmarkDTO{
+		ID:        bookmark.ID,
+		PostID:    bookmark.PostID,
+		Folder:    bookmark.Folder,
+		CreatedAt: bookmark.CreatedAt,
+	}
+}
+
+func MapSavedPostToDTO(saved *bookmarksvc.SavedPost) *BookmarkDTO {
+	dto := MapBookmarkToDTO(saved.Bookmark)
+	dto.Post = post.MapPostToDTO(saved.Post)
+	return dto
+}
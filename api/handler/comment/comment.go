@@ -0,0 +1,152 @@
+package comment
+
+import (
+	"anchor-blog/api/handler"
+	commentsvc "anchor-blog/internal/service/comment"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CommentHandler struct {
+	commentService *commentsvc.CommentService
+}
+
+func NewCommentHandler(cs *commentsvc.CommentService) *CommentHandler {
+	return &CommentHandler{
+		commentService: cs,
+	}
+}
+
+type CreateCommentRequest struct {
+	Content  string `json:"content" binding:"required"`
+	ParentID string `json:"parent_id"`
+}
+
+type UpdateCommentRequest struct {
+	Content string `json:"content" binding:"required"`
+}
+
+// Create adds a comment or a reply to a post
+func (h *CommentHandler) Create(c *gin.Context) {
+	postID := c.Param("id")
+
+	var req CreateCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	comment, err := h.commentService.AddComment(c.Request.Context(), postID, userID.(string), req.ParentID, req.Content)
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, MapCommentToDTO(comment))
+}
+
+// ListByPost returns the top-level comments of a post
+func (h *CommentHandler) ListByPost(c *gin.Context) {
+	postID := c.Param("id")
+	pageStr := c.DefaultQuery("page", "1")
+	limitStr := c.DefaultQuery("limit", "20")
+
+	page, _ := strconv.ParseInt(pageStr, 10, 64)
+	limit, _ := strconv.ParseInt(limitStr, 10, 64)
+
+	comments, err := h.commentService.ListComments(c.Request.Context(), postID, c.GetString("user_id"), page, limit)
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+
+	res := make([]*CommentDTO, len(comments))
+	for idx, comment := range comments {
+		res[idx] = MapCommentToDTO(comment)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"comments": res,
+		"count":    len(res),
+		"page":     page,
+	})
+}
+
+// ListReplies returns the replies to a comment
+func (h *CommentHandler) ListReplies(c *gin.Context) {
+	commentID := c.Param("id")
+	pageStr := c.DefaultQuery("page", "1")
+	limitStr := c.DefaultQuery("limit", "20")
+
+	page, _ := strconv.ParseInt(pageStr, 10, 64)
+	limit, _ := strconv.ParseInt(limitStr, 10, 64)
+
+	replies, err := h.commentService.ListReplies(c.Request.Context(), commentID, c.GetString("user_id"), page, limit)
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+
+	res := make([]*CommentDTO, len(replies))
+	for idx, reply := range replies {
+		res[idx] = MapCommentToDTO(reply)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"replies": res,
+		"count":   len(res),
+		"page":    page,
+	})
+}
+
+// Update edits a comment owned by the current user
+func (h *CommentHandler) Update(c *gin.Context) {
+	commentID := c.Param("id")
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var req UpdateCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	comment, err := h.commentService.EditComment(c.Request.Context(), commentID, userID.(string), req.Content)
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, MapCommentToDTO(comment))
+}
+
+// Delete tombstones a comment owned by the current user
+func (h *CommentHandler) Delete(c *gin.Context) {
+	commentID := c.Param("id")
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	err := h.commentService.DeleteComment(c.Request.Context(), commentID, userID.(string))
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Comment deleted successfully"})
+}
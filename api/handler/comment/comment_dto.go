@@ -0,0 +1,37 @@
+package comment
+
+import (
+	"anchor-blog/internal/domain/entities"
+	"time"
+)
+
+type CommentDTO struct {
+	ID         string    `json:"id"`
+	PostID     string    `json:"post_id"`
+	AuthorID   string    `json:"author_id,omitempty"`
+	ParentID   string    `json:"parent_id,omitempty"`
+	Content    string    `json:"content"`
+	ReplyCount int       `json:"reply_count"`
+	Deleted    bool      `json:"deleted"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+func MapCommentToDTO(comment *entities.Comment) *CommentDTO {
+	dto := &CommentDTO{
+		ID:         comment.ID,
+		PostID:     comment.PostID,
+		AuthorID:   comment.AuthorID,
+		ParentID:   comment.ParentID,
+		Content:    comment.Content,
+		ReplyCount: comment.ReplyCount,
+		Deleted:    comment.Deleted,
+		CreatedAt:  comment.CreatedAt,
+		UpdatedAt:  comment.UpdatedAt,
+	}
+	// Tombstones don't reveal who wrote them
+	if comment.Deleted {
+		dto.AuthorID = ""
+	}
+	return dto
+}
@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"anchor-blog/internal/domain/entities"
+	AppError "anchor-blog/internal/errors"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PostETag is the strong entity tag of a post: its version, which changes with every edit,
+// followed by a digest of the counters that change without one, e.g. "4-9f86d081884c7d65".
+// extra is anything else the response shows, such as the post's series navigation.
+func PostETag(post *entities.Post, extra ...string) string {
+	h := sha256.New()
+	fmt.Fprintln(h, post.ViewCount, post.CommentCount, post.Reactions)
+	for _, part := range extra {
+		fmt.Fprintln(h, part)
+	}
+	return `"` + strconv.Itoa(post.Version) + "-" + hex.EncodeToString(h.Sum(nil)[:8]) + `"`
+}
+
+// PostListETag is the strong entity tag of a page of posts. It covers the order of the posts,
+// their edits and counters, and any other state of the page such as its cursors.
+// The second result is the time the newest of the posts was last updated.
+func PostListETag(posts []*entities.Post, extra ...string) (string, time.Time) {
+	h := sha256.New()
+	var lastModified time.Time
+	for _, post := range posts {
+		fmt.Fprintln(h, post.ID, post.Version, post.UpdatedAt.UnixNano(), post.ViewCount, post.CommentCount, post.Reactions)
+		if post.UpdatedAt.After(lastModified) {
+			lastModified = post.UpdatedAt
+		}
+	}
+	for _, part := range extra {
+		fmt.Fprintln(h, part)
+	}
+	return `"` + hex.EncodeToString(h.Sum(nil)[:16]) + `"`, lastModified
+}
+
+// CheckNotModified sets the validators of a response and answers 304 Not Modified when the
+// client's copy is still current. It returns true when the response has been written.
+// If-Modified-Since is honoured when the client sends no If-None-Match.
+func CheckNotModified(c *gin.Context, etag string, lastModified time.Time) bool {
+	if etag != "" {
+		c.Header("ETag", etag)
+	}
+	if !lastModified.IsZero() {
+		c.Header("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+	if NotModified(c.Request, etag, lastModified) {
+		c.Status(http.StatusNotModified)
+		return true
+	}
+	return false
+}
+
+// NotModified evaluates If-None-Match, falling back to If-Modified-Since only when no ETag was sent
+func NotModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		if etag == "" {
+			return false
+		}
+		for _, tag := range strings.Split(inm, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == "*" || tag == etag {
+				return true
+			}
+		}
+		return false
+	}
+
+	if lastModified.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	return !lastModified.Truncate(time.Second).After(since)
+}
+
+// ExpectedVersion returns the version a client based its edit on: the version an If-Match
+// entity tag starts with, or else the version field of the request body, which is nil when
+// the body has none. An If-Match that names no version can never match and fails with
+// ErrVersionConflict.
+func ExpectedVersion(c *gin.Context, bodyVersion *int) (int, error) {
+	ifMatch := strings.TrimSpace(c.GetHeader("If-Match"))
+	if ifMatch == "" || ifMatch == "*" {
+		if bodyVersion == nil {
+			return 0, AppError.ErrVersionRequired
+		}
+		if *bodyVersion < 0 {
+			return 0, AppError.ErrValidationFailed
+		}
+		return *bodyVersion, nil
+	}
+
+	// Weak tags don't take part in If-Match comparisons
+	if len(ifMatch) < 3 || !strings.HasPrefix(ifMatch, `"`) || !strings.HasSuffix(ifMatch, `"`) {
+		return 0, AppError.ErrVersionConflict
+	}
+	tag := ifMatch[1 : len(ifMatch)-1]
+	if i := strings.IndexByte(tag, '-'); i >= 0 {
+		tag = tag[:i]
+	}
+	version, err := strconv.Atoi(tag)
+	if err != nil || version < 0 {
+		return 0, AppError.ErrVersionConflict
+	}
+	return version, nil
+}
@@ -0,0 +1,203 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"anchor-blog/internal/domain/entities"
+	AppError "anchor-blog/internal/errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostETag(t *testing.T) {
+	post := &entities.Post{ID: "post-1", Version: 4, ViewCount: 10, Reactions: map[string]int{"like": 1}}
+	etag := PostETag(post)
+
+	tests := []struct {
+		name   string
+		change func(p *entities.Post)
+		same   bool
+	}{
+		{"unchanged", func(p *entities.Post) {}, true},
+		{"title outside the tag", func(p *entities.Post) { p.Title = "Renamed" }, true},
+		{"new version", func(p *entities.Post) { p.Version++ }, false},
+		{"new view", func(p *entities.Post) { p.ViewCount++ }, false},
+		{"new comment", func(p *entities.Post) { p.CommentCount++ }, false},
+		{"new reaction", func(p *entities.Post) { p.Reactions = map[string]int{"like": 2} }, false},
+	}
+
+	assert.Equal(t, etag, PostETag(post), "no extra parts")
+	assert.NotEqual(t, etag, PostETag(post, "series-1", "1", "3"), "series navigation")
+	assert.NotEqual(t, PostETag(post, "series-1", "1", "3"), PostETag(post, "series-1", "1", "4"), "another part published")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changed := *post
+			tt.change(&changed)
+
+			assert.Equal(t, tt.same, PostETag(&changed) == etag)
+			assert.Regexp(t, `^"\d+-[0-9a-f]{16}"$`, PostETag(&changed))
+		})
+	}
+}
+
+func TestPostListETag(t *testing.T) {
+	updatedAt := time.Date(2025, 8, 9, 10, 30, 0, 0, time.UTC)
+	first := &entities.Post{ID: "post-1", Version: 1, UpdatedAt: updatedAt}
+	second := &entities.Post{ID: "post-2", Version: 3, UpdatedAt: updatedAt.Add(time.Hour)}
+	etag, lastModified := PostListETag([]*entities.Post{first, second}, "next", "")
+
+	assert.Equal(t, second.UpdatedAt, lastModified, "the newest update on the page")
+
+	tests := []struct {
+		name  string
+		posts []*entities.Post
+		extra []string
+		same  bool
+	}{
+		{"same page", []*entities.Post{first, second}, []string{"next", ""}, true},
+		{"other order", []*entities.Post{second, first}, []string{"next", ""}, false},
+		{"post left the page", []*entities.Post{first}, []string{"next", ""}, false},
+		{"other cursor", []*entities.Post{first, second}, []string{"", ""}, false},
+		{"edited post", []*entities.Post{first, {ID: "post-2", Version: 4, UpdatedAt: second.UpdatedAt}}, []string{"next", ""}, false},
+		{"new view", []*entities.Post{first, {ID: "post-2", Version: 3, UpdatedAt: second.UpdatedAt, ViewCount: 1}}, []string{"next", ""}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _ := PostListETag(tt.posts, tt.extra...)
+			assert.Equal(t, tt.same, got == etag)
+		})
+	}
+}
+
+func TestNotModified(t *testing.T) {
+	etag := `"4-9f86d081884c7d65"`
+	lastModified := time.Date(2025, 8, 9, 10, 30, 0, 500, time.UTC)
+
+	tests := []struct {
+		name         string
+		headers      map[string]string
+		etag         string
+		lastModified time.Time
+		want         bool
+	}{
+		{"no validators", nil, etag, lastModified, false},
+		{"matching tag", map[string]string{"If-None-Match": etag}, etag, lastModified, true},
+		{"other tag", map[string]string{"If-None-Match": `"3-9f86d081884c7d65"`}, etag, lastModified, false},
+		{"tag in a list", map[string]string{"If-None-Match": `"1-aa", ` + etag + ` ,"2-bb"`}, etag, lastModified, true},
+		{"weak tag", map[string]string{"If-None-Match": "W/" + etag}, etag, lastModified, true},
+		{"weak tag in a list", map[string]string{"If-None-Match": `"1-aa", W/` + etag}, etag, lastModified, true},
+		{"any tag", map[string]string{"If-None-Match": "*"}, etag, lastModified, true},
+		{"tag without a current tag", map[string]string{"If-None-Match": "*"}, "", lastModified, false},
+		{"tag wins over date", map[string]string{
+			"If-None-Match":     `"3-9f86d081884c7d65"`,
+			"If-Modified-Since": lastModified.Add(time.Hour).Format(http.TimeFormat),
+		}, etag, lastModified, false},
+		{"date at last change", map[string]string{"If-Modified-Since": lastModified.Format(http.TimeFormat)}, etag, lastModified, true},
+		{"date after last change", map[string]string{"If-Modified-Since": lastModified.Add(time.Hour).Format(http.TimeFormat)}, etag, lastModified, true},
+		{"date before last change", map[string]string{"If-Modified-Since": lastModified.Add(-time.Second).Format(http.TimeFormat)}, etag, lastModified, false},
+		{"date without a last change", map[string]string{"If-Modified-Since": lastModified.Format(http.TimeFormat)}, etag, time.Time{}, false},
+		{"invalid date", map[string]string{"If-Modified-Since": "yesterday"}, etag, lastModified, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/posts/post-1", nil)
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+
+			assert.Equal(t, tt.want, NotModified(req, tt.etag, tt.lastModified))
+		})
+	}
+}
+
+func TestCheckNotModified(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	etag := `"4-9f86d081884c7d65"`
+	lastModified := time.Date(2025, 8, 9, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name             string
+		ifNoneMatch      string
+		ifModifiedSince  string
+		lastModified     time.Time
+		wantWritten      bool
+		wantStatus       int
+		wantLastModified string
+	}{
+		{"current copy", etag, "", lastModified, true, http.StatusNotModified, "Sat, 09 Aug 2025 10:30:00 GMT"},
+		{"stale copy", `"3-9f86d081884c7d65"`, "", lastModified, false, http.StatusOK, "Sat, 09 Aug 2025 10:30:00 GMT"},
+		{"no last change", etag, "", time.Time{}, true, http.StatusNotModified, ""},
+		{"unchanged since", "", "Sat, 09 Aug 2025 10:30:00 GMT", lastModified, true, http.StatusNotModified, "Sat, 09 Aug 2025 10:30:00 GMT"},
+		{"changed since", "", "Sat, 09 Aug 2025 10:29:59 GMT", lastModified, false, http.StatusOK, "Sat, 09 Aug 2025 10:30:00 GMT"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/posts/post-1", nil)
+			if tt.ifNoneMatch != "" {
+				c.Request.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			if tt.ifModifiedSince != "" {
+				c.Request.Header.Set("If-Modified-Since", tt.ifModifiedSince)
+			}
+
+			// Execute
+			written := CheckNotModified(c, etag, tt.lastModified)
+			c.Writer.WriteHeaderNow()
+
+			// Assert
+			assert.Equal(t, tt.wantWritten, written)
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, etag, w.Header().Get("ETag"))
+			assert.Equal(t, tt.wantLastModified, w.Header().Get("Last-Modified"))
+		})
+	}
+}
+
+func TestExpectedVersion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	version := 2
+	negative := -1
+
+	tests := []struct {
+		name        string
+		ifMatch     string
+		bodyVersion *int
+		want        int
+		wantErr     error
+	}{
+		{"body version", "", &version, 2, nil},
+		{"no version", "", nil, 0, AppError.ErrVersionRequired},
+		{"negative body version", "", &negative, 0, AppError.ErrValidationFailed},
+		{"any tag uses the body", "*", &version, 2, nil},
+		{"post tag", `"4-9f86d081884c7d65"`, &version, 4, nil},
+		{"bare version", `"7"`, nil, 7, nil},
+		{"weak tag", `W/"4-9f86d081884c7d65"`, nil, 0, AppError.ErrVersionConflict},
+		{"tag without a version", `"abc"`, nil, 0, AppError.ErrVersionConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodPut, "/posts/post-1", nil)
+			if tt.ifMatch != "" {
+				c.Request.Header.Set("If-Match", tt.ifMatch)
+			}
+
+			got, err := ExpectedVersion(c, tt.bodyVersion)
+
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package feed
+
+import (
+	"anchor-blog/api/handler"
+	feedsvc "anchor-blog/internal/service/feed"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FeedHandler struct {
+	feedService *feedsvc.FeedService
+}
+
+func NewFeedHandler(fs *feedsvc.FeedService) *FeedHandler {
+	return &FeedHandler{
+		feedService: fs,
+	}
+}
+
+// Site serves the feed of all published posts
+func (h *FeedHandler) Site(c *gin.Context) {
+	h.serve(c, feedsvc.Request{})
+}
+
+// Tag serves the feed of the posts carrying a tag
+func (h *FeedHandler) Tag(c *gin.Context) {
+	h.serve(c, feedsvc.Request{Tag: c.Param("tag")})
+}
+
+// Author serves the feed of an author's posts; the author is given by username or user id
+func (h *FeedHandler) Author(c *gin.Context) {
+	h.serve(c, feedsvc.Request{Author: c.Param("author")})
+}
+
+func (h *FeedHandler) serve(c *gin.Context, req feedsvc.Request) {
+	req.Format = c.Param("format")
+	req.Content = c.Query("content")
+	req.Origin = handler.RequestOrigin(c)
+	req.SelfURL = req.Origin + c.Request.URL.RequestURI()
+
+	doc, err := h.feedService.Generate(c.Request.Context(), req)
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+
+	if handler.CheckNotModified(c, doc.ETag, doc.LastModified) {
+		return
+	}
+
+	c.Data(http.StatusOK, doc.ContentType, doc.Body)
+}
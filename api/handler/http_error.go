@@ -15,6 +15,9 @@ type ErrorResponse struct {
 }
 
 func HandleHttpError(c *gin.Context, err error) {
+	// Errors must not be cached under the route's Cache-Control policy
+	c.Writer.Header().Del("Cache-Control")
+
 	switch {
 	case errors.Is(err, AppError.ErrNotFound),
 		errors.Is(err, AppError.ErrUserNotFound):
@@ -23,16 +26,46 @@ func HandleHttpError(c *gin.Context, err error) {
 
 	case errors.Is(err, AppError.ErrInvalidUserID),
 		errors.Is(err, AppError.ErrInvalidPostID),
+		errors.Is(err, AppError.ErrInvalidCommentID),
+		errors.Is(err, AppError.ErrInvalidCursor),
+		errors.Is(err, AppError.ErrInvalidSeriesID),
+		errors.Is(err, AppError.ErrInvalidImportJobID),
+		errors.Is(err, AppError.ErrInvalidReportID),
+		errors.Is(err, AppError.ErrInvalidMediaID),
 		errors.Is(err, AppError.ErrValidationFailed),
 		errors.Is(err, AppError.ErrInvalidToken):
 
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 
 	case errors.Is(err, AppError.ErrEmailAlreadyExists),
-		errors.Is(err, AppError.ErrUsernameTaken):
+		errors.Is(err, AppError.ErrUsernameTaken),
+		errors.Is(err, AppError.ErrTagExists),
+		errors.Is(err, AppError.ErrPostInSeries),
+		errors.Is(err, AppError.ErrImportJobRunning),
+		errors.Is(err, AppError.ErrAlreadyCollaborator),
+		errors.Is(err, AppError.ErrAlreadyReported),
+		errors.Is(err, AppError.ErrReportClosed),
+		errors.Is(err, AppError.ErrMediaInUse),
+		errors.Is(err, AppError.ErrInvalidStatusTransition):
 
 		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
 
+	case errors.Is(err, AppError.ErrVersionConflict):
+
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+
+	case errors.Is(err, AppError.ErrVersionRequired):
+
+		c.JSON(http.StatusPreconditionRequired, gin.H{"error": err.Error()})
+
+	case errors.Is(err, AppError.ErrMediaTooLarge):
+
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+
+	case errors.Is(err, AppError.ErrUnsupportedMediaType):
+
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+
 	case errors.Is(err, AppError.ErrInvalidCredentials),
 		errors.Is(err, AppError.ErrUnauthorized):
 
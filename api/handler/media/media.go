@@ -0,0 +1,151 @@
+package media
+
+import (
+	"anchor-blog/api/handler"
+	mediasvc "anchor-blog/internal/service/media"
+	"errors"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MediaHandler struct {
+	mediaService *mediasvc.MediaService
+}
+
+func NewMediaHandler(ms *mediasvc.MediaService) *MediaHandler {
+	return &MediaHandler{
+		mediaService: ms,
+	}
+}
+
+// Upload adds an image to the current user's media library; the file comes in the "file" form field
+func (h *MediaHandler) Upload(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	// Leave room for the multipart framing around the file
+	maxSize := h.mediaService.MaxUploadSize()
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSize+1<<20)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "File is too large"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A file is required in the 'file' field"})
+		return
+	}
+	if fileHeader.Size > maxSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "File is too large"})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Could not read the uploaded file"})
+		return
+	}
+	defer file.Close()
+
+	media, err := h.mediaService.Upload(c.Request.Context(), userID.(string), fileHeader.Filename, file)
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, MapMediaToDTO(media, h.mediaService))
+}
+
+// ListMedia returns the current user's media library, newest first
+func (h *MediaHandler) ListMedia(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	page, _ := strconv.ParseInt(c.DefaultQuery("page", "1"), 10, 64)
+	limit, _ := strconv.ParseInt(c.DefaultQuery("limit", "20"), 10, 64)
+
+	library, err := h.mediaService.ListMedia(c.Request.Context(), userID.(string), page, limit)
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+
+	res := make([]*MediaDTO, len(library))
+	for idx, media := range library {
+		res[idx] = MapMediaToDTO(media, h.mediaService)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"media": res,
+		"count": len(res),
+		"page":  page,
+	})
+}
+
+// GetMedia returns one item of the current user's media library
+func (h *MediaHandler) GetMedia(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	media, err := h.mediaService.GetMedia(c.Request.Context(), c.Param("id"), userID.(string))
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, MapMediaToDTO(media, h.mediaService))
+}
+
+// DeleteMedia removes an item of the media library unless a post still uses it
+func (h *MediaHandler) DeleteMedia(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	if err := h.mediaService.DeleteMedia(c.Request.Context(), c.Param("id"), userID.(string)); err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Media deleted"})
+}
+
+// ServeFile streams a stored file. Keys are never reused, so the files can be cached forever.
+func (h *MediaHandler) ServeFile(c *gin.Context) {
+	key := strings.TrimPrefix(c.Param("key"), "/")
+
+	file, err := h.mediaService.Open(c.Request.Context(), key)
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+	defer file.Close()
+
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	c.DataFromReader(http.StatusOK, -1, contentType, file, map[string]string{
+		"Cache-Control":          "public, max-age=31536000, immutable",
+		"X-Content-Type-Options": "nosniff",
+	})
+}
@@ -0,0 +1,55 @@
+package media
+
+import (
+	"anchor-blog/internal/domain/entities"
+	mediasvc "anchor-blog/internal/service/media"
+	"time"
+)
+
+type MediaDTO struct {
+	ID          string                  `json:"id"`
+	Filename    string                  `json:"filename"`
+	ContentType string                  `json:"content_type"`
+	Size        int64                   `json:"size"`
+	Width       int                     `json:"width,omitempty"`
+	Height      int                     `json:"height,omitempty"`
+	URL         string                  `json:"url"`
+	Thumbnails  map[string]ThumbnailDTO `json:"thumbnails"`
+	UsedBy      []string                `json:"used_by"`
+	CreatedAt   time.Time               `json:"created_at"`
+}
+
+type ThumbnailDTO struct {
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+func MapMediaToDTO(media *entities.Media, ms *mediasvc.MediaService) *MediaDTO {
+	thumbnails := make(map[string]ThumbnailDTO, len(media.Thumbnails))
+	for _, thumbnail := range media.Thumbnails {
+		thumbnails[thumbnail.Name] = ThumbnailDTO{
+			URL:    ms.URL(thumbnail.Key),
+			Width:  thumbnail.Width,
+			Height: thumbnail.Height,
+		}
+	}
+
+	usedBy := media.PostIDs
+	if usedBy == nil {
+		usedBy = []string{}
+	}
+
+	return &MediaDTO{
+		ID:          media.ID,
+		Filename:    media.Filename,
+		ContentType: media.ContentType,
+		Size:        media.Size,
+		Width:       media.Width,
+		Height:      media.Height,
+		URL:         ms.URL(media.Key),
+		Thumbnails:  thumbnails,
+		UsedBy:      usedBy,
+		CreatedAt:   media.CreatedAt,
+	}
+}
@@ -0,0 +1,121 @@
+package moderation
+
+import (
+	"anchor-blog/api/handler"
+	moderationsvc "anchor-blog/internal/service/moderation"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ModerationHandler struct {
+	moderationService *moderationsvc.ModerationService
+}
+
+// NewModerationHandler creates the handler for post reports and the moderation queue
+func NewModerationHandler(ms *moderationsvc.ModerationService) *ModerationHandler {
+	return &ModerationHandler{moderationService: ms}
+}
+
+// ReportPost flags a post for the moderators
+func (h *ModerationHandler) ReportPost(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var req ReportPostRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := h.moderationService.ReportPost(c.Request.Context(), c.Param("id"), userID.(string), req.Reason, req.Details)
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, MapReportToDTO(report))
+}
+
+// ListReports returns the moderation queue, oldest first. status narrows it to open, dismissed or actioned reports.
+func (h *ModerationHandler) ListReports(c *gin.Context) {
+	status := c.Query("status")
+	page, _ := strconv.ParseInt(c.DefaultQuery("page", "1"), 10, 64)
+	limit, _ := strconv.ParseInt(c.DefaultQuery("limit", "20"), 10, 64)
+
+	reports, err := h.moderationService.ListReports(c.Request.Context(), status, page, limit)
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+
+	res := make([]*ReportDTO, len(reports))
+	for idx, report := range reports {
+		res[idx] = MapReportToDTO(report)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"reports": res,
+		"count":   len(res),
+		"page":    page,
+		"status":  status,
+	})
+}
+
+// GetReport returns a single report
+func (h *ModerationHandler) GetReport(c *gin.Context) {
+	report, err := h.moderationService.GetReport(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, MapReportToDTO(report))
+}
+
+// ResolveReport acts on the post of an open report and closes all open reports of that post
+func (h *ModerationHandler) ResolveReport(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var req ResolveReportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	action, err := h.moderationService.Resolve(c.Request.Context(), c.Param("id"), userID.(string), req.Action, req.Note)
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, MapModerationActionToDTO(action))
+}
+
+// ListActions returns the moderation log, newest first, optionally for one post_id or author_id
+func (h *ModerationHandler) ListActions(c *gin.Context) {
+	page, _ := strconv.ParseInt(c.DefaultQuery("page", "1"), 10, 64)
+	limit, _ := strconv.ParseInt(c.DefaultQuery("limit", "20"), 10, 64)
+
+	actions, err := h.moderationService.ListActions(c.Request.Context(), c.Query("post_id"), c.Query("author_id"), page, limit)
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+
+	res := make([]*ModerationActionDTO, len(actions))
+	for idx, action := range actions {
+		res[idx] = MapModerationActionToDTO(action)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"actions": res,
+		"count":   len(res),
+		"page":    page,
+	})
+}
@@ -0,0 +1,70 @@
+package moderation
+
+import (
+	"anchor-blog/internal/domain/entities"
+	"time"
+)
+
+type ReportPostRequest struct {
+	Reason  string `json:"reason" binding:"required"` // spam, harassment, hate, illegal or other
+	Details string `json:"details"`                   // required for other
+}
+
+type ResolveReportRequest struct {
+	Action string `json:"action" binding:"required"` // dismiss, hide, delete or warn
+	Note   string `json:"note"`
+}
+
+type ReportDTO struct {
+	ID          string     `json:"id"`
+	PostID      string     `json:"post_id"`
+	ReporterID  string     `json:"reporter_id"`
+	Reason      string     `json:"reason"`
+	Details     string     `json:"details,omitempty"`
+	Status      string     `json:"status"`
+	ModeratorID string     `json:"moderator_id,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ResolvedAt  *time.Time `json:"resolved_at,omitempty"`
+}
+
+type ModerationActionDTO struct {
+	ID          string    `json:"id"`
+	ReportID    string    `json:"report_id,omitempty"`
+	PostID      string    `json:"post_id"`
+	AuthorID    string    `json:"author_id"`
+	ModeratorID string    `json:"moderator_id,omitempty"` // empty for automatic actions
+	Action      string    `json:"action"`
+	Note        string    `json:"note,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func MapReportToDTO(report *entities.Report) *ReportDTO {
+	dto := &ReportDTO{
+		ID:          report.ID,
+		PostID:      report.PostID,
+		ReporterID:  report.ReporterID,
+		Reason:      report.Reason,
+		Details:     report.Details,
+		Status:      report.Status,
+		ModeratorID: report.ModeratorID,
+		CreatedAt:   report.CreatedAt,
+	}
+	if !report.ResolvedAt.IsZero() {
+		resolvedAt := report.ResolvedAt
+		dto.ResolvedAt = &resolvedAt
+	}
+	return dto
+}
+
+func MapModerationActionToDTO(action *entities.ModerationAction) *ModerationActionDTO {
+	return &ModerationActionDTO{
+		ID:          action.ID,
+		ReportID:    action.ReportID,
+		PostID:      action.PostID,
+		AuthorID:    action.AuthorID,
+		ModeratorID: action.ModeratorID,
+		Action:      action.Action,
+		Note:        action.Note,
+		CreatedAt:   action.CreatedAt,
+	}
+}
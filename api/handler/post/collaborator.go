@@ -0,0 +1,77 @@
+package post
+
+import (
+	"anchor-blog/api/handler"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CollaboratorRequest names the user to invite or hand a post over to
+type CollaboratorRequest struct {
+	User string `json:"user" binding:"required"` // user id or username
+}
+
+// AddCollaborator invites a user to co-author a post; only the owner can do this
+func (h *PostHandler) AddCollaborator(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var req CollaboratorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	post, err := h.postService.AddCollaborator(c.Request.Context(), c.Param("id"), userID.(string), req.User)
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, MapPostToDTO(post))
+}
+
+// RemoveCollaborator takes a collaborator off a post.
+// The owner can remove anyone; a co-author can remove themselves to leave the post.
+func (h *PostHandler) RemoveCollaborator(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	post, err := h.postService.RemoveCollaborator(c.Request.Context(), c.Param("id"), userID.(string), c.Param("userId"))
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, MapPostToDTO(post))
+}
+
+// TransferOwnership hands a post over to another user; the previous owner stays on as a co-author
+func (h *PostHandler) TransferOwnership(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var req CollaboratorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	post, err := h.postService.TransferOwnership(c.Request.Context(), c.Param("id"), userID.(string), req.User)
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, MapPostToDTO(post))
+}
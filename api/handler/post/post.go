@@ -3,12 +3,24 @@ package post
 import (
 	"anchor-blog/api/handler"
 	"anchor-blog/internal/domain/entities"
+	AppError "anchor-blog/internal/errors"
+	bookmarksvc "anchor-blog/internal/service/bookmark"
+	commentsvc "anchor-blog/internal/service/comment"
 	postsvc "anchor-blog/internal/service/post"
+	reactionsvc "anchor-blog/internal/service/reaction"
+	relatedsvc "anchor-blog/internal/service/related"
+	revisionsvc "anchor-blog/internal/service/revision"
+	seriessvc "anchor-blog/internal/service/series"
+	tagsvc "anchor-blog/internal/service/tag"
+	trendingsvc "anchor-blog/internal/service/trending"
 	viewsvc "anchor-blog/internal/service/view"
 	"anchor-blog/pkg/utils"
+	"errors"
+	"log"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -16,19 +28,55 @@ import (
 type PostHandler struct {
 	postService         *postsvc.PostService
 	viewTrackingService *viewsvc.ViewTrackingService
+	commentService      *commentsvc.CommentService
+	revisionService     *revisionsvc.RevisionService
+	tagService          *tagsvc.TagService
+	seriesService       *seriessvc.SeriesService
+	bookmarkService     *bookmarksvc.BookmarkService
+	reactionService     *reactionsvc.ReactionService
+	trendingService     *trendingsvc.TrendingService
+	relatedService      *relatedsvc.RelatedService
 }
 
-func NewPostHandler(ps *postsvc.PostService, vts *viewsvc.ViewTrackingService) *PostHandler {
+func NewPostHandler(ps *postsvc.PostService, vts *viewsvc.ViewTrackingService, cs *commentsvc.CommentService, rs *revisionsvc.RevisionService, ts *tagsvc.TagService, ss *seriessvc.SeriesService, bs *bookmarksvc.BookmarkService, rcs *reactionsvc.ReactionService, trs *trendingsvc.TrendingService, rls *relatedsvc.RelatedService) *PostHandler {
 	return &PostHandler{
 		postService:         ps,
 		viewTrackingService: vts,
+		commentService:      cs,
+		revisionService:     rs,
+		tagService:          ts,
+		seriesService:       ss,
+		bookmarkService:     bs,
+		reactionService:     rcs,
+		trendingService:     trs,
+		relatedService:      rls,
 	}
 }
 
 type CreatePostRequest struct {
-	Title   string   `json:"title" binding:"required"`
-	Content string   `json:"content" binding:"required"`
-	Tags    []string `json:"tags"`
+	Title      string     `json:"title" binding:"required"`
+	Content    string     `json:"content" binding:"required"`
+	Tags       []string   `json:"tags"`
+	Status     string     `json:"status"`     // published (default), draft or scheduled; ignored on update
+	PublishAt  *time.Time `json:"publish_at"` // required when status is scheduled
+	Visibility string     `json:"visibility"` // public (default), unlisted, private or members-only; ignored on update
+	Version    *int       `json:"version"`    // on update, the version being edited unless If-Match is sent
+}
+
+type DeletePostRequest struct {
+	Version *int `json:"version"` // the version being deleted unless If-Match is sent
+}
+
+type RestoreRevisionRequest struct {
+	Version *int `json:"version"` // the version being replaced unless If-Match is sent
+}
+
+type PublishPostRequest struct {
+	PublishAt *time.Time `json:"publish_at"` // optional, schedules the post when in the future
+}
+
+type VisibilityRequest struct {
+	Visibility string `json:"visibility" binding:"required"` // public, unlisted, private or members-only
 }
 
 func (h *PostHandler) Create(c *gin.Context) {
@@ -45,22 +93,94 @@ func (h *PostHandler) Create(c *gin.Context) {
 		return
 	}
 
-	post, err := h.postService.CreatePost(c.Request.Context(), req.Title, req.Content, authorIDHex.(string), req.Tags)
+	tags, ok := h.resolveTags(c, req.Tags)
+	if !ok {
+		return
+	}
+	req.Tags = tags
+
+	var post *entities.Post
+	var err error
+	switch req.Status {
+	case "", entities.PostStatusPublished:
+		post, err = h.postService.CreatePost(c.Request.Context(), req.Title, req.Content, authorIDHex.(string), req.Tags, req.Visibility)
+	case entities.PostStatusDraft:
+		post, err = h.postService.CreateDraft(c.Request.Context(), req.Title, req.Content, authorIDHex.(string), req.Tags, time.Time{}, req.Visibility)
+	case entities.PostStatusScheduled:
+		if req.PublishAt == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "publish_at is required for scheduled posts"})
+			return
+		}
+		post, err = h.postService.CreateDraft(c.Request.Context(), req.Title, req.Content, authorIDHex.(string), req.Tags, *req.PublishAt, req.Visibility)
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "status must be one of published, draft or scheduled"})
+		return
+	}
 	if err != nil {
 		handler.HandleHttpError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusCreated, post)
+	c.Header("ETag", handler.PostETag(post))
+	c.JSON(http.StatusCreated, MapPostToDTO(post))
 }
 
 func (h *PostHandler) GetByID(c *gin.Context) {
 	postID := c.Param("id")
 
+	post, err := h.postService.GetPostByID(c.Request.Context(), postID)
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+
+	h.respondWithPost(c, post)
+}
+
+// GetBySlug resolves a permalink. Historical slugs answer with a redirect to the current one.
+func (h *PostHandler) GetBySlug(c *gin.Context) {
+	slug := c.Param("slug")
+
+	post, err := h.postService.GetPostBySlug(c.Request.Context(), slug)
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+
+	if post.Slug != slug && post.IsPublished() {
+		location := "/api/v1/posts/by-slug/" + post.Slug
+		c.Header("Location", location)
+		c.JSON(http.StatusMovedPermanently, gin.H{
+			"redirect_to": post.Slug,
+			"location":    location,
+			"post_id":     post.ID,
+		})
+		return
+	}
+
+	h.respondWithPost(c, post)
+}
+
+// respondWithPost writes a post the reader may see and counts the view
+func (h *PostHandler) respondWithPost(c *gin.Context, post *entities.Post) {
+	// Drafts, scheduled, archived and hidden posts are not public; private and
+	// members-only posts need a signed-in reader, set by the optional auth middleware
+	viewerID := c.GetString("user_id")
+	if err := h.postService.CheckAccess(c.Request.Context(), post, viewerID); err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+
+	format := c.DefaultQuery("format", "markdown")
+	if format != "markdown" && format != "html" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be markdown or html"})
+		return
+	}
+
 	// Track the view with IP-based throttling
 	if h.viewTrackingService != nil {
 		clientIP := utils.GetClientIP(c)
-		err := h.viewTrackingService.TrackView(c.Request.Context(), postID, clientIP)
+		err := h.viewTrackingService.TrackView(c.Request.Context(), post.ID, clientIP)
 		if err != nil {
 			// Log the error but don't fail the request
 			// View tracking is not critical for post retrieval
@@ -68,33 +188,49 @@ func (h *PostHandler) GetByID(c *gin.Context) {
 		}
 	}
 
-	post, err := h.postService.GetPostByID(c.Request.Context(), postID)
-	if err != nil {
-		handler.HandleHttpError(c, err)
+	// Series navigation is optional, the post is served without it on errors
+	var series *SeriesNavigationDTO
+	if h.seriesService != nil {
+		nav, err := h.seriesService.GetNavigation(c.Request.Context(), post)
+		if err != nil {
+			log.Printf("Error loading series navigation of post %s: %v", post.ID, err)
+		} else if nav != nil {
+			series = MapNavigationToDTO(nav)
+		}
+	}
+
+	// A reader with the current copy gets 304; the view above still counts.
+	// The navigation is part of the tag, as it changes when other parts do.
+	if handler.CheckNotModified(c, handler.PostETag(post, series.etagParts()...), post.UpdatedAt) {
 		return
 	}
 
-	c.JSON(http.StatusOK, post)
+	if format == "html" {
+		post = h.postService.EnsureRendered(c.Request.Context(), post)
+		dto := MapPostToRenderedDTO(post)
+		dto.Series = series
+		c.JSON(http.StatusOK, dto)
+		return
+	}
+	dto := MapPostToDTO(post)
+	dto.Series = series
+	c.JSON(http.StatusOK, dto)
 }
 
 func (h *PostHandler) List(c *gin.Context) {
-	pageStr := c.DefaultQuery("page", "1")
-	limitStr := c.DefaultQuery("limit", "10")
-
-	page, _ := strconv.ParseInt(pageStr, 10, 64)
-	limit, _ := strconv.ParseInt(limitStr, 10, 64)
-
-	posts, err := h.postService.ListPosts(c.Request.Context(), page, limit)
+	req := pageRequestFromQuery(c)
+	page, err := h.postService.ListPosts(c.Request.Context(), req)
 	if err != nil {
 		handler.HandleHttpError(c, err)
 		return
 	}
-	res := make([]*PostDTO, len(posts))
-	for idx, post := range posts {
-		res[idx] = MapPostToDTO(post)
-	}
 
-	c.JSON(http.StatusOK, posts)
+	if req.Cursor != "" {
+		respondWithPage(c, page, postPageResponse(page))
+		return
+	}
+	// page/limit requests keep the bare array older clients expect
+	respondWithPage(c, page, postDTOs(page.Posts))
 }
 
 // GetPopularPosts returns posts ordered by view count
@@ -106,15 +242,64 @@ func (h *PostHandler) GetPopularPosts(c *gin.Context) {
 		limit = 10
 	}
 
-	posts, err := h.viewTrackingService.GetPopularPosts(c.Request.Context(), limit)
+	var page *postsvc.PostPage
+	if cursor := c.Query("cursor"); cursor != "" {
+		criteria := postsvc.SearchCriteria{Sort: []string{entities.SortViews}}
+		req := postsvc.PageRequest{Limit: int64(limit), Cursor: cursor}
+		var err error
+		if page, err = h.postService.QueryPosts(c.Request.Context(), criteria, req); err != nil {
+			handler.HandleHttpError(c, err)
+			return
+		}
+	} else {
+		posts, err := h.viewTrackingService.GetPopularPosts(c.Request.Context(), limit)
+		if err != nil {
+			handler.HandleHttpError(c, err)
+			return
+		}
+		page = postsvc.FirstPage(posts, []string{entities.SortViews}, int64(limit))
+	}
+
+	respondWithPage(c, page, postPageResponse(page))
+}
+
+// GetTrendingPosts returns the posts ranked by recent activity within a window
+func (h *PostHandler) GetTrendingPosts(c *gin.Context) {
+	page, limit := pageAndLimit(c, 10)
+
+	trending, err := h.trendingService.GetTrending(c.Request.Context(), c.Query("window"), page, limit)
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+
+	res := make([]*TrendingPostDTO, len(trending.Posts))
+	for i, p := range trending.Posts {
+		res[i] = MapTrendingPostToDTO(p)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"posts":       res,
+		"count":       len(res),
+		"page":        page,
+		"window":      trending.Window,
+		"computed_at": trending.ComputedAt,
+	})
+}
+
+// GetRelatedPosts suggests posts to read after the given one
+func (h *PostHandler) GetRelatedPosts(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "5"))
+
+	posts, err := h.relatedService.GetRelated(c.Request.Context(), c.Param("id"), c.GetString("user_id"), limit)
 	if err != nil {
 		handler.HandleHttpError(c, err)
 		return
 	}
 
 	res := make([]*PostDTO, len(posts))
-	for idx, post := range posts {
-		res[idx] = MapPostToDTO(post)
+	for i, p := range posts {
+		res[i] = MapPostToDTO(p)
 	}
 
 	c.JSON(http.StatusOK, gin.H{
@@ -136,10 +321,20 @@ func (h *PostHandler) GetViewStats(c *gin.Context) {
 	})
 }
 
-// GetPostViewCount returns the view count for a specific post
+// GetPostViewCount returns the view count of a post the reader may see
 func (h *PostHandler) GetPostViewCount(c *gin.Context) {
 	postID := c.Param("id")
 
+	post, err := h.postService.GetPostByID(c.Request.Context(), postID)
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+	if err := h.postService.CheckAccess(c.Request.Context(), post, c.GetString("user_id")); err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+
 	viewCount, err := h.viewTrackingService.GetViewCount(c.Request.Context(), postID)
 	if err != nil {
 		handler.HandleHttpError(c, err)
@@ -169,24 +364,37 @@ func (h *PostHandler) UpdatePost(c *gin.Context) {
 		return
 	}
 
-	// Check if user owns the post (basic authorization)
+	// The owner and co-authors can edit
 	existingPost, err := h.postService.GetPostByID(c.Request.Context(), postID)
 	if err != nil {
 		handler.HandleHttpError(c, err)
 		return
 	}
 
-	if existingPost.AuthorID != userID.(string) {
-		c.JSON(http.StatusForbidden, gin.H{"error": "You can only update your own posts"})
+	if !existingPost.CanEdit(userID.(string)) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You can only update posts you own or co-author"})
 		return
 	}
 
-	updatedPost, err := h.postService.UpdatePost(c.Request.Context(), postID, req.Title, req.Content, req.Tags)
+	// The edit must be based on the current version so it doesn't overwrite someone else's
+	version, err := handler.ExpectedVersion(c, req.Version)
 	if err != nil {
-		handler.HandleHttpError(c, err)
+		h.handleWriteError(c, postID, err)
+		return
+	}
+
+	tags, ok := h.resolveTags(c, req.Tags)
+	if !ok {
 		return
 	}
 
+	updatedPost, err := h.postService.UpdatePost(c.Request.Context(), postID, userID.(string), req.Title, req.Content, tags, version)
+	if err != nil {
+		h.handleWriteError(c, postID, err)
+		return
+	}
+
+	c.Header("ETag", handler.PostETag(updatedPost))
 	c.JSON(http.StatusOK, MapPostToDTO(updatedPost))
 }
 
@@ -201,91 +409,222 @@ func (h *PostHandler) DeletePost(c *gin.Context) {
 		return
 	}
 
-	// Check if user owns the post (basic authorization)
+	// Only the owner can delete
 	existingPost, err := h.postService.GetPostByID(c.Request.Context(), postID)
 	if err != nil {
 		handler.HandleHttpError(c, err)
 		return
 	}
 
-	if existingPost.AuthorID != userID.(string) {
-		c.JSON(http.StatusForbidden, gin.H{"error": "You can only delete your own posts"})
+	if !existingPost.IsOwner(userID.(string)) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only the owner can delete a post"})
 		return
 	}
 
-	err = h.postService.DeletePost(c.Request.Context(), postID)
+	// The body is optional when the version comes as If-Match
+	var req DeletePostRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	version, err := handler.ExpectedVersion(c, req.Version)
 	if err != nil {
-		handler.HandleHttpError(c, err)
+		h.handleWriteError(c, postID, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Post deleted successfully"})
+	// The post goes to the trash; its comments and other data are removed when it is purged
+	err = h.postService.DeletePost(c.Request.Context(), postID, version)
+	if err != nil {
+		h.handleWriteError(c, postID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Post moved to trash"})
 }
 
-// SearchPosts searches for posts
+// SearchPosts searches for posts.
+// q accepts the compact syntax, e.g. "tag:go author:alice after:2025-01-01 sort:likes".
 func (h *PostHandler) SearchPosts(c *gin.Context) {
 	query := c.Query("q")
 	searchType := c.DefaultQuery("type", "title") // title or author
-	pageStr := c.DefaultQuery("page", "1")
-	limitStr := c.DefaultQuery("limit", "10")
 
-	if query == "" {
+	criteria, err := searchCriteriaFromRequest(c)
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+	if criteria.IsEmpty() {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Search query is required"})
 		return
 	}
 
-	page, _ := strconv.ParseInt(pageStr, 10, 64)
-	limit, _ := strconv.ParseInt(limitStr, 10, 64)
+	page, err := h.postService.QueryPosts(c.Request.Context(), criteria, pageRequestFromQuery(c))
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
 
-	posts, err := h.postService.SearchPosts(c.Request.Context(), query, searchType, page, limit)
+	res := postPageResponse(page)
+	res["query"] = query
+	res["type"] = searchType
+	c.JSON(http.StatusOK, res)
+}
+
+// FilterPosts filters posts by any combination of tags, author, date range and sort
+func (h *PostHandler) FilterPosts(c *gin.Context) {
+	criteria, err := searchCriteriaFromRequest(c)
 	if err != nil {
 		handler.HandleHttpError(c, err)
 		return
 	}
+	if criteria.IsEmpty() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "At least one of tags, author, start_date or end_date must be provided"})
+		return
+	}
+
+	page, err := h.postService.QueryPosts(c.Request.Context(), criteria, pageRequestFromQuery(c))
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, postPageResponse(page))
+}
+
+// searchCriteriaFromRequest parses the compact q syntax and lets explicit
+// query parameters (tags, match, author, start_date/after, end_date/before, sort) override it
+func searchCriteriaFromRequest(c *gin.Context) (postsvc.SearchCriteria, error) {
+	var criteria postsvc.SearchCriteria
+	var err error
+
+	if q := strings.TrimSpace(c.Query("q")); q != "" {
+		if c.Query("type") == "author" {
+			// Legacy form: q holds the author
+			criteria.Author = q
+		} else if criteria, err = postsvc.ParseSearchQuery(q); err != nil {
+			return criteria, err
+		}
+	}
+
+	if tagsParam := c.Query("tags"); tagsParam != "" {
+		for _, tag := range strings.Split(tagsParam, ",") {
+			criteria.Tags = append(criteria.Tags, strings.TrimSpace(tag))
+		}
+	}
+	switch c.Query("match") {
+	case "all":
+		criteria.MatchAllTags = true
+	case "any":
+		criteria.MatchAllTags = false
+	}
+	if author := c.Query("author"); author != "" {
+		criteria.Author = author
+	}
+	if after := c.DefaultQuery("after", c.Query("start_date")); after != "" {
+		criteria.After = after
+	}
+	if before := c.DefaultQuery("before", c.Query("end_date")); before != "" {
+		criteria.Before = before
+	}
+	if sortParam := c.Query("sort"); sortParam != "" {
+		criteria.Sort = strings.Split(strings.ToLower(sortParam), ",")
+	}
 
+	return criteria, nil
+}
+
+// pageRequestFromQuery reads the page, limit and cursor query parameters.
+// page/limit keep working for older clients; cursor takes over when present.
+func pageRequestFromQuery(c *gin.Context) postsvc.PageRequest {
+	page, limit := pageAndLimit(c, 10)
+
+	return postsvc.PageRequest{
+		Page:   page,
+		Limit:  limit,
+		Cursor: c.Query("cursor"),
+	}
+}
+
+// pageAndLimit reads the page and limit query parameters of a listing that has no cursor.
+// limit is capped at postsvc.MaxPageSize like the cursor listings.
+func pageAndLimit(c *gin.Context, defaultLimit int64) (int64, int64) {
+	page, _ := strconv.ParseInt(c.DefaultQuery("page", "1"), 10, 64)
+	limit, _ := strconv.ParseInt(c.DefaultQuery("limit", strconv.FormatInt(defaultLimit, 10)), 10, 64)
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	return page, min(limit, postsvc.MaxPageSize)
+}
+
+// postDTOs maps a page of posts to their public form
+func postDTOs(posts []*entities.Post) []*PostDTO {
 	res := make([]*PostDTO, len(posts))
 	for idx, post := range posts {
 		res[idx] = MapPostToDTO(post)
 	}
+	return res
+}
 
-	c.JSON(http.StatusOK, gin.H{
-		"posts": res,
-		"count": len(res),
-		"query": query,
-		"type":  searchType,
-	})
+// postPageResponse is the common body of every post listing
+func postPageResponse(page *postsvc.PostPage) gin.H {
+	res := postDTOs(page.Posts)
+
+	return gin.H{
+		"posts":       res,
+		"count":       len(res),
+		"next_cursor": page.NextCursor,
+		"prev_cursor": page.PrevCursor,
+	}
 }
 
-// FilterPosts filters posts by tags or date
-func (h *PostHandler) FilterPosts(c *gin.Context) {
-	tagsParam := c.Query("tags")
-	startDate := c.Query("start_date")
-	endDate := c.Query("end_date")
-	pageStr := c.DefaultQuery("page", "1")
-	limitStr := c.DefaultQuery("limit", "10")
+// respondWithPage writes a public page of posts, or 304 when the client's copy is current.
+// The cursors are also sent as headers, so clients reading a bare array can switch to them.
+func respondWithPage(c *gin.Context, page *postsvc.PostPage, body any) {
+	c.Header("X-Next-Cursor", page.NextCursor)
+	c.Header("X-Prev-Cursor", page.PrevCursor)
 
-	page, _ := strconv.ParseInt(pageStr, 10, 64)
-	limit, _ := strconv.ParseInt(limitStr, 10, 64)
+	etag, lastModified := handler.PostListETag(page.Posts, page.NextCursor, page.PrevCursor)
+	if handler.CheckNotModified(c, etag, lastModified) {
+		return
+	}
+	c.JSON(http.StatusOK, body)
+}
 
-	var posts []*entities.Post
-	var err error
+// ListMyPosts lists the current user's posts, including drafts and scheduled ones
+func (h *PostHandler) ListMyPosts(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
 
-	if tagsParam != "" {
-		// Filter by tags
-		tags := strings.Split(tagsParam, ",")
-		// Trim whitespace from tags
-		for i, tag := range tags {
-			tags[i] = strings.TrimSpace(tag)
-		}
-		posts, err = h.postService.FilterPostsByTags(c.Request.Context(), tags, page, limit)
-	} else if startDate != "" && endDate != "" {
-		// Filter by date range
-		posts, err = h.postService.FilterPostsByDateRange(c.Request.Context(), startDate, endDate, page, limit)
-	} else {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Either tags or date range (start_date and end_date) must be provided"})
+	status := c.Query("status")
+
+	page, err := h.postService.ListAuthorPosts(c.Request.Context(), userID.(string), status, pageRequestFromQuery(c))
+	if err != nil {
+		handler.HandleHttpError(c, err)
 		return
 	}
 
+	res := postPageResponse(page)
+	res["status"] = status
+	c.JSON(http.StatusOK, res)
+}
+
+// ListTrash lists the current user's deleted posts, most recently deleted first
+func (h *PostHandler) ListTrash(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	page, limit := pageAndLimit(c, 20)
+
+	posts, err := h.postService.ListTrash(c.Request.Context(), userID.(string), page, limit)
 	if err != nil {
 		handler.HandleHttpError(c, err)
 		return
@@ -299,104 +638,164 @@ func (h *PostHandler) FilterPosts(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"posts": res,
 		"count": len(res),
+		"page":  page,
 	})
 }
 
-// LikePost likes a post
-func (h *PostHandler) LikePost(c *gin.Context) {
-	postID := c.Param("id")
-
+// RestorePost takes one of the current user's posts out of the trash
+func (h *PostHandler) RestorePost(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
 		return
 	}
 
-	err := h.postService.LikePost(c.Request.Context(), postID, userID.(string))
+	post, err := h.postService.RestorePost(c.Request.Context(), c.Param("id"), userID.(string))
 	if err != nil {
 		handler.HandleHttpError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Post liked successfully"})
+	c.JSON(http.StatusOK, MapPostToDTO(post))
 }
 
-// UnlikePost unlikes a post
-func (h *PostHandler) UnlikePost(c *gin.Context) {
+// PublishPost publishes a post now or schedules it for later
+func (h *PostHandler) PublishPost(c *gin.Context) {
 	postID := c.Param("id")
-
-	userID, exists := c.Get("user_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+	if _, ok := h.authorizeEditor(c, postID); !ok {
 		return
 	}
 
-	err := h.postService.UnlikePost(c.Request.Context(), postID, userID.(string))
+	var req PublishPostRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	var publishAt time.Time
+	if req.PublishAt != nil {
+		publishAt = *req.PublishAt
+	}
+
+	post, err := h.postService.PublishPost(c.Request.Context(), postID, publishAt)
 	if err != nil {
 		handler.HandleHttpError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Post unliked successfully"})
+	c.JSON(http.StatusOK, MapPostToDTO(post))
 }
 
-// DislikePost dislikes a post
-func (h *PostHandler) DislikePost(c *gin.Context) {
+// UnpublishPost moves a post back to draft
+func (h *PostHandler) UnpublishPost(c *gin.Context) {
 	postID := c.Param("id")
+	if _, ok := h.authorizeEditor(c, postID); !ok {
+		return
+	}
 
-	userID, exists := c.Get("user_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+	post, err := h.postService.UnpublishPost(c.Request.Context(), postID)
+	if err != nil {
+		handler.HandleHttpError(c, err)
 		return
 	}
 
-	err := h.postService.DislikePost(c.Request.Context(), postID, userID.(string))
+	c.JSON(http.StatusOK, MapPostToDTO(post))
+}
+
+// ArchivePost hides a post from public listings without deleting it
+func (h *PostHandler) ArchivePost(c *gin.Context) {
+	postID := c.Param("id")
+	if _, ok := h.authorizeEditor(c, postID); !ok {
+		return
+	}
+
+	post, err := h.postService.ArchivePost(c.Request.Context(), postID)
 	if err != nil {
 		handler.HandleHttpError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Post disliked successfully"})
+	c.JSON(http.StatusOK, MapPostToDTO(post))
 }
 
-// UndislikePost removes dislike from a post
-func (h *PostHandler) UndislikePost(c *gin.Context) {
+// SetVisibility changes who can read a post
+func (h *PostHandler) SetVisibility(c *gin.Context) {
 	postID := c.Param("id")
+	if _, ok := h.authorizeEditor(c, postID); !ok {
+		return
+	}
 
-	userID, exists := c.Get("user_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+	var req VisibilityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	err := h.postService.UndislikePost(c.Request.Context(), postID, userID.(string))
+	post, err := h.postService.SetVisibility(c.Request.Context(), postID, req.Visibility)
 	if err != nil {
 		handler.HandleHttpError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Post undisliked successfully"})
+	c.JSON(http.StatusOK, MapPostToDTO(post))
 }
 
-// GetPostLikeStatus gets the like status for a post
-func (h *PostHandler) GetPostLikeStatus(c *gin.Context) {
-	postID := c.Param("id")
+// handleWriteError answers a failed version check with the version the post is at now,
+// so the client can reload it and retry; other errors are handled as usual
+func (h *PostHandler) handleWriteError(c *gin.Context, postID string, err error) {
+	if !errors.Is(err, AppError.ErrVersionConflict) {
+		handler.HandleHttpError(c, err)
+		return
+	}
+
+	current, getErr := h.postService.GetPostByID(c.Request.Context(), postID)
+	if getErr != nil {
+		handler.HandleHttpError(c, getErr)
+		return
+	}
+	c.Header("ETag", handler.PostETag(current))
+	c.JSON(http.StatusPreconditionFailed, gin.H{
+		"error":           err.Error(),
+		"current_version": current.Version,
+	})
+}
 
+// authorizeEditor returns the post when the authenticated user owns or co-authors it.
+// Otherwise it writes an error response and returns false.
+func (h *PostHandler) authorizeEditor(c *gin.Context, postID string) (*entities.Post, bool) {
 	userID, exists := c.Get("user_id")
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
-		return
+		return nil, false
 	}
 
-	liked, disliked, err := h.postService.GetPostLikeStatus(c.Request.Context(), postID, userID.(string))
+	existingPost, err := h.postService.GetPostByID(c.Request.Context(), postID)
 	if err != nil {
 		handler.HandleHttpError(c, err)
-		return
+		return nil, false
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"post_id":  postID,
-		"liked":    liked,
-		"disliked": disliked,
-	})
+	if !existingPost.CanEdit(userID.(string)) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You can only manage posts you own or co-author"})
+		return nil, false
+	}
+
+	return existingPost, true
+}
+
+// resolveTags maps the requested tags to their registered names when the tag registry is enabled.
+// Otherwise it writes an error response and returns false.
+func (h *PostHandler) resolveTags(c *gin.Context, tags []string) ([]string, bool) {
+	if h.tagService == nil {
+		return tags, true
+	}
+
+	resolved, err := h.tagService.ResolveTags(c.Request.Context(), tags)
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return nil, false
+	}
+	return resolved, true
 }
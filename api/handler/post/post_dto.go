@@ -2,48 +2,195 @@ package post
 
 import (
 	"anchor-blog/internal/domain/entities"
+	seriessvc "anchor-blog/internal/service/series"
+	trendingsvc "anchor-blog/internal/service/trending"
+	"strconv"
 	"time"
 )
 
 type PostDTO struct {
-	ID        string    `json:"id"`
-	Title     string    `json:"title"`
-	Content   string    `json:"content"`
-	AuthorID  string    `json:"author_id"`
-	Tags      []string  `json:"tags"`
-	ViewCount int       `json:"view_count"`
-	Likes     []string  `json:"likes"`
-	Dislikes  []string  `json:"dislikes"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID           string          `json:"id"`
+	Title        string          `json:"title"`
+	Slug         string          `json:"slug"`
+	Content      string          `json:"content"`
+	AuthorID     string          `json:"author_id"` // the owner
+	Authors      []PostAuthorDTO `json:"authors"`   // owner first, then co-authors
+	Tags         []string        `json:"tags"`
+	ViewCount    int             `json:"view_count"`
+	LikeCount    int             `json:"like_count"`
+	DislikeCount int             `json:"dislike_count"`
+	Reactions    map[string]int  `json:"reactions"` // count per kind, like and dislike included
+	CommentCount int             `json:"comment_count"`
+	Status       string          `json:"status"`
+	PublishAt    time.Time       `json:"publish_at"`
+	Visibility   string          `json:"visibility"`       // public, unlisted, private or members-only
+	Hidden       bool            `json:"hidden,omitempty"` // hidden by moderation
+	Excerpt      string          `json:"excerpt,omitempty"`
+	ReadingTime  int             `json:"reading_time,omitempty"` // minutes
+	Version      int             `json:"version"`                // send back as If-Match or version to update or delete
+	CreatedAt    time.Time       `json:"created_at"`
+	UpdatedAt    time.Time       `json:"updated_at"`
+	DeletedAt    *time.Time      `json:"deleted_at,omitempty"` // only on posts in the trash
+
+	Series *SeriesNavigationDTO `json:"series,omitempty"` // only on single post responses
+}
+
+// PostAuthorDTO is one of the people credited on a post
+type PostAuthorDTO struct {
+	UserID string `json:"user_id"`
+	Role   string `json:"role"` // owner or co-author
+}
+
+// SeriesNavigationDTO places a post within its series
+type SeriesNavigationDTO struct {
+	ID       string             `json:"id"`
+	Title    string             `json:"title"`
+	Position int                `json:"position"`
+	Total    int                `json:"total"`
+	Previous *SeriesPostLinkDTO `json:"previous"`
+	Next     *SeriesPostLinkDTO `json:"next"`
+}
+
+type SeriesPostLinkDTO struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Slug  string `json:"slug"`
+}
+
+// RenderedPostDTO is the post as returned for format=html
+type RenderedPostDTO struct {
+	*PostDTO
+	HTML string        `json:"html"`
+	TOC  []TOCEntryDTO `json:"toc"`
+}
+
+// TrendingPostDTO is a post of a trending ranking
+type TrendingPostDTO struct {
+	*PostDTO
+	Score float64 `json:"trending_score"`
+}
+
+type TOCEntryDTO struct {
+	Level int    `json:"level"`
+	Text  string `json:"text"`
+	ID    string `json:"id"`
 }
 
 func MapPostToDTO(post *entities.Post) *PostDTO {
-	return &PostDTO{
-		ID:        post.ID,
-		Title:     post.Title,
-		Content:   post.Content,
-		AuthorID:  post.AuthorID,
-		Tags:      post.Tags,
-		ViewCount: post.ViewCount,
-		Likes:     post.Likes,
-		Dislikes:  post.Dislikes,
-		CreatedAt: post.CreatedAt,
-		UpdatedAt: post.UpdatedAt,
+	dto := &PostDTO{
+		ID:           post.ID,
+		Title:        post.Title,
+		Slug:         post.Slug,
+		Content:      post.Content,
+		AuthorID:     post.AuthorID,
+		Authors:      mapPostAuthors(post),
+		Tags:         post.Tags,
+		ViewCount:    post.ViewCount,
+		LikeCount:    post.ReactionCount(entities.ReactionLike),
+		DislikeCount: post.ReactionCount(entities.ReactionDislike),
+		Reactions:    post.Reactions,
+		CommentCount: post.CommentCount,
+		Status:       post.Status,
+		PublishAt:    post.PublishAt,
+		Visibility:   post.Visibility,
+		Hidden:       post.Hidden,
+		Version:      post.Version,
+		CreatedAt:    post.CreatedAt,
+		UpdatedAt:    post.UpdatedAt,
+	}
+	if dto.Visibility == "" {
+		dto.Visibility = entities.PostVisibilityPublic
 	}
+	if !post.DeletedAt.IsZero() {
+		deletedAt := post.DeletedAt
+		dto.DeletedAt = &deletedAt
+	}
+	if post.Rendered != nil {
+		dto.Excerpt = post.Rendered.Excerpt
+		dto.ReadingTime = post.Rendered.ReadingTime
+	}
+	return dto
+}
+
+func mapPostAuthors(post *entities.Post) []PostAuthorDTO {
+	authors := []PostAuthorDTO{{UserID: post.AuthorID, Role: entities.PostRoleOwner}}
+	for _, collaborator := range post.Collaborators {
+		authors = append(authors, PostAuthorDTO{UserID: collaborator.UserID, Role: collaborator.Role})
+	}
+	return authors
+}
+
+// MapPostToRenderedDTO expects the post's rendered content to be filled in
+func MapPostToRenderedDTO(post *entities.Post) *RenderedPostDTO {
+	dto := &RenderedPostDTO{PostDTO: MapPostToDTO(post), TOC: []TOCEntryDTO{}}
+	if post.Rendered != nil {
+		dto.HTML = post.Rendered.HTML
+		for _, entry := range post.Rendered.TOC {
+			dto.TOC = append(dto.TOC, TOCEntryDTO{Level: entry.Level, Text: entry.Text, ID: entry.ID})
+		}
+	}
+	return dto
+}
+
+func MapTrendingPostToDTO(trending *trendingsvc.TrendingPost) *TrendingPostDTO {
+	return &TrendingPostDTO{PostDTO: MapPostToDTO(trending.Post), Score: trending.Score}
+}
+
+func MapNavigationToDTO(nav *seriessvc.Navigation) *SeriesNavigationDTO {
+	return &SeriesNavigationDTO{
+		ID:       nav.Series.ID,
+		Title:    nav.Series.Title,
+		Position: nav.Position,
+		Total:    nav.Total,
+		Previous: mapPostLink(nav.Previous),
+		Next:     mapPostLink(nav.Next),
+	}
+}
+
+// etagParts lists what the navigation shows, for the entity tag of the post; nil has none
+func (nav *SeriesNavigationDTO) etagParts() []string {
+	if nav == nil {
+		return nil
+	}
+	parts := []string{nav.ID, nav.Title, strconv.Itoa(nav.Position), strconv.Itoa(nav.Total)}
+	for _, link := range []*SeriesPostLinkDTO{nav.Previous, nav.Next} {
+		if link == nil {
+			parts = append(parts, "")
+			continue
+		}
+		parts = append(parts, link.ID+" "+link.Slug+" "+link.Title)
+	}
+	return parts
+}
+
+func mapPostLink(post *entities.Post) *SeriesPostLinkDTO {
+	if post == nil {
+		return nil
+	}
+	return &SeriesPostLinkDTO{ID: post.ID, Title: post.Title, Slug: post.Slug}
 }
 
 func MapDTOToPost(dto *PostDTO) *entities.Post {
+	collaborators := []entities.Collaborator{}
+	for _, author := range dto.Authors {
+		if author.Role != entities.PostRoleOwner {
+			collaborators = append(collaborators, entities.Collaborator{UserID: author.UserID, Role: author.Role})
+		}
+	}
 	return &entities.Post{
-		ID:        dto.ID,
-		Title:     dto.Title,
-		Content:   dto.Content,
-		AuthorID:  dto.AuthorID,
-		Tags:      dto.Tags,
-		ViewCount: dto.ViewCount,
-		Likes:     dto.Likes,
-		Dislikes:  dto.Dislikes,
-		CreatedAt: dto.CreatedAt,
-		UpdatedAt: dto.UpdatedAt,
+		ID:            dto.ID,
+		Title:         dto.Title,
+		Slug:          dto.Slug,
+		Content:       dto.Content,
+		AuthorID:      dto.AuthorID,
+		Collaborators: collaborators,
+		Tags:          dto.Tags,
+		ViewCount:     dto.ViewCount,
+		Reactions:     dto.Reactions,
+		CommentCount:  dto.CommentCount,
+		Status:        dto.Status,
+		PublishAt:     dto.PublishAt,
+		CreatedAt:     dto.CreatedAt,
+		UpdatedAt:     dto.UpdatedAt,
 	}
 }
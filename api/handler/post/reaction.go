@@ -0,0 +1,112 @@
+package post
+
+import (
+	"anchor-blog/api/handler"
+	"anchor-blog/internal/domain/entities"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LikePost likes a post
+func (h *PostHandler) LikePost(c *gin.Context) {
+	h.react(c, entities.ReactionLike, "Post liked successfully")
+}
+
+// UnlikePost unlikes a post
+func (h *PostHandler) UnlikePost(c *gin.Context) {
+	h.unreact(c, entities.ReactionLike, "Post unliked successfully")
+}
+
+// DislikePost dislikes a post
+func (h *PostHandler) DislikePost(c *gin.Context) {
+	h.react(c, entities.ReactionDislike, "Post disliked successfully")
+}
+
+// UndislikePost removes dislike from a post
+func (h *PostHandler) UndislikePost(c *gin.Context) {
+	h.unreact(c, entities.ReactionDislike, "Post undisliked successfully")
+}
+
+// AddReaction adds a reaction of the kind given in the path
+func (h *PostHandler) AddReaction(c *gin.Context) {
+	h.react(c, c.Param("kind"), "Reaction added successfully")
+}
+
+// RemoveReaction removes a reaction of the kind given in the path
+func (h *PostHandler) RemoveReaction(c *gin.Context) {
+	h.unreact(c, c.Param("kind"), "Reaction removed successfully")
+}
+
+// ListReactionKinds returns the reactions users can choose from
+func (h *PostHandler) ListReactionKinds(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"kinds": h.reactionService.Kinds()})
+}
+
+// GetPostLikeStatus gets the like status for a post
+func (h *PostHandler) GetPostLikeStatus(c *gin.Context) {
+	postID := c.Param("id")
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	status, err := h.reactionService.GetLikeStatus(c.Request.Context(), postID, userID.(string))
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+
+	bookmarked := false
+	if h.bookmarkService != nil {
+		bookmarked, err = h.bookmarkService.IsBookmarked(c.Request.Context(), userID.(string), postID)
+		if err != nil {
+			handler.HandleHttpError(c, err)
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"post_id":    postID,
+		"liked":      status.Liked,
+		"disliked":   status.Disliked,
+		"reactions":  status.Kinds,
+		"bookmarked": bookmarked,
+	})
+}
+
+func (h *PostHandler) react(c *gin.Context, kind, message string) {
+	postID := c.Param("id")
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	if err := h.reactionService.React(c.Request.Context(), postID, userID.(string), kind); err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": message})
+}
+
+func (h *PostHandler) unreact(c *gin.Context, kind, message string) {
+	postID := c.Param("id")
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	if err := h.reactionService.Unreact(c.Request.Context(), postID, userID.(string), kind); err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": message})
+}
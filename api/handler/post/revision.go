@@ -0,0 +1,158 @@
+package post
+
+import (
+	"anchor-blog/api/handler"
+	"anchor-blog/internal/domain/entities"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RevisionDTO struct {
+	Number       int       `json:"number"`
+	PostID       string    `json:"post_id"`
+	EditorID     string    `json:"editor_id"`
+	Title        string    `json:"title"`
+	Content      string    `json:"content,omitempty"`
+	Tags         []string  `json:"tags"`
+	RestoredFrom int       `json:"restored_from,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func MapRevisionToDTO(revision *entities.PostRevision, withContent bool) *RevisionDTO {
+	dto := &RevisionDTO{
+		Number:       revision.Number,
+		PostID:       revision.PostID,
+		EditorID:     revision.EditorID,
+		Title:        revision.Title,
+		Tags:         revision.Tags,
+		RestoredFrom: revision.RestoredFrom,
+		CreatedAt:    revision.CreatedAt,
+	}
+	if withContent {
+		dto.Content = revision.Content
+	}
+	return dto
+}
+
+// ListRevisions lists the revision history of a post (without content)
+func (h *PostHandler) ListRevisions(c *gin.Context) {
+	postID := c.Param("id")
+	if _, ok := h.authorizeEditor(c, postID); !ok {
+		return
+	}
+
+	page, limit := pageAndLimit(c, 20)
+
+	revisions, err := h.revisionService.ListRevisions(c.Request.Context(), postID, page, limit)
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+
+	res := make([]*RevisionDTO, len(revisions))
+	for idx, revision := range revisions {
+		res[idx] = MapRevisionToDTO(revision, false)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"revisions": res,
+		"count":     len(res),
+	})
+}
+
+// GetRevision returns the full snapshot of a single revision
+func (h *PostHandler) GetRevision(c *gin.Context) {
+	postID := c.Param("id")
+	if _, ok := h.authorizeEditor(c, postID); !ok {
+		return
+	}
+
+	number, err := strconv.Atoi(c.Param("number"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Revision number must be an integer"})
+		return
+	}
+
+	revision, err := h.revisionService.GetRevision(c.Request.Context(), postID, number)
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, MapRevisionToDTO(revision, true))
+}
+
+// DiffRevisions shows a line-level diff between two revisions
+func (h *PostHandler) DiffRevisions(c *gin.Context) {
+	postID := c.Param("id")
+	if _, ok := h.authorizeEditor(c, postID); !ok {
+		return
+	}
+
+	from, errFrom := strconv.Atoi(c.Query("from"))
+	to, errTo := strconv.Atoi(c.Query("to"))
+	if errFrom != nil || errTo != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Both from and to revision numbers are required"})
+		return
+	}
+
+	diff, err := h.revisionService.Diff(c.Request.Context(), postID, from, to)
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+// RestoreRevision makes an older revision the current version of the post
+func (h *PostHandler) RestoreRevision(c *gin.Context) {
+	postID := c.Param("id")
+	if _, ok := h.authorizeEditor(c, postID); !ok {
+		return
+	}
+	userID := c.GetString("user_id")
+
+	number, err := strconv.Atoi(c.Param("number"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Revision number must be an integer"})
+		return
+	}
+
+	// Like an edit, the restore must be based on the current version
+	var req RestoreRevisionRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	version, err := handler.ExpectedVersion(c, req.Version)
+	if err != nil {
+		h.handleWriteError(c, postID, err)
+		return
+	}
+
+	revision, err := h.revisionService.GetRevision(c.Request.Context(), postID, number)
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+
+	// Tags renamed or merged since the revision was taken resolve to their current names
+	tags, ok := h.resolveTags(c, revision.Tags)
+	if !ok {
+		return
+	}
+	updatedPost, err := h.postService.RestoreRevision(c.Request.Context(), postID, userID, revision, tags, version)
+	if err != nil {
+		h.handleWriteError(c, postID, err)
+		return
+	}
+
+	c.Header("ETag", handler.PostETag(updatedPost))
+	c.JSON(http.StatusOK, MapPostToDTO(updatedPost))
+}
@@ -0,0 +1,128 @@
+package post
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"anchor-blog/internal/domain/entities"
+	AppError "anchor-blog/internal/errors"
+	postsvc "anchor-blog/internal/service/post"
+	revisionsvc "anchor-blog/internal/service/revision"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// versionedPosts holds one post and refuses updates based on an older version
+type versionedPosts struct {
+	entities.IPostRepository
+	post *entities.Post
+}
+
+func (r *versionedPosts) FindByID(ctx context.Context, id string) (*entities.Post, error) {
+	if id != r.post.ID {
+		return nil, AppError.ErrNotFound
+	}
+	copied := *r.post
+	return &copied, nil
+}
+
+func (r *versionedPosts) Update(ctx context.Context, id string, post *entities.Post) (*entities.Post, error) {
+	if post.Version != r.post.Version {
+		return nil, AppError.ErrVersionConflict
+	}
+	r.post.Title, r.post.Content, r.post.Tags = post.Title, post.Content, post.Tags
+	r.post.Version++
+	return r.FindByID(ctx, id)
+}
+
+// fixedRevisions serves the revisions of one post
+type fixedRevisions struct {
+	entities.IRevisionRepository
+	revisions map[int]*entities.PostRevision
+}
+
+func (r *fixedRevisions) FindByNumber(ctx context.Context, postID string, number int) (*entities.PostRevision, error) {
+	revision, ok := r.revisions[number]
+	if !ok {
+		return nil, AppError.ErrNotFound
+	}
+	return revision, nil
+}
+
+func (r *fixedRevisions) Create(ctx context.Context, revision *entities.PostRevision) (*entities.PostRevision, error) {
+	return revision, nil
+}
+
+func (r *fixedRevisions) CountByPost(ctx context.Context, postID string) (int64, error) {
+	return int64(len(r.revisions)), nil
+}
+
+func setupRestore() (*gin.Engine, *versionedPosts) {
+	gin.SetMode(gin.TestMode)
+	posts := &versionedPosts{post: &entities.Post{ID: "post-1", AuthorID: "author-1", Title: "Edited by someone else", Version: 3}}
+	revisions := &fixedRevisions{revisions: map[int]*entities.PostRevision{
+		1: {Number: 1, PostID: "post-1", Title: "First draft", Content: "Hello"},
+	}}
+	postService := postsvc.NewPostService(posts, nil)
+	postService.SetRevisionRecorder(revisionsvc.NewRevisionService(revisions))
+	h := NewPostHandler(postService, nil, nil, revisionsvc.NewRevisionService(revisions), nil, nil, nil, nil, nil, nil)
+
+	router := gin.New()
+	router.POST("/posts/:id/revisions/:number/restore", func(c *gin.Context) {
+		c.Set("user_id", "author-1")
+	}, h.RestoreRevision)
+	return router, posts
+}
+
+func TestPostHandler_RestoreRevision_Version(t *testing.T) {
+	tests := []struct {
+		name        string
+		ifMatch     string
+		body        string
+		wantStatus  int
+		wantVersion int
+		wantTitle   string
+	}{
+		{"stale If-Match", `"2-9f86d081884c7d65"`, "", http.StatusPreconditionFailed, 3, "Edited by someone else"},
+		{"stale body version", "", `{"version": 2}`, http.StatusPreconditionFailed, 3, "Edited by someone else"},
+		{"no version", "", "", http.StatusPreconditionRequired, 3, "Edited by someone else"},
+		{"current If-Match", `"3-9f86d081884c7d65"`, "", http.StatusOK, 4, "First draft"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			router, posts := setupRestore()
+			req := httptest.NewRequest(http.MethodPost, "/posts/post-1/revisions/1/restore", strings.NewReader(tt.body))
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			if tt.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			w := httptest.NewRecorder()
+
+			// Execute
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantVersion, posts.post.Version)
+			assert.Equal(t, tt.wantTitle, posts.post.Title)
+			if tt.wantStatus == http.StatusPreconditionFailed {
+				var body struct {
+					CurrentVersion int `json:"current_version"`
+				}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+				assert.Equal(t, 3, body.CurrentVersion)
+				assert.True(t, strings.HasPrefix(w.Header().Get("ETag"), `"3-`))
+			}
+		})
+	}
+}
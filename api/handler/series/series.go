@@ -0,0 +1,159 @@
+package series
+
+import (
+	"anchor-blog/api/handler"
+	"anchor-blog/internal/domain/entities"
+	seriessvc "anchor-blog/internal/service/series"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SeriesHandler struct {
+	seriesService *seriessvc.SeriesService
+}
+
+func NewSeriesHandler(ss *seriessvc.SeriesService) *SeriesHandler {
+	return &SeriesHandler{
+		seriesService: ss,
+	}
+}
+
+type SeriesRequest struct {
+	Title       string   `json:"title" binding:"required"`
+	Description string   `json:"description"`
+	PostIDs     []string `json:"post_ids"` // in reading order
+}
+
+// Create creates a series of the current user's posts
+func (h *SeriesHandler) Create(c *gin.Context) {
+	var req SeriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	series, err := h.seriesService.CreateSeries(c.Request.Context(), userID.(string), req.Title, req.Description, req.PostIDs)
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+
+	h.respondWithSeries(c, http.StatusCreated, series, false)
+}
+
+// Get returns a series with its published parts
+func (h *SeriesHandler) Get(c *gin.Context) {
+	series, err := h.seriesService.GetSeries(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+
+	h.respondWithSeries(c, http.StatusOK, series, true)
+}
+
+// List returns the series of the author given by the author query parameter
+func (h *SeriesHandler) List(c *gin.Context) {
+	authorID := c.Query("author")
+	if authorID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "author is required"})
+		return
+	}
+
+	h.listSeries(c, authorID, true)
+}
+
+// ListMine returns the current user's series, including unpublished parts
+func (h *SeriesHandler) ListMine(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	h.listSeries(c, userID.(string), false)
+}
+
+// Update replaces the title, description and parts of a series
+func (h *SeriesHandler) Update(c *gin.Context) {
+	var req SeriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	series, err := h.seriesService.UpdateSeries(c.Request.Context(), c.Param("id"), userID.(string), req.Title, req.Description, req.PostIDs)
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+
+	h.respondWithSeries(c, http.StatusOK, series, false)
+}
+
+// Delete deletes a series; its posts are kept
+func (h *SeriesHandler) Delete(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	if err := h.seriesService.DeleteSeries(c.Request.Context(), c.Param("id"), userID.(string)); err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Series deleted successfully"})
+}
+
+func (h *SeriesHandler) listSeries(c *gin.Context, authorID string, publishedOnly bool) {
+	page, _ := strconv.ParseInt(c.DefaultQuery("page", "1"), 10, 64)
+	limit, _ := strconv.ParseInt(c.DefaultQuery("limit", "20"), 10, 64)
+
+	list, err := h.seriesService.ListAuthorSeries(c.Request.Context(), authorID, page, limit)
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+
+	res := make([]*SeriesDTO, len(list))
+	for idx, series := range list {
+		parts, err := h.seriesService.ListParts(c.Request.Context(), series, publishedOnly)
+		if err != nil {
+			handler.HandleHttpError(c, err)
+			return
+		}
+		res[idx] = MapSeriesToDTO(series, parts)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"series": res,
+		"count":  len(res),
+		"page":   page,
+	})
+}
+
+func (h *SeriesHandler) respondWithSeries(c *gin.Context, status int, series *entities.Series, publishedOnly bool) {
+	parts, err := h.seriesService.ListParts(c.Request.Context(), series, publishedOnly)
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+
+	c.JSON(status, MapSeriesToDTO(series, parts))
+}
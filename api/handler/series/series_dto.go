@@ -0,0 +1,47 @@
+package series
+
+import (
+	"anchor-blog/internal/domain/entities"
+	"time"
+)
+
+type SeriesDTO struct {
+	ID          string          `json:"id"`
+	AuthorID    string          `json:"author_id"`
+	Title       string          `json:"title"`
+	Description string          `json:"description"`
+	Parts       []SeriesPartDTO `json:"parts"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+}
+
+type SeriesPartDTO struct {
+	Position int    `json:"position"`
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Slug     string `json:"slug"`
+	Status   string `json:"status"`
+}
+
+// MapSeriesToDTO expects the parts in reading order
+func MapSeriesToDTO(series *entities.Series, parts []*entities.Post) *SeriesDTO {
+	dto := &SeriesDTO{
+		ID:          series.ID,
+		AuthorID:    series.AuthorID,
+		Title:       series.Title,
+		Description: series.Description,
+		Parts:       make([]SeriesPartDTO, len(parts)),
+		CreatedAt:   series.CreatedAt,
+		UpdatedAt:   series.UpdatedAt,
+	}
+	for idx, part := range parts {
+		dto.Parts[idx] = SeriesPartDTO{
+			Position: idx + 1,
+			ID:       part.ID,
+			Title:    part.Title,
+			Slug:     part.Slug,
+			Status:   part.Status,
+		}
+	}
+	return dto
+}
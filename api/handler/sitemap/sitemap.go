@@ -0,0 +1,47 @@
+package sitemap
+
+import (
+	"anchor-blog/api/handler"
+	sitemapsvc "anchor-blog/internal/service/sitemap"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const contentType = "application/xml; charset=utf-8"
+
+type SitemapHandler struct {
+	sitemapService *sitemapsvc.SitemapService
+}
+
+func NewSitemapHandler(ss *sitemapsvc.SitemapService) *SitemapHandler {
+	return &SitemapHandler{
+		sitemapService: ss,
+	}
+}
+
+// Index serves /sitemap.xml: the sitemap itself, or the index of its parts on large sites
+func (h *SitemapHandler) Index(c *gin.Context) {
+	h.serve(c, 0)
+}
+
+// Part serves one numbered part of a split sitemap, e.g. /sitemaps/2.xml
+func (h *SitemapHandler) Part(c *gin.Context) {
+	part, err := strconv.Atoi(strings.TrimSuffix(c.Param("part"), ".xml"))
+	if err != nil || part < 1 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "sitemap not found"})
+		return
+	}
+	h.serve(c, part)
+}
+
+func (h *SitemapHandler) serve(c *gin.Context, part int) {
+	doc, err := h.sitemapService.Document(handler.RequestOrigin(c), part)
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+	c.Data(http.StatusOK, contentType, doc)
+}
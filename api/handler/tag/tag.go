@@ -0,0 +1,137 @@
+package tag
+
+import (
+	"anchor-blog/api/handler"
+	tagsvc "anchor-blog/internal/service/tag"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TagHandler struct {
+	tagService *tagsvc.TagService
+}
+
+func NewTagHandler(ts *tagsvc.TagService) *TagHandler {
+	return &TagHandler{
+		tagService: ts,
+	}
+}
+
+type UpdateTagRequest struct {
+	Description string `json:"description"`
+}
+
+type RenameTagRequest struct {
+	To string `json:"to" binding:"required"`
+}
+
+type MergeTagsRequest struct {
+	Sources []string `json:"sources" binding:"required"`
+	Target  string   `json:"target" binding:"required"`
+}
+
+// List returns the tags with their post counts, most used first unless sort=name
+func (h *TagHandler) List(c *gin.Context) {
+	sortBy := c.DefaultQuery("sort", tagsvc.SortPopular)
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "0"))
+	if err != nil || limit < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a non-negative integer"})
+		return
+	}
+
+	tags, err := h.tagService.ListTags(c.Request.Context(), sortBy, limit)
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+
+	dtos := make([]*TagDTO, len(tags))
+	for i, tag := range tags {
+		dtos[i] = MapTagToDTO(tag)
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"tags":  dtos,
+		"count": len(dtos),
+	})
+}
+
+// Get returns a single tag; former names resolve to the current tag
+func (h *TagHandler) Get(c *gin.Context) {
+	tag, err := h.tagService.GetTag(c.Request.Context(), c.Param("name"))
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, MapTagToDTO(tag))
+}
+
+// UpdateDescription sets the description of a tag
+func (h *TagHandler) UpdateDescription(c *gin.Context) {
+	var req UpdateTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tag, err := h.tagService.UpdateDescription(c.Request.Context(), c.Param("name"), req.Description)
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, MapTagToDTO(tag))
+}
+
+// Rename renames a tag on every post
+func (h *TagHandler) Rename(c *gin.Context) {
+	var req RenameTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tag, modified, err := h.tagService.RenameTag(c.Request.Context(), c.Param("name"), req.To, c.GetString("user_id"))
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tag":           MapTagToDTO(tag),
+		"posts_updated": modified,
+	})
+}
+
+// Merge folds several tags into one
+func (h *TagHandler) Merge(c *gin.Context) {
+	var req MergeTagsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tag, modified, err := h.tagService.MergeTags(c.Request.Context(), req.Sources, req.Target, c.GetString("user_id"))
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tag":           MapTagToDTO(tag),
+		"posts_updated": modified,
+	})
+}
+
+// Normalize rewrites tags stored in non-canonical form
+func (h *TagHandler) Normalize(c *gin.Context) {
+	modified, err := h.tagService.NormalizeAll(c.Request.Context())
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"posts_updated": modified})
+}
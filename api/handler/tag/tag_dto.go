@@ -0,0 +1,25 @@
+package tag
+
+import (
+	"anchor-blog/internal/domain/entities"
+)
+
+type TagDTO struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Aliases     []string `json:"aliases"`
+	PostCount   int      `json:"post_count"`
+}
+
+func MapTagToDTO(tag *entities.Tag) *TagDTO {
+	aliases := tag.Aliases
+	if aliases == nil {
+		aliases = []string{}
+	}
+	return &TagDTO{
+		Name:        tag.Name,
+		Description: tag.Description,
+		Aliases:     aliases,
+		PostCount:   tag.PostCount,
+	}
+}
@@ -0,0 +1,70 @@
+package transfer
+
+import (
+	"anchor-blog/api/handler"
+	transfersvc "anchor-blog/internal/service/transfer"
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// largest archive accepted by the import endpoint
+const maxArchiveSize = 64 << 20
+
+type TransferHandler struct {
+	transferService *transfersvc.TransferService
+}
+
+func NewTransferHandler(ts *transfersvc.TransferService) *TransferHandler {
+	return &TransferHandler{
+		transferService: ts,
+	}
+}
+
+// Export downloads the posts as a zip of Markdown files, optionally only those of ?author=<username>
+func (h *TransferHandler) Export(c *gin.Context) {
+	var buf bytes.Buffer
+	exported, err := h.transferService.Export(c.Request.Context(), &buf, c.Query("author"))
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+
+	name := fmt.Sprintf("posts-%s.zip", time.Now().UTC().Format("20060102-150405"))
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, name))
+	c.Header("X-Exported-Posts", strconv.Itoa(exported))
+	c.Data(http.StatusOK, "application/zip", buf.Bytes())
+}
+
+// Import creates posts from a zip archive uploaded in the "file" form field.
+// The report lists the outcome of every file; failed files don't stop the import.
+func (h *TransferHandler) Import(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxArchiveSize+1<<20)
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "a zip archive is required in the file field"})
+		return
+	}
+	if fileHeader.Size > maxArchiveSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("archive is larger than %d MB", maxArchiveSize>>20)})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "unable to read the uploaded archive"})
+		return
+	}
+	defer file.Close()
+
+	report, err := h.transferService.Import(c.Request.Context(), file, fileHeader.Size)
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
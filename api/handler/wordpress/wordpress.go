@@ -0,0 +1,108 @@
+package wordpress
+
+import (
+	"anchor-blog/api/handler"
+	wordpresssvc "anchor-blog/internal/service/wordpress"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// used when no upload limit is configured
+const defaultMaxUploadMB = 256
+
+type WordPressHandler struct {
+	importService *wordpresssvc.ImportService
+	maxUpload     int64
+}
+
+// NewWordPressHandler creates the handler for WordPress imports; maxUploadMB limits export files
+func NewWordPressHandler(is *wordpresssvc.ImportService, maxUploadMB int) *WordPressHandler {
+	if maxUploadMB <= 0 {
+		maxUploadMB = defaultMaxUploadMB
+	}
+	return &WordPressHandler{
+		importService: is,
+		maxUpload:     int64(maxUploadMB) << 20,
+	}
+}
+
+// Import starts importing a WXR file uploaded in the "file" form field.
+// The import runs in the background; the job in the response reports its progress.
+func (h *WordPressHandler) Import(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxUpload+1<<20)
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "a WordPress export file is required in the file field"})
+		return
+	}
+	if fileHeader.Size > h.maxUpload {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("export file is larger than %d MB", h.maxUpload>>20)})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "unable to read the uploaded file"})
+		return
+	}
+	defer file.Close()
+
+	job, err := h.importService.StartImport(c.Request.Context(), userID.(string), fileHeader.Filename, file)
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+	c.JSON(http.StatusAccepted, MapImportJobToDTO(job))
+}
+
+// ListJobs returns the import jobs, newest first
+func (h *WordPressHandler) ListJobs(c *gin.Context) {
+	page, _ := strconv.ParseInt(c.DefaultQuery("page", "1"), 10, 64)
+	limit, _ := strconv.ParseInt(c.DefaultQuery("limit", "20"), 10, 64)
+
+	jobs, err := h.importService.ListJobs(c.Request.Context(), page, limit)
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+
+	res := make([]*ImportJobDTO, len(jobs))
+	for idx, job := range jobs {
+		res[idx] = MapImportJobToDTO(job)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"jobs":  res,
+		"count": len(res),
+		"page":  page,
+	})
+}
+
+// GetJob returns the progress report of an import job
+func (h *WordPressHandler) GetJob(c *gin.Context) {
+	job, err := h.importService.GetJob(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, MapImportJobToDTO(job))
+}
+
+// Resume continues a failed or interrupted import job where it stopped
+func (h *WordPressHandler) Resume(c *gin.Context) {
+	job, err := h.importService.Resume(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		handler.HandleHttpError(c, err)
+		return
+	}
+	c.JSON(http.StatusAccepted, MapImportJobToDTO(job))
+}
@@ -0,0 +1,65 @@
+package wordpress
+
+import (
+	"anchor-blog/internal/domain/entities"
+	"time"
+)
+
+type ImportJobDTO struct {
+	ID           string               `json:"id"`
+	Source       string               `json:"source"`
+	FileName     string               `json:"file_name"`
+	Status       string               `json:"status"`
+	Total        int                  `json:"total"`
+	Processed    int                  `json:"processed"`
+	Progress     int                  `json:"progress"` // percent of the items processed
+	Imported     int                  `json:"imported"`
+	Skipped      int                  `json:"skipped"`
+	Failed       int                  `json:"failed"`
+	UsersCreated int                  `json:"users_created"`
+	Errors       []ImportItemErrorDTO `json:"errors"`
+	Error        string               `json:"error,omitempty"`
+	CreatedBy    string               `json:"created_by"`
+	CreatedAt    time.Time            `json:"created_at"`
+	UpdatedAt    time.Time            `json:"updated_at"`
+	FinishedAt   *time.Time           `json:"finished_at,omitempty"`
+}
+
+type ImportItemErrorDTO struct {
+	Item  string `json:"item"`
+	Error string `json:"error"`
+}
+
+func MapImportJobToDTO(job *entities.ImportJob) *ImportJobDTO {
+	errs := make([]ImportItemErrorDTO, len(job.Errors))
+	for i, e := range job.Errors {
+		errs[i] = ImportItemErrorDTO{Item: e.Item, Error: e.Error}
+	}
+	progress := 100
+	if job.Total > 0 {
+		progress = job.Processed * 100 / job.Total
+	}
+
+	dto := &ImportJobDTO{
+		ID:           job.ID,
+		Source:       job.Source,
+		FileName:     job.FileName,
+		Status:       job.Status,
+		Total:        job.Total,
+		Processed:    job.Processed,
+		Progress:     progress,
+		Imported:     job.Imported,
+		Skipped:      job.Skipped,
+		Failed:       job.Failed,
+		UsersCreated: job.UsersCreated,
+		Errors:       errs,
+		Error:        job.Error,
+		CreatedBy:    job.CreatedBy,
+		CreatedAt:    job.CreatedAt,
+		UpdatedAt:    job.UpdatedAt,
+	}
+	if !job.FinishedAt.IsZero() {
+		dto.FinishedAt = &job.FinishedAt
+	}
+	return dto
+}
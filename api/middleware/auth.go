@@ -51,3 +51,21 @@ func AuthMiddleware(jwtSecret string) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// OptionalAuth attaches the user of a valid access token to the context like AuthMiddleware,
+// but lets requests without one through as anonymous. An invalid or expired token is
+// ignored as well, so public content stays readable with a stale token.
+func OptionalAuth(jwtSecret string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authHeader := c.GetHeader("Authorization")
+		if len(authHeader) > 7 && authHeader[:7] == "Bearer " {
+			if claims, err := jwtutil.ValidateToken(authHeader[7:], jwtSecret); err == nil {
+				c.Set("user_id", claims.UserID)
+				c.Set("username", claims.Username)
+				c.Set("role", claims.Role)
+			}
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CacheControl applies a Cache-Control policy, e.g. "public, max-age=60", to the GET and HEAD
+// responses of a route group. Requests with an Authorization header get "private, no-cache"
+// instead, as what they see can depend on the user. An empty policy sets nothing.
+func CacheControl(policy string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if policy != "" && (c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead) {
+			c.Writer.Header().Add("Vary", "Authorization")
+			if c.GetHeader("Authorization") != "" {
+				c.Header("Cache-Control", "private, no-cache")
+			} else {
+				c.Header("Cache-Control", policy)
+			}
+		}
+
+		c.Next()
+	}
+}
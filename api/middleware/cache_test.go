@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheControl(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name          string
+		policy        string
+		method        string
+		authorization string
+		wantPolicy    string
+		wantVary      string
+	}{
+		{"anonymous read", "public, max-age=60", http.MethodGet, "", "public, max-age=60", "Authorization"},
+		{"anonymous head", "public, max-age=60", http.MethodHead, "", "public, max-age=60", "Authorization"},
+		{"signed-in read", "public, max-age=60", http.MethodGet, "Bearer token", "private, no-cache", "Authorization"},
+		{"write", "public, max-age=60", http.MethodPost, "", "", ""},
+		{"no policy", "", http.MethodGet, "", "", ""},
+		{"no policy signed in", "", http.MethodGet, "Bearer token", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			router := gin.New()
+			router.Use(CacheControl(tt.policy))
+			router.Handle(tt.method, "/posts", func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(tt.method, "/posts", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+
+			// Execute
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.wantPolicy, w.Header().Get("Cache-Control"))
+			assert.Equal(t, tt.wantVary, w.Header().Get("Vary"))
+		})
+	}
+}
+
+func TestCacheControl_KeepsOtherVaryValues(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Writer.Header().Add("Vary", "Origin")
+	})
+	router.Use(CacheControl("public, max-age=60"))
+	router.GET("/posts", func(c *gin.Context) {
+		c.Status(http.StatusNotModified)
+	})
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/posts", nil))
+
+	// Assert: the policy is sent on 304s as well
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Equal(t, "public, max-age=60", w.Header().Get("Cache-Control"))
+	assert.Equal(t, []string{"Origin", "Authorization"}, w.Header().Values("Vary"))
+}
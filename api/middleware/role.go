@@ -65,3 +65,28 @@ func RequireUser() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// RequireAdmin allows admins and superadmins
+func RequireAdmin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userRole, exists := c.Get("role")
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "User not authenticated",
+			})
+			c.Abort()
+			return
+		}
+
+		if userRole != "admin" && userRole != "superadmin" {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error":     "Insufficient permissions",
+				"user_role": userRole,
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
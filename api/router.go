@@ -2,11 +2,21 @@ package api
 
 import (
 	"anchor-blog/api/handler"
+	"anchor-blog/api/handler/bookmark"
+	"anchor-blog/api/handler/comment"
 	"anchor-blog/api/handler/content"
+	"anchor-blog/api/handler/feed"
+	"anchor-blog/api/handler/media"
+	"anchor-blog/api/handler/moderation"
 	g "anchor-blog/api/handler/oauth"
 	"anchor-blog/api/handler/post"
+	"anchor-blog/api/handler/series"
+	"anchor-blog/api/handler/sitemap"
 	"anchor-blog/api/handler/swagger"
+	"anchor-blog/api/handler/tag"
+	"anchor-blog/api/handler/transfer"
 	"anchor-blog/api/handler/user"
+	"anchor-blog/api/handler/wordpress"
 	"anchor-blog/api/middleware"
 	"anchor-blog/config"
 	"net/http"
@@ -14,12 +24,28 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// Cache-Control policies of the cached route groups when the config sets none
+const (
+	defaultPostsCacheControl = "public, max-age=60"
+	defaultFeedsCacheControl = "public, max-age=300"
+)
+
 func SetupRouter(cfg *config.Config, userHandler *user.UserHandler,
 	postHandler *post.PostHandler,
+	commentHandler *comment.CommentHandler,
 	activationHandler *handler.ActivationHandler,
 	passwordResetHandler *handler.PasswordResetHandler,
 	contentHandler *content.ContentHandler,
-	oauthHandler *g.OAuthHandler) *gin.Engine {
+	oauthHandler *g.OAuthHandler,
+	tagHandler *tag.TagHandler,
+	seriesHandler *series.SeriesHandler,
+	bookmarkHandler *bookmark.BookmarkHandler,
+	feedHandler *feed.FeedHandler,
+	sitemapHandler *sitemap.SitemapHandler,
+	transferHandler *transfer.TransferHandler,
+	wordpressHandler *wordpress.WordPressHandler,
+	moderationHandler *moderation.ModerationHandler,
+	mediaHandler *media.MediaHandler) *gin.Engine {
 
 	router := gin.Default()
 
@@ -30,9 +56,14 @@ func SetupRouter(cfg *config.Config, userHandler *user.UserHandler,
 		})
 	})
 
+	// Sitemaps are served from the site root, where crawlers look for them
+	router.GET("/sitemap.xml", sitemapHandler.Index)
+	router.GET("/sitemaps/:part", sitemapHandler.Part)
+
 	v1 := router.Group("/api/v1")
 
-	// Public routes
+	// Public routes; optionalAuth identifies signed-in readers of posts that are not public
+	optionalAuth := middleware.OptionalAuth(cfg.JWT.AccessTokenSecret)
 	public := v1.Group("")
 	{
 		// Auth routes
@@ -52,14 +83,46 @@ func SetupRouter(cfg *config.Config, userHandler *user.UserHandler,
 		public.POST("/users/reset-password", passwordResetHandler.ResetPassword)
 		public.PATCH("/users/last-seen/:id", userHandler.SetLastSeen)
 
+		// Post reads that answer conditional requests, cached under the posts policy
+		postReads := public.Group("", middleware.CacheControl(policyOr(cfg.CacheControl.Posts, defaultPostsCacheControl)))
+		{
+			postReads.GET("/posts/:id", optionalAuth, postHandler.GetByID) // ✔️
+			postReads.GET("/posts", postHandler.List)                      // ✔️
+			postReads.GET("/posts/popular", postHandler.GetPopularPosts)   // ✔️
+			postReads.GET("/posts/by-slug/:slug", optionalAuth, postHandler.GetBySlug)
+		}
+
 		// Post routes
-		public.GET("/posts/:id", postHandler.GetByID)                // ✔️
-		public.GET("/posts", postHandler.List)                       // ✔️
-		public.GET("/posts/popular", postHandler.GetPopularPosts)    // ✔️
-		public.GET("/posts/search", postHandler.SearchPosts)         // ✔️
-		public.GET("/posts/filter", postHandler.FilterPosts)         // ✔️
-		public.GET("/posts/:id/views", postHandler.GetPostViewCount) // ✔️
-		public.GET("/stats/views", postHandler.GetViewStats)         // ✔️
+		public.GET("/posts/search", postHandler.SearchPosts)                       // ✔️
+		public.GET("/posts/filter", postHandler.FilterPosts)                       // ✔️
+		public.GET("/posts/:id/views", optionalAuth, postHandler.GetPostViewCount) // ✔️
+		public.GET("/stats/views", postHandler.GetViewStats)                       // ✔️
+		public.GET("/posts/trending", postHandler.GetTrendingPosts)
+		public.GET("/posts/:id/related", optionalAuth, postHandler.GetRelatedPosts)
+		public.GET("/reactions", postHandler.ListReactionKinds)
+
+		// Comment routes
+		public.GET("/posts/:id/comments", optionalAuth, commentHandler.ListByPost)
+		public.GET("/comments/:id/replies", optionalAuth, commentHandler.ListReplies)
+
+		// Tag routes
+		public.GET("/tags", tagHandler.List)
+		public.GET("/tags/:name", tagHandler.Get)
+
+		// Series routes
+		public.GET("/series", seriesHandler.List)
+		public.GET("/series/:id", seriesHandler.Get)
+
+		// Feed routes; format is rss, atom or json
+		feeds := public.Group("", middleware.CacheControl(policyOr(cfg.CacheControl.Feeds, defaultFeedsCacheControl)))
+		{
+			feeds.GET("/feed/:format", feedHandler.Site)
+			feeds.GET("/feed/tags/:tag/:format", feedHandler.Tag)
+			feeds.GET("/feed/authors/:author/:format", feedHandler.Author)
+		}
+
+		// Media files kept by the local storage backend
+		public.GET("/media/files/*key", mediaHandler.ServeFile)
 
 		// documentation
 		public.GET("/docs/documentation.yaml", swagger.OpenAPISpecHandler) // ✔️
@@ -73,6 +136,26 @@ func SetupRouter(cfg *config.Config, userHandler *user.UserHandler,
 		private.POST("/posts", postHandler.Create)           // ✔️
 		private.PUT("/posts/:id", postHandler.UpdatePost)    // ✔️
 		private.DELETE("/posts/:id", postHandler.DeletePost) // ✔️
+		private.GET("/me/posts", postHandler.ListMyPosts)
+		private.GET("/me/trash", postHandler.ListTrash)
+		private.POST("/posts/:id/restore", postHandler.RestorePost)
+
+		// Post lifecycle routes
+		private.POST("/posts/:id/publish", postHandler.PublishPost)
+		private.POST("/posts/:id/unpublish", postHandler.UnpublishPost)
+		private.POST("/posts/:id/archive", postHandler.ArchivePost)
+		private.PUT("/posts/:id/visibility", postHandler.SetVisibility)
+
+		// Post revision routes
+		private.GET("/posts/:id/revisions", postHandler.ListRevisions)
+		private.GET("/posts/:id/revisions/diff", postHandler.DiffRevisions)
+		private.GET("/posts/:id/revisions/:number", postHandler.GetRevision)
+		private.POST("/posts/:id/revisions/:number/restore", postHandler.RestoreRevision)
+
+		// Post collaborator routes
+		private.POST("/posts/:id/collaborators", postHandler.AddCollaborator)
+		private.DELETE("/posts/:id/collaborators/:userId", postHandler.RemoveCollaborator)
+		private.POST("/posts/:id/transfer", postHandler.TransferOwnership)
 
 		// Post interaction routes
 		private.POST("/posts/:id/like", postHandler.LikePost)                // ✔️
@@ -80,6 +163,32 @@ func SetupRouter(cfg *config.Config, userHandler *user.UserHandler,
 		private.POST("/posts/:id/dislike", postHandler.DislikePost)          // ✔️
 		private.DELETE("/posts/:id/dislike", postHandler.UndislikePost)      // ✔️
 		private.GET("/posts/:id/like-status", postHandler.GetPostLikeStatus) // ✔️
+		private.POST("/posts/:id/reactions/:kind", postHandler.AddReaction)
+		private.DELETE("/posts/:id/reactions/:kind", postHandler.RemoveReaction)
+		private.POST("/posts/:id/report", moderationHandler.ReportPost)
+
+		// Bookmark routes
+		private.POST("/posts/:id/bookmark", bookmarkHandler.Add)
+		private.DELETE("/posts/:id/bookmark", bookmarkHandler.Remove)
+		private.GET("/me/bookmarks", bookmarkHandler.List)
+		private.GET("/me/bookmarks/folders", bookmarkHandler.ListFolders)
+
+		// Media library routes
+		private.POST("/media", mediaHandler.Upload)
+		private.GET("/me/media", mediaHandler.ListMedia)
+		private.GET("/media/:id", mediaHandler.GetMedia)
+		private.DELETE("/media/:id", mediaHandler.DeleteMedia)
+
+		// Series routes
+		private.POST("/series", seriesHandler.Create)
+		private.PUT("/series/:id", seriesHandler.Update)
+		private.DELETE("/series/:id", seriesHandler.Delete)
+		private.GET("/me/series", seriesHandler.ListMine)
+
+		// Comment routes
+		private.POST("/posts/:id/comments", commentHandler.Create)
+		private.PUT("/comments/:id", commentHandler.Update)
+		private.DELETE("/comments/:id", commentHandler.Delete)
 
 		// Profile routes
 		private.GET("/user/profile", userHandler.GetProfile)
@@ -89,6 +198,28 @@ func SetupRouter(cfg *config.Config, userHandler *user.UserHandler,
 		private.PATCH("/admin/users/:id/promote", middleware.RequireSuperadmin(), userHandler.PromoteUser) // ✔️
 		private.PATCH("/admin/users/:id/demote", middleware.RequireSuperadmin(), userHandler.DemoteUser)   // ✔️
 
+		// Tag administration routes
+		private.PUT("/admin/tags/:name", middleware.RequireAdmin(), tagHandler.UpdateDescription)
+		private.POST("/admin/tags/:name/rename", middleware.RequireAdmin(), tagHandler.Rename)
+		private.POST("/admin/tags/merge", middleware.RequireAdmin(), tagHandler.Merge)
+		private.POST("/admin/tags/normalize", middleware.RequireAdmin(), tagHandler.Normalize)
+
+		// Post import/export routes
+		private.GET("/admin/posts/export", middleware.RequireAdmin(), transferHandler.Export)
+		private.POST("/admin/posts/import", middleware.RequireAdmin(), transferHandler.Import)
+
+		// WordPress import routes
+		private.POST("/admin/imports/wordpress", middleware.RequireAdmin(), wordpressHandler.Import)
+		private.GET("/admin/imports", middleware.RequireAdmin(), wordpressHandler.ListJobs)
+		private.GET("/admin/imports/:id", middleware.RequireAdmin(), wordpressHandler.GetJob)
+		private.POST("/admin/imports/:id/resume", middleware.RequireAdmin(), wordpressHandler.Resume)
+
+		// Moderation routes
+		private.GET("/admin/reports", middleware.RequireAdmin(), moderationHandler.ListReports)
+		private.GET("/admin/reports/:id", middleware.RequireAdmin(), moderationHandler.GetReport)
+		private.POST("/admin/reports/:id/resolve", middleware.RequireAdmin(), moderationHandler.ResolveReport)
+		private.GET("/admin/moderation/log", middleware.RequireAdmin(), moderationHandler.ListActions)
+
 		// Auth routes
 		private.POST("/logout", userHandler.Logout) // ✔️
 	}
@@ -102,3 +233,11 @@ func SetupRouter(cfg *config.Config, userHandler *user.UserHandler,
 
 	return router
 }
+
+// policyOr returns the configured Cache-Control policy, or the fallback when none is set
+func policyOr(policy, fallback string) string {
+	if policy == "" {
+		return fallback
+	}
+	return policy
+}
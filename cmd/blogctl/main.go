@@ -0,0 +1,193 @@
+// Command blogctl runs maintenance tasks against the blog's database.
+//
+// Usage:
+//
+//	blogctl export [-author username] [-o posts.zip]
+//	blogctl import posts.zip
+//	blogctl wordpress export.xml
+//	blogctl wordpress -resume job-id
+//
+// It reads the same configuration as the server from the working directory.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"anchor-blog/config"
+	"anchor-blog/internal/app"
+	importjobrepo "anchor-blog/internal/repository/importjob"
+	transfersvc "anchor-blog/internal/service/transfer"
+	wordpresssvc "anchor-blog/internal/service/wordpress"
+	"anchor-blog/pkg/db"
+)
+
+const usage = `usage:
+  blogctl export [-author username] [-o posts.zip]   export posts as Markdown with front matter
+  blogctl import posts.zip                            import an exported archive
+  blogctl wordpress export.xml                        import a WordPress export (WXR) file
+  blogctl wordpress -resume job-id                    continue an interrupted WordPress import
+`
+
+func main() {
+	log.SetFlags(0)
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	cfg, err := config.LoadConfig(".")
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+	mongoClient, err := db.Connect(cfg.Mongo.URI)
+	if err != nil {
+		log.Fatalf("Failed to connect to MongoDB: %v", err)
+	}
+	defer db.Disconnect(mongoClient)
+
+	// Posts are written through the same services as the server's, so imports get revisions,
+	// registered tags and tracked media, and the server's post cache is kept current
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	redisClient := app.ConnectRedis(ctx, cfg)
+	cancel()
+	mediaStorage, err := app.NewMediaStorage(cfg)
+	if err != nil {
+		log.Fatalf("Failed to set up media storage: %v", err)
+	}
+
+	database := mongoClient.Database(cfg.Mongo.Database)
+	repositories := app.NewRepositories(cfg, database, redisClient)
+	postService := app.NewPostServices(cfg, repositories, mediaStorage).Posts
+	transferService := transfersvc.NewTransferService(postService, repositories.Posts, repositories.Users)
+	importJobRepository := importjobrepo.NewMongoImportJobRepository(database.Collection("import_jobs"))
+	importService := wordpresssvc.NewImportService(importJobRepository, postService, repositories.Posts, repositories.Users, cfg.Import.Dir)
+
+	var code int
+	switch os.Args[1] {
+	case "export":
+		code = runExport(transferService, os.Args[2:])
+	case "import":
+		code = runImport(transferService, os.Args[2:])
+	case "wordpress":
+		code = runWordPress(importService, os.Args[2:])
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		code = 2
+	}
+	if code != 0 {
+		db.Disconnect(mongoClient)
+		os.Exit(code)
+	}
+}
+
+func runExport(service *transfersvc.TransferService, args []string) int {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	author := flags.String("author", "", "only export the posts of this username")
+	output := flags.String("o", "posts.zip", "archive to write")
+	flags.Parse(args)
+
+	file, err := os.Create(*output)
+	if err != nil {
+		log.Printf("Failed to create %s: %v", *output, err)
+		return 1
+	}
+	exported, err := service.Export(context.Background(), file, *author)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(*output)
+		log.Printf("Export failed: %v", err)
+		return 1
+	}
+
+	log.Printf("Exported %d posts to %s", exported, *output)
+	return 0
+}
+
+func runImport(service *transfersvc.TransferService, args []string) int {
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	flags.Parse(args)
+	if flags.NArg() != 1 {
+		fmt.Fprint(os.Stderr, usage)
+		return 2
+	}
+
+	file, err := os.Open(flags.Arg(0))
+	if err != nil {
+		log.Printf("Failed to open archive: %v", err)
+		return 1
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		log.Printf("Failed to open archive: %v", err)
+		return 1
+	}
+
+	report, err := service.Import(context.Background(), file, info.Size())
+	if err != nil {
+		log.Printf("Import failed: %v", err)
+		return 1
+	}
+	for _, result := range report.Files {
+		if result.Error != "" {
+			log.Printf("✗ %s: %s", result.File, result.Error)
+		} else {
+			log.Printf("✓ %s → %s", result.File, result.Slug)
+		}
+	}
+	log.Printf("Imported %d posts, %d failed", report.Imported, report.Failed)
+	if report.Failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+func runWordPress(service *wordpresssvc.ImportService, args []string) int {
+	flags := flag.NewFlagSet("wordpress", flag.ExitOnError)
+	resume := flags.String("resume", "", "id of an import job to continue")
+	flags.Parse(args)
+
+	ctx := context.Background()
+	jobID := *resume
+	if jobID == "" {
+		if flags.NArg() != 1 {
+			fmt.Fprint(os.Stderr, usage)
+			return 2
+		}
+		file, err := os.Open(flags.Arg(0))
+		if err != nil {
+			log.Printf("Failed to open export file: %v", err)
+			return 1
+		}
+		job, err := service.CreateJob(ctx, "", filepath.Base(flags.Arg(0)), file)
+		file.Close()
+		if err != nil {
+			log.Printf("Import failed: %v", err)
+			return 1
+		}
+		log.Printf("Created import job %s for %d items", job.ID, job.Total)
+		jobID = job.ID
+	}
+
+	job, err := service.Run(ctx, jobID)
+	if err != nil {
+		log.Printf("Import failed: %v (continue with: blogctl wordpress -resume %s)", err, jobID)
+		return 1
+	}
+	for _, itemErr := range job.Errors {
+		log.Printf("✗ %s: %s", itemErr.Item, itemErr.Error)
+	}
+	log.Printf("Imported %d posts, skipped %d items, %d failed, created %d users", job.Imported, job.Skipped, job.Failed, job.UsersCreated)
+	if job.Failed > 0 {
+		return 1
+	}
+	return 0
+}
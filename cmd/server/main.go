@@ -7,21 +7,38 @@ import (
 
 	"anchor-blog/api"
 	"anchor-blog/api/handler"
+	"anchor-blog/api/handler/bookmark"
+	"anchor-blog/api/handler/comment"
 	"anchor-blog/api/handler/content"
+	"anchor-blog/api/handler/feed"
+	"anchor-blog/api/handler/media"
+	"anchor-blog/api/handler/moderation"
 	g "anchor-blog/api/handler/oauth"
 	"anchor-blog/api/handler/post"
+	"anchor-blog/api/handler/series"
+	"anchor-blog/api/handler/sitemap"
+	"anchor-blog/api/handler/tag"
+	"anchor-blog/api/handler/transfer"
 	"anchor-blog/api/handler/user"
+	"anchor-blog/api/handler/wordpress"
 	"anchor-blog/config"
+	"anchor-blog/internal/app"
 	"anchor-blog/internal/repository/gemini"
-	postrepo "anchor-blog/internal/repository/post"
+	importjobrepo "anchor-blog/internal/repository/importjob"
+	reportrepo "anchor-blog/internal/repository/report"
 	tokenrepo "anchor-blog/internal/repository/token"
-	userrepo "anchor-blog/internal/repository/user"
+	trendingrepo "anchor-blog/internal/repository/trending"
+	viewstatsrepo "anchor-blog/internal/repository/viewstats"
 	contentsvc "anchor-blog/internal/service/content"
+	feedsvc "anchor-blog/internal/service/feed"
+	moderationsvc "anchor-blog/internal/service/moderation"
 	postsvc "anchor-blog/internal/service/post"
+	transfersvc "anchor-blog/internal/service/transfer"
+	trendingsvc "anchor-blog/internal/service/trending"
 	usersvc "anchor-blog/internal/service/user"
 	viewsvc "anchor-blog/internal/service/view"
+	wordpresssvc "anchor-blog/internal/service/wordpress"
 	"anchor-blog/pkg/db"
-	redisclient "anchor-blog/pkg/redis"
 )
 
 func main() {
@@ -40,49 +57,104 @@ func main() {
 	log.Println("MongoDB connected")
 
 	// Initialize collections
-	userCollection := mongoClient.Database(cfg.Mongo.Database).Collection(cfg.Mongo.UserCollection)
 	tokenCollection := mongoClient.Database(cfg.Mongo.Database).Collection(cfg.Mongo.TokenCollection)
-	postCollection := mongoClient.Database(cfg.Mongo.Database).Collection(cfg.Mongo.PostCollection)
 	activationTokenCollection := mongoClient.Database(cfg.Mongo.Database).Collection("activation_tokens")
 	passwordResetTokenCollection := mongoClient.Database(cfg.Mongo.Database).Collection("password_reset_tokens")
+	postViewsCollection := mongoClient.Database(cfg.Mongo.Database).Collection("post_views")
+	trendingCollection := mongoClient.Database(cfg.Mongo.Database).Collection("trending")
+	importJobCollection := mongoClient.Database(cfg.Mongo.Database).Collection("import_jobs")
+	reportCollection := mongoClient.Database(cfg.Mongo.Database).Collection("reports")
+	moderationActionCollection := mongoClient.Database(cfg.Mongo.Database).Collection("moderation_actions")
 
-	// Initialize Redis client
-	redisClient := redisclient.NewRedisClient(cfg.Redis.Host, cfg.Redis.Port, cfg.Redis.Password, cfg.Redis.DB)
-
-	// Test Redis connection
+	// Connect to Redis; handlers work without it when it is unavailable
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
-
-	if err := redisClient.Ping(ctx); err != nil {
-		log.Printf("⚠️  Redis connection failed: %v (continuing without Redis)", err)
-		redisClient = nil // Set to nil so handlers can handle gracefully
-	} else {
-		log.Println("✅ Redis connected")
-	}
+	redisClient := app.ConnectRedis(ctx, cfg)
 
 	// Initialize repositories
-	userRepository := userrepo.NewUserRepository(userCollection)
+	repositories := app.NewRepositories(cfg, mongoClient.Database(cfg.Mongo.Database), redisClient)
+	userRepository := repositories.Users
+	postRepository := repositories.Posts
 	tokenRepository := tokenrepo.NewMongoTokenRepository(tokenCollection)
-	postRepository := postrepo.NewMongoPostRepository(postCollection)
 	activationTokenRepo := tokenrepo.NewActivationTokenRepository(activationTokenCollection)
 	passwordResetTokenRepo := tokenrepo.NewPasswordResetTokenRepository(passwordResetTokenCollection)
+	viewStatsRepository := viewstatsrepo.NewMongoViewStatsRepository(postViewsCollection)
+	trendingRepository := trendingrepo.NewMongoTrendingRepository(trendingCollection)
+	importJobRepository := importjobrepo.NewMongoImportJobRepository(importJobCollection)
+	reportRepository := reportrepo.NewMongoReportRepository(reportCollection)
+	moderationActionRepository := reportrepo.NewMongoModerationActionRepository(moderationActionCollection)
+
+	// Initialize media storage
+	mediaStorage, err := app.NewMediaStorage(cfg)
+	if err != nil {
+		log.Fatalf("Failed to set up media storage: %v", err)
+	}
 
 	// Initialize services
 	activationService := usersvc.NewActivationService(userRepository, activationTokenRepo)
 	passwordResetService := usersvc.NewPasswordResetService(userRepository, passwordResetTokenRepo)
+	postServices := app.NewPostServices(cfg, repositories, mediaStorage)
+	postService := postServices.Posts
+	commentService := postServices.Comments
+	revisionService := postServices.Revisions
+	tagService := postServices.Tags
+	seriesService := postServices.Series
+	bookmarkService := postServices.Bookmarks
+	reactionService := postServices.Reactions
+	relatedService := postServices.Related
+	mediaService := postServices.Media
+	sitemapService := postServices.Sitemap
+	trendingService := trendingsvc.NewTrendingService(trendingRepository, viewStatsRepository, repositories.Reactions, postRepository, cfg.Trending.RecomputeInterval, cfg.Trending.Gravity)
 
 	// Initialize view tracking service (with Redis if available)
 	var viewTrackingService *viewsvc.ViewTrackingService
 	if redisClient != nil {
-		viewTrackingService = viewsvc.NewViewTrackingService(redisClient, postRepository, cfg.Redis.ViewTrackingTTL)
+		viewTrackingService = viewsvc.NewViewTrackingService(redisClient, postRepository, viewStatsRepository, cfg.Redis.ViewTrackingTTL)
 		log.Println("✅ View tracking service initialized with Redis")
 	} else {
 		log.Println("⚠️  View tracking service disabled (Redis unavailable)")
 	}
 
+	// Move like/dislike arrays of older posts into the reaction store
+	go func() {
+		migrated, err := reactionService.MigrateLegacyReactions(context.Background())
+		if err != nil {
+			log.Printf("⚠️  Reaction migration stopped after %d posts: %v", migrated, err)
+		} else if migrated > 0 {
+			log.Printf("✅ Migrated reactions of %d posts", migrated)
+		}
+	}()
+
+	// Start the background publisher for scheduled posts
+	postsvc.NewScheduledPublisher(postService, cfg.Post.PublishCheckInterval).Start(context.Background())
+
+	// Keep the trending rankings fresh
+	trendingService.Start(context.Background())
+
 	// Initialize handlers
 	userHandler := user.NewUserHandler(usersvc.NewUserServices(userRepository, tokenRepository, cfg), activationService)
-	postHandler := post.NewPostHandler(postsvc.NewPostService(postRepository), viewTrackingService)
+	sitemapService.Start(context.Background())
+	postsvc.NewTrashPurger(postService, cfg.Post.TrashRetention, cfg.Post.PurgeInterval).Start(context.Background())
+	postHandler := post.NewPostHandler(postService, viewTrackingService, commentService, revisionService, tagService, seriesService, bookmarkService, reactionService, trendingService, relatedService)
+	commentHandler := comment.NewCommentHandler(commentService)
+	tagHandler := tag.NewTagHandler(tagService)
+	seriesHandler := series.NewSeriesHandler(seriesService)
+	bookmarkHandler := bookmark.NewBookmarkHandler(bookmarkService)
+	feedHandler := feed.NewFeedHandler(feedsvc.NewFeedService(postService, userRepository, feedsvc.Settings{
+		Title:       cfg.Feed.Title,
+		Description: cfg.Feed.Description,
+		SiteURL:     cfg.Feed.SiteURL,
+		PostURL:     cfg.Feed.PostURL,
+		Limit:       cfg.Feed.Limit,
+		Content:     cfg.Feed.Content,
+	}))
+	sitemapHandler := sitemap.NewSitemapHandler(sitemapService)
+	transferHandler := transfer.NewTransferHandler(transfersvc.NewTransferService(postService, postRepository, userRepository))
+	importService := wordpresssvc.NewImportService(importJobRepository, postService, postRepository, userRepository, cfg.Import.Dir)
+	importService.ResumeInterrupted(context.Background())
+	wordpressHandler := wordpress.NewWordPressHandler(importService, cfg.Import.MaxUploadSize)
+	moderationHandler := moderation.NewModerationHandler(moderationsvc.NewModerationService(reportRepository, moderationActionRepository, postService, cfg.Moderation.AutoHideThreshold))
+	mediaHandler := media.NewMediaHandler(mediaService)
 	activationHandler := handler.NewActivationHandler(activationService)
 	passwordResetHandler := handler.NewPasswordResetHandler(passwordResetService)
 	contentHandler := content.NewContentHandler(contentsvc.NewContentUsecase(gemini.NewGeminiRepo(cfg.GenAI.GeminiAPIKey, cfg.GenAI.GeminiModel)))
@@ -91,7 +163,7 @@ func main() {
 	oauthHandler := g.NewOAuthHandler(usersvc.NewUserServices(userRepository, tokenRepository, cfg))
 
 	// Start Server
-	router := api.SetupRouter(cfg, userHandler, postHandler, activationHandler, passwordResetHandler, contentHandler, oauthHandler)
+	router := api.SetupRouter(cfg, userHandler, postHandler, commentHandler, activationHandler, passwordResetHandler, contentHandler, oauthHandler, tagHandler, seriesHandler, bookmarkHandler, feedHandler, sitemapHandler, transferHandler, wordpressHandler, moderationHandler, mediaHandler)
 	log.Printf("🚀 Server is running on port %s\n", cfg.Server.Port)
 	if err := router.Run(":" + cfg.Server.Port); err != nil {
 		log.Fatalf("Failed to start server: %v", err)
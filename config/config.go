@@ -33,12 +33,80 @@ type Config struct {
 		MaxWords     int    `mapstructure:"max_words"`
 	} `mapstructure:"genai"`
 
+	Post struct {
+		PublishCheckInterval int `mapstructure:"publish_check_interval"` // seconds between scheduled publish runs
+		TrashRetention       int `mapstructure:"trash_retention"`        // days deleted posts are kept before they are purged
+		PurgeInterval        int `mapstructure:"purge_interval"`         // seconds between purge runs
+	} `mapstructure:"post"`
+
+	Trending struct {
+		RecomputeInterval int     `mapstructure:"recompute_interval"` // seconds between ranking runs
+		Gravity           float64 `mapstructure:"gravity"`            // how fast posts sink with age
+	} `mapstructure:"trending"`
+
+	Related struct {
+		CacheTTL int `mapstructure:"cache_ttl"` // seconds a related posts ranking is reused
+	} `mapstructure:"related"`
+
+	Feed struct {
+		Title       string `mapstructure:"title"`
+		Description string `mapstructure:"description"`
+		SiteURL     string `mapstructure:"site_url"` // defaults to the origin of the request
+		PostURL     string `mapstructure:"post_url"` // link pattern with {id} and {slug}, e.g. https://blog.example.com/p/{slug}
+		Limit       int    `mapstructure:"limit"`    // posts per feed
+		Content     string `mapstructure:"content"`  // full (default) or excerpt
+	} `mapstructure:"feed"`
+
+	CacheControl struct {
+		Posts string `mapstructure:"posts"` // policy of public post reads; defaults to "public, max-age=60"
+		Feeds string `mapstructure:"feeds"` // policy of feeds; defaults to "public, max-age=300"
+	} `mapstructure:"cache_control"`
+
+	Sitemap struct {
+		SiteURL         string `mapstructure:"site_url"`         // defaults to the origin of the request
+		PostURL         string `mapstructure:"post_url"`         // link pattern with {id} and {slug}
+		TagURL          string `mapstructure:"tag_url"`          // link pattern with {tag}
+		AuthorURL       string `mapstructure:"author_url"`       // link pattern with {id} and {username}
+		RebuildInterval int    `mapstructure:"rebuild_interval"` // seconds between full rebuilds
+	} `mapstructure:"sitemap"`
+
+	Import struct {
+		Dir           string `mapstructure:"dir"`             // where uploaded export files are kept until imported
+		MaxUploadSize int    `mapstructure:"max_upload_size"` // MB
+	} `mapstructure:"import"`
+
+	Media struct {
+		Storage       string `mapstructure:"storage"`         // local (default) or s3
+		Dir           string `mapstructure:"dir"`             // where the local backend keeps files
+		PublicURL     string `mapstructure:"public_url"`      // where clients download local files; defaults to /api/v1/media/files
+		MaxUploadSize int    `mapstructure:"max_upload_size"` // MB
+		S3            struct {
+			Endpoint  string `mapstructure:"endpoint"`
+			Region    string `mapstructure:"region"`
+			Bucket    string `mapstructure:"bucket"`
+			AccessKey string `mapstructure:"access_key"`
+			SecretKey string `mapstructure:"secret_key"`
+			PathStyle bool   `mapstructure:"path_style"` // needed by most S3-compatible stores
+			PublicURL string `mapstructure:"public_url"` // a CDN in front of the bucket; defaults to the bucket URL
+		} `mapstructure:"s3"`
+	} `mapstructure:"media"`
+
+	Moderation struct {
+		AutoHideThreshold int `mapstructure:"auto_hide_threshold"` // open reports that hide a post until reviewed; 0 uses 5, negative turns it off
+	} `mapstructure:"moderation"`
+
+	Reactions struct {
+		Emoji []string `mapstructure:"emoji"` // reaction kinds offered besides like and dislike
+	} `mapstructure:"reactions"`
+
 	Redis struct {
 		Host            string `mapstructure:"host"`
 		Port            string `mapstructure:"port"`
 		Password        string `mapstructure:"password"`
 		DB              int    `mapstructure:"db"`
 		ViewTrackingTTL int    `mapstructure:"view_tracking_ttl"`
+		PostCacheTTL    int    `mapstructure:"post_cache_ttl"` // seconds a post stays cached; 0 uses 300, negative turns the post cache off
+		ListCacheTTL    int    `mapstructure:"list_cache_ttl"` // seconds a list page stays cached; 0 uses 30
 	} `mapstructure:"redis"`
 
 	OAuth struct {
@@ -0,0 +1,157 @@
+// Package app assembles the post service and everything hooked into it. The server and
+// blogctl both build it here, so a post written by either one gets a revision, registered
+// tags, tracked media and refreshed related rankings, sitemap entries and cache.
+package app
+
+import (
+	"context"
+	"log"
+
+	"anchor-blog/config"
+	"anchor-blog/internal/domain/entities"
+	bookmarkrepo "anchor-blog/internal/repository/bookmark"
+	commentrepo "anchor-blog/internal/repository/comment"
+	mediarepo "anchor-blog/internal/repository/media"
+	postrepo "anchor-blog/internal/repository/post"
+	reactionrepo "anchor-blog/internal/repository/reaction"
+	revisionrepo "anchor-blog/internal/repository/revision"
+	seriesrepo "anchor-blog/internal/repository/series"
+	tagrepo "anchor-blog/internal/repository/tag"
+	userrepo "anchor-blog/internal/repository/user"
+	bookmarksvc "anchor-blog/internal/service/bookmark"
+	commentsvc "anchor-blog/internal/service/comment"
+	mediasvc "anchor-blog/internal/service/media"
+	postsvc "anchor-blog/internal/service/post"
+	reactionsvc "anchor-blog/internal/service/reaction"
+	relatedsvc "anchor-blog/internal/service/related"
+	revisionsvc "anchor-blog/internal/service/revision"
+	seriessvc "anchor-blog/internal/service/series"
+	sitemapsvc "anchor-blog/internal/service/sitemap"
+	tagsvc "anchor-blog/internal/service/tag"
+	redisclient "anchor-blog/pkg/redis"
+	"anchor-blog/pkg/storage"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Repositories are the stores behind the post services
+type Repositories struct {
+	Posts     entities.IPostRepository
+	Users     entities.IUserRepository
+	Comments  entities.ICommentRepository
+	Revisions entities.IRevisionRepository
+	Tags      entities.ITagRepository
+	Series    entities.ISeriesRepository
+	Bookmarks entities.IBookmarkRepository
+	Reactions entities.IReactionRepository
+	Media     entities.IMediaRepository
+}
+
+// NewRepositories opens the stores in database. Posts are read through the Redis cache
+// when redisClient is set, so writes from any command keep the cache current.
+func NewRepositories(cfg *config.Config, database *mongo.Database, redisClient *redisclient.Client) Repositories {
+	posts := postrepo.NewMongoPostRepository(database.Collection(cfg.Mongo.PostCollection))
+	if redisClient != nil && cfg.Redis.PostCacheTTL >= 0 {
+		posts = postrepo.NewCachedPostRepository(posts, redisClient, cfg.Redis.PostCacheTTL, cfg.Redis.ListCacheTTL)
+		log.Println("✅ Post cache enabled")
+	}
+
+	return Repositories{
+		Posts:     posts,
+		Users:     userrepo.NewUserRepository(database.Collection(cfg.Mongo.UserCollection)),
+		Comments:  commentrepo.NewMongoCommentRepository(database.Collection("comments")),
+		Revisions: revisionrepo.NewMongoRevisionRepository(database.Collection("post_revisions")),
+		Tags:      tagrepo.NewMongoTagRepository(database.Collection("tags")),
+		Series:    seriesrepo.NewMongoSeriesRepository(database.Collection("series")),
+		Bookmarks: bookmarkrepo.NewMongoBookmarkRepository(database.Collection("bookmarks")),
+		Reactions: reactionrepo.NewMongoReactionRepository(database.Collection("reactions")),
+		Media:     mediarepo.NewMongoMediaRepository(database.Collection("media")),
+	}
+}
+
+// PostServices is the post service together with the services hooked into it
+type PostServices struct {
+	Posts     *postsvc.PostService
+	Revisions *revisionsvc.RevisionService
+	Tags      *tagsvc.TagService
+	Comments  *commentsvc.CommentService
+	Series    *seriessvc.SeriesService
+	Bookmarks *bookmarksvc.BookmarkService
+	Reactions *reactionsvc.ReactionService
+	Related   *relatedsvc.RelatedService
+	Media     *mediasvc.MediaService
+	Sitemap   *sitemapsvc.SitemapService
+}
+
+// NewPostServices builds the post services and registers their listeners and cascades.
+// Background work such as the sitemap rebuild is left for the caller to start.
+func NewPostServices(cfg *config.Config, repos Repositories, mediaStorage storage.Storage) *PostServices {
+	posts := postsvc.NewPostService(repos.Posts, repos.Users)
+	s := &PostServices{
+		Posts:     posts,
+		Revisions: revisionsvc.NewRevisionService(repos.Revisions),
+		Tags:      tagsvc.NewTagService(repos.Tags, repos.Posts, posts),
+		Comments:  commentsvc.NewCommentService(repos.Comments, repos.Posts, posts),
+		Series:    seriessvc.NewSeriesService(repos.Series, repos.Posts),
+		Bookmarks: bookmarksvc.NewBookmarkService(repos.Bookmarks, repos.Posts),
+		Reactions: reactionsvc.NewReactionService(repos.Reactions, repos.Posts, posts, cfg.Reactions.Emoji),
+		Related:   relatedsvc.NewRelatedService(repos.Posts, posts, cfg.Related.CacheTTL),
+		Media:     mediasvc.NewMediaService(repos.Media, repos.Posts, mediaStorage, cfg.Media.MaxUploadSize),
+		Sitemap: sitemapsvc.NewSitemapService(repos.Posts, repos.Users, sitemapsvc.Settings{
+			SiteURL:         cfg.Sitemap.SiteURL,
+			PostURL:         cfg.Sitemap.PostURL,
+			TagURL:          cfg.Sitemap.TagURL,
+			AuthorURL:       cfg.Sitemap.AuthorURL,
+			RebuildInterval: cfg.Sitemap.RebuildInterval,
+		}),
+	}
+
+	s.Posts.SetRevisionRecorder(s.Revisions)
+	s.Posts.SetTagResolver(s.Tags)
+	s.Posts.AddChangeListener(s.Related)
+	s.Posts.AddChangeListener(s.Sitemap)
+	s.Posts.AddChangeListener(s.Media)
+	s.Posts.AddCascade("comments", s.Comments.DeletePostComments)
+	s.Posts.AddCascade("revisions", s.Revisions.DeletePostRevisions)
+	s.Posts.AddCascade("series entry", s.Series.RemovePost)
+	s.Posts.AddCascade("bookmarks", s.Bookmarks.DeletePostBookmarks)
+	s.Posts.AddCascade("reactions", s.Reactions.DeletePostReactions)
+	s.Posts.AddCascade("media references", s.Media.DeletePostReferences)
+	return s
+}
+
+// NewMediaStorage picks the backend uploads are kept in
+func NewMediaStorage(cfg *config.Config) (storage.Storage, error) {
+	if cfg.Media.Storage == "s3" {
+		return storage.NewS3Storage(storage.S3Config{
+			Endpoint:  cfg.Media.S3.Endpoint,
+			Region:    cfg.Media.S3.Region,
+			Bucket:    cfg.Media.S3.Bucket,
+			AccessKey: cfg.Media.S3.AccessKey,
+			SecretKey: cfg.Media.S3.SecretKey,
+			PathStyle: cfg.Media.S3.PathStyle,
+			PublicURL: cfg.Media.S3.PublicURL,
+		})
+	}
+
+	dir := cfg.Media.Dir
+	if dir == "" {
+		dir = "uploads/media"
+	}
+	publicURL := cfg.Media.PublicURL
+	if publicURL == "" {
+		publicURL = "/api/v1/media/files"
+	}
+	return storage.NewLocalStorage(dir, publicURL)
+}
+
+// ConnectRedis returns a connected Redis client, or nil when Redis can't be reached
+func ConnectRedis(ctx context.Context, cfg *config.Config) *redisclient.Client {
+	redisClient := redisclient.NewRedisClient(cfg.Redis.Host, cfg.Redis.Port, cfg.Redis.Password, cfg.Redis.DB)
+	if err := redisClient.Ping(ctx); err != nil {
+		log.Printf("⚠️  Redis connection failed: %v (continuing without Redis)", err)
+		return nil
+	}
+	log.Println("✅ Redis connected")
+	return redisClient
+}
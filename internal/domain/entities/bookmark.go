@@ -0,0 +1,20 @@
+package entities
+
+import (
+	"time"
+)
+
+// Bookmark saves a post to a user's reading list
+type Bookmark struct {
+	ID        string
+	UserID    string
+	PostID    string
+	Folder    string // optional label, empty for unfiled bookmarks
+	CreatedAt time.Time
+}
+
+// BookmarkFolder is a folder of a user's reading list with the number of bookmarks in it
+type BookmarkFolder struct {
+	Name  string
+	Count int
+}
@@ -0,0 +1,17 @@
+package entities
+
+import (
+	"context"
+)
+
+// IBookmarkRepository defines the interface for bookmark data operations.
+type IBookmarkRepository interface {
+	// Save bookmarks a post, or moves an existing bookmark to the bookmark's folder
+	Save(ctx context.Context, bookmark *Bookmark) (*Bookmark, error)
+	Delete(ctx context.Context, userID, postID string) error
+	// FindByUser lists bookmarks newest first; an empty folder lists all of them
+	FindByUser(ctx context.Context, userID, folder string, opts PaginationOptions) ([]*Bookmark, error)
+	Exists(ctx context.Context, userID, postID string) (bool, error)
+	ListFolders(ctx context.Context, userID string) ([]*BookmarkFolder, error)
+	DeleteByPostID(ctx context.Context, postID string) error
+}
@@ -0,0 +1,17 @@
+package entities
+
+import (
+	"time"
+)
+
+type Comment struct {
+	ID         string
+	PostID     string
+	AuthorID   string
+	ParentID   string // empty for top-level comments
+	Content    string
+	ReplyCount int
+	Deleted    bool // tombstoned comments keep their place in the thread
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
@@ -0,0 +1,19 @@
+package entities
+
+import (
+	"context"
+)
+
+// ICommentRepository defines the interface for comment data operations.
+type ICommentRepository interface {
+	Create(ctx context.Context, comment *Comment) (*Comment, error)
+	FindByID(ctx context.Context, id string) (*Comment, error)
+	FindByPost(ctx context.Context, postID string, opts PaginationOptions) ([]*Comment, error)
+	FindReplies(ctx context.Context, parentID string, opts PaginationOptions) ([]*Comment, error)
+	UpdateContent(ctx context.Context, id, content string) (*Comment, error)
+
+	// Tombstone clears the content of a comment but keeps it so replies stay attached
+	Tombstone(ctx context.Context, id string) error
+	IncrementReplyCount(ctx context.Context, id string, delta int) error
+	DeleteByPostID(ctx context.Context, postID string) error
+}
@@ -0,0 +1,44 @@
+package entities
+
+import (
+	"time"
+)
+
+// Import job states
+const (
+	ImportJobQueued    = "queued"
+	ImportJobRunning   = "running"
+	ImportJobCompleted = "completed"
+	ImportJobFailed    = "failed"
+)
+
+// MaxImportJobErrors is how many item errors a job keeps; later ones are only counted
+const MaxImportJobErrors = 200
+
+// ImportJob tracks an import of an external export file that runs in the background.
+// Processed counts the items of the file handled so far, a resumed job skips them.
+type ImportJob struct {
+	ID           string
+	Source       string // format of the file, e.g. wxr
+	FileName     string // name of the file as uploaded
+	FilePath     string // where the file is kept until the job completes
+	CreatedBy    string
+	Status       string // queued, running, completed, failed
+	Total        int    // items in the file
+	Processed    int
+	Imported     int
+	Skipped      int // items that aren't posts or were imported before
+	Failed       int
+	UsersCreated int
+	Errors       []ImportItemError
+	Error        string // why the job stopped, for failed jobs
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	FinishedAt   time.Time
+}
+
+// ImportItemError explains why an item of an import was not imported
+type ImportItemError struct {
+	Item  string // title or id of the item in the file
+	Error string
+}
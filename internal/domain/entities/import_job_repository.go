@@ -0,0 +1,21 @@
+package entities
+
+import (
+	"context"
+	"time"
+)
+
+// IImportJobRepository defines the interface for import job data operations.
+type IImportJobRepository interface {
+	Create(ctx context.Context, job *ImportJob) (*ImportJob, error)
+	FindByID(ctx context.Context, id string) (*ImportJob, error)
+	// FindAll lists jobs newest first
+	FindAll(ctx context.Context, opts PaginationOptions) ([]*ImportJob, error)
+	// FindStale returns running jobs whose progress wasn't saved since the given time
+	FindStale(ctx context.Context, before time.Time) ([]*ImportJob, error)
+	// Claim marks an unfinished job as running, unless it is already running and saved
+	// progress after staleBefore. It reports whether the caller got the job.
+	Claim(ctx context.Context, id string, staleBefore time.Time) (bool, error)
+	// Save stores the progress and state of a job
+	Save(ctx context.Context, job *ImportJob) error
+}
@@ -0,0 +1,33 @@
+package entities
+
+import (
+	"time"
+)
+
+// Media is an uploaded image in a user's media library
+type Media struct {
+	ID          string
+	OwnerID     string
+	Filename    string // name of the uploaded file
+	ContentType string // sniffed from the content, not taken from the upload
+	Size        int64  // bytes
+	Width       int
+	Height      int
+	Key         string // storage key of the original
+	Thumbnails  []Thumbnail
+	PostIDs     []string // posts whose content uses the media; it can't be deleted while there are any
+	CreatedAt   time.Time
+}
+
+// Thumbnail is a scaled-down copy of a media image
+type Thumbnail struct {
+	Name   string // small, medium or large
+	Key    string
+	Width  int
+	Height int
+}
+
+// IsUsed reports whether posts still reference the media
+func (m *Media) IsUsed() bool {
+	return len(m.PostIDs) > 0
+}
@@ -0,0 +1,21 @@
+package entities
+
+import (
+	"context"
+)
+
+// IMediaRepository defines the interface for media library operations.
+type IMediaRepository interface {
+	// Create keeps the ID of the media when it is set, as its files are stored under it
+	Create(ctx context.Context, media *Media) (*Media, error)
+	FindByID(ctx context.Context, id string) (*Media, error)
+	// FindByOwner lists a user's media, newest first
+	FindByOwner(ctx context.Context, ownerID string, opts PaginationOptions) ([]*Media, error)
+	// Delete fails with ErrMediaInUse while posts reference the media
+	Delete(ctx context.Context, id string) error
+
+	// SetPostReferences records that a post uses exactly the given media, dropping older references
+	SetPostReferences(ctx context.Context, postID string, mediaIDs []string) error
+	// RemovePostReferences forgets a post that was deleted for good
+	RemovePostReferences(ctx context.Context, postID string) error
+}
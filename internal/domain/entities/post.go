@@ -4,17 +4,138 @@ import (
 	"time"
 )
 
+const (
+	PostStatusDraft     = "draft"
+	PostStatusScheduled = "scheduled"
+	PostStatusPublished = "published"
+	PostStatusArchived  = "archived"
+)
+
+// Roles on a post. The owner is the post's AuthorID; co-authors are listed as collaborators.
+const (
+	PostRoleOwner    = "owner"
+	PostRoleCoAuthor = "co-author"
+)
+
+// Who can read a published post. Only public posts are listed; unlisted ones are reachable
+// by link, private ones by their authors and members-only ones by any activated user.
+const (
+	PostVisibilityPublic   = "public"
+	PostVisibilityUnlisted = "unlisted"
+	PostVisibilityPrivate  = "private"
+	PostVisibilityMembers  = "members-only"
+)
+
 type Post struct {
-	ID        string
-	Title     string
-	Content   string
-	AuthorID  string
-	Tags      []string
-	ViewCount int
-	Likes     []string
-	Dislikes  []string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID            string
+	Title         string
+	Slug          string
+	OldSlugs      []string // slugs used by earlier titles, kept so old links still resolve
+	Content       string
+	AuthorID      string // the owner
+	Collaborators []Collaborator
+	Tags          []string
+	ViewCount     int
+	Reactions     map[string]int // count per reaction kind
+	CommentCount  int
+	Status        string    // draft, scheduled, published, archived
+	PublishAt     time.Time // when the post went (or goes) live
+	Hidden        bool      // hidden by moderation; only the post's authors still see it
+	Visibility    string    // public, unlisted, private or members-only; empty counts as public
+	Rendered      *RenderedContent
+	Version       int // bumped by every edit, for optimistic concurrency; posts from before versioning are at 0
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	DeletedAt     time.Time // set while the post is in the trash
+}
+
+// Collaborator is a user who was invited to work on a post
+type Collaborator struct {
+	UserID  string
+	Role    string // co-author
+	AddedAt time.Time
+}
+
+// RenderedContent caches the HTML form of a post's Markdown content
+type RenderedContent struct {
+	HTML        string
+	TOC         []TOCEntry
+	Excerpt     string
+	ReadingTime int // minutes
+	Version     int // renderer version that produced it; older ones get re-rendered
+}
+
+// TOCEntry is a heading of the rendered content; ID is its anchor in the HTML
+type TOCEntry struct {
+	Level int
+	Text  string
+	ID    string
+}
+
+// IsPublished reports whether the post is publicly visible.
+// Posts stored before the lifecycle existed have no status and count as published.
+// Posts hidden by moderation are not visible whatever their status.
+func (p *Post) IsPublished() bool {
+	return (p.Status == PostStatusPublished || p.Status == "") && !p.Hidden
+}
+
+// IsListed reports whether the post shows up in public listings, search, feeds and the sitemap
+func (p *Post) IsListed() bool {
+	return p.IsPublished() && (p.Visibility == PostVisibilityPublic || p.Visibility == "")
+}
+
+// CanView reports whether a reader may open the post. member tells whether the
+// reader is signed in with an activated account; userID is empty for anonymous readers.
+func (p *Post) CanView(userID string, member bool) bool {
+	if !p.IsPublished() {
+		return false
+	}
+	switch p.Visibility {
+	case PostVisibilityPrivate:
+		return p.CanEdit(userID)
+	case PostVisibilityMembers:
+		return member || p.CanEdit(userID)
+	}
+	return true
+}
+
+// IsValidVisibility reports whether v is one of the visibility levels
+func IsValidVisibility(v string) bool {
+	switch v {
+	case PostVisibilityPublic, PostVisibilityUnlisted, PostVisibilityPrivate, PostVisibilityMembers:
+		return true
+	}
+	return false
+}
+
+// IsOwner reports whether the user owns the post. Only the owner may delete it,
+// manage its collaborators or hand it over to someone else.
+func (p *Post) IsOwner(userID string) bool {
+	return userID != "" && p.AuthorID == userID
+}
+
+// RoleOf returns the role of a user on the post, or an empty string when they have none
+func (p *Post) RoleOf(userID string) string {
+	if p.IsOwner(userID) {
+		return PostRoleOwner
+	}
+	for _, collaborator := range p.Collaborators {
+		if collaborator.UserID == userID {
+			return collaborator.Role
+		}
+	}
+	return ""
+}
+
+// CanEdit reports whether the user may change the post: its owner and its co-authors can
+func (p *Post) CanEdit(userID string) bool {
+	role := p.RoleOf(userID)
+	return role == PostRoleOwner || role == PostRoleCoAuthor
+}
+
+// ReactionCount returns the number of reactions of a kind, for example ReactionLike
+func (p *Post) ReactionCount(kind string) int {
+	return p.Reactions[kind]
 }
 
 // content should collections of block
@@ -0,0 +1,35 @@
+package entities
+
+import (
+	"time"
+)
+
+// Sort keys understood by PostQuery
+const (
+	SortNewest = "newest"
+	SortOldest = "oldest"
+	SortViews  = "views"
+	SortLikes  = "likes"
+)
+
+// PostQuery composes every public listing filter. Zero-valued fields are ignored.
+type PostQuery struct {
+	Text         string   // case-insensitive match on the title
+	Tags         []string // posts carrying any of the tags, or all of them when MatchAllTags is set
+	MatchAllTags bool
+	AuthorID     string
+	After        time.Time // created at or after
+	Before       time.Time // created at or before
+	Sort         []string  // applied in order; defaults to newest
+}
+
+// PageCursor is a position in a sorted listing: the sort values of the boundary post.
+// Listings continue strictly after it, or strictly before it when Backward is set.
+type PageCursor struct {
+	Sort      string // sort keys the cursor was issued for
+	ID        string
+	CreatedAt time.Time
+	ViewCount int
+	LikeCount int
+	Backward  bool
+}
@@ -2,36 +2,61 @@ package entities
 
 import (
 	"context"
+	"time"
 )
 
 // PaginationOptions holds the parameters for pagination.
+// A non-nil Cursor takes precedence over Page.
 type PaginationOptions struct {
-	Page  int64
-	Limit int64
+	Page   int64
+	Limit  int64
+	Cursor *PageCursor
 }
 
 // PostRepository defines the interface for post data operations.
 type IPostRepository interface {
 	Create(ctx context.Context, post *Post) (*Post, error)
+	// Import creates a post keeping its status, view count and timestamps
+	Import(ctx context.Context, post *Post) (*Post, error)
 	FindByID(ctx context.Context, id string) (*Post, error)
+	// FindByIDs keeps the order of ids and skips missing posts
+	FindByIDs(ctx context.Context, ids []string) ([]*Post, error)
+	// FindBySlug resolves both current and historical slugs
+	FindBySlug(ctx context.Context, slug string) (*Post, error)
 	FindAll(ctx context.Context, opts PaginationOptions) ([]*Post, error)
+	// FindByAuthorAndStatus ignores visibility and includes co-authored posts; empty authorID or status match everything
+	FindByAuthorAndStatus(ctx context.Context, authorID, status string, opts PaginationOptions) ([]*Post, error)
 
-	// CRUD operations
+	// CRUD operations. Lookups, listings and content updates skip posts in the trash.
+	// Every edit bumps the post's version. Update and Delete only apply to the expected
+	// version (post.Version for Update) and fail with ErrVersionConflict otherwise.
 	Update(ctx context.Context, id string, post *Post) (*Post, error)
-	Delete(ctx context.Context, id string) error
+	// Delete moves a post to the trash; DeletePermanently removes it for good
+	Delete(ctx context.Context, id string, version int) error
+	DeletePermanently(ctx context.Context, id string) error
 
-	// Search and filter operations
-	SearchByTitle(ctx context.Context, query string, opts PaginationOptions) ([]*Post, error)
-	SearchByAuthor(ctx context.Context, authorID string, opts PaginationOptions) ([]*Post, error)
-	FilterByTags(ctx context.Context, tags []string, opts PaginationOptions) ([]*Post, error)
-	FilterByDateRange(ctx context.Context, startDate, endDate string, opts PaginationOptions) ([]*Post, error)
+	// Trash
+	FindTrashed(ctx context.Context, authorID string, opts PaginationOptions) ([]*Post, error)
+	FindTrashedByID(ctx context.Context, id string) (*Post, error)
+	Restore(ctx context.Context, id string) (*Post, error)
+	// PurgeTrashed permanently removes up to limit posts deleted before the given time and returns their ids
+	PurgeTrashed(ctx context.Context, deletedBefore time.Time, limit int) ([]string, error)
 
-	// Like/Dislike operations
-	AddLike(ctx context.Context, postID, userID string) error
-	RemoveLike(ctx context.Context, postID, userID string) error
-	AddDislike(ctx context.Context, postID, userID string) error
-	RemoveDislike(ctx context.Context, postID, userID string) error
-	GetLikeStatus(ctx context.Context, postID, userID string) (liked bool, disliked bool, error error)
+	// Collaborators. AddCollaborator fails with ErrAlreadyCollaborator when the user already works on the post;
+	// TransferOwnership only succeeds while fromUserID still owns the post and keeps them on as a co-author.
+	AddCollaborator(ctx context.Context, postID string, collaborator Collaborator) (*Post, error)
+	RemoveCollaborator(ctx context.Context, postID, userID string) (*Post, error)
+	TransferOwnership(ctx context.Context, postID, fromUserID, toUserID string) (*Post, error)
+
+	// Search and filter operations over published, public posts
+	Query(ctx context.Context, query PostQuery, opts PaginationOptions) ([]*Post, error)
+
+	// Reaction counters, kept in sync by the reaction service
+	IncrementReactionCount(ctx context.Context, postID, kind string, delta int) error
+	// FindLegacyReactions returns posts that still carry like/dislike arrays
+	FindLegacyReactions(ctx context.Context, limit int) ([]*LegacyReactions, error)
+	// ClearLegacyReactions drops the arrays of a migrated post and stores its counters
+	ClearLegacyReactions(ctx context.Context, postID string, counts map[string]int) error
 
 	// View tracking methods
 	IncrementViewCount(ctx context.Context, postID string) error
@@ -39,4 +64,25 @@ type IPostRepository interface {
 	GetTotalViews(ctx context.Context) (int64, error)
 	GetPostsByViewCount(ctx context.Context, limit int) ([]*Post, error)
 	ResetViewCount(ctx context.Context, postID string) error
+
+	// Lifecycle operations
+	UpdateStatus(ctx context.Context, id, status string, publishAt time.Time) (*Post, error)
+	// PublishScheduled publishes the scheduled posts that are due and returns their ids
+	PublishScheduled(ctx context.Context, now time.Time) ([]string, error)
+	// SetHidden hides a post from the public for moderation, or shows it again
+	SetHidden(ctx context.Context, id string, hidden bool) (*Post, error)
+	// SetVisibility changes the visibility level of a post
+	SetVisibility(ctx context.Context, id, visibility string) (*Post, error)
+
+	// Comment counter, kept in sync by the comment service
+	IncrementCommentCount(ctx context.Context, postID string, delta int) error
+
+	// Tag usage and bulk rewrites
+	CountTags(ctx context.Context, publishedOnly bool) (map[string]int, error)
+	// FindByTags returns every post carrying any of the tags, trashed posts included, as stored
+	FindByTags(ctx context.Context, tags []string) ([]*Post, error)
+	ReplaceTags(ctx context.Context, from []string, to string) (int64, error)
+
+	// Rendered content cache
+	SaveRendered(ctx context.Context, id string, rendered *RenderedContent) error
 }
@@ -0,0 +1,28 @@
+package entities
+
+import (
+	"time"
+)
+
+// Likes and dislikes are reactions too; a user can hold only one of the two on a post.
+// Every other kind is an emoji reaction that can be combined freely.
+const (
+	ReactionLike    = "like"
+	ReactionDislike = "dislike"
+)
+
+// Reaction is a user's reaction of one kind to a post
+type Reaction struct {
+	ID        string
+	PostID    string
+	UserID    string
+	Kind      string
+	CreatedAt time.Time
+}
+
+// LegacyReactions are the like and dislike arrays posts stored before reactions had their own collection
+type LegacyReactions struct {
+	PostID   string
+	Likes    []string
+	Dislikes []string
+}
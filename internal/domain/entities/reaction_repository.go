@@ -0,0 +1,22 @@
+package entities
+
+import (
+	"context"
+	"time"
+)
+
+// IReactionRepository defines the interface for reaction data operations.
+type IReactionRepository interface {
+	// Add stores a reaction and reports whether it is new
+	Add(ctx context.Context, reaction *Reaction) (bool, error)
+	// AddMany stores reactions, skipping the ones that already exist
+	AddMany(ctx context.Context, reactions []*Reaction) error
+	// Remove deletes a reaction and reports whether there was one
+	Remove(ctx context.Context, postID, userID, kind string) (bool, error)
+	// FindKinds returns the kinds of reaction a user left on a post
+	FindKinds(ctx context.Context, postID, userID string) ([]string, error)
+	CountByPost(ctx context.Context, postID string) (map[string]int, error)
+	// CountSince counts the reactions of a kind left since the given time, keyed by post ID
+	CountSince(ctx context.Context, kind string, since time.Time) (map[string]int, error)
+	DeleteByPostID(ctx context.Context, postID string) error
+}
@@ -0,0 +1,55 @@
+package entities
+
+import (
+	"time"
+)
+
+// Report states. A report stays open until a moderator acts on its post.
+const (
+	ReportStatusOpen      = "open"
+	ReportStatusDismissed = "dismissed"
+	ReportStatusActioned  = "actioned"
+)
+
+// Reasons a reader can give when reporting a post
+const (
+	ReportReasonSpam       = "spam"
+	ReportReasonHarassment = "harassment"
+	ReportReasonHate       = "hate"
+	ReportReasonIllegal    = "illegal"
+	ReportReasonOther      = "other"
+)
+
+// Moderation actions. ModerationAutoHide is taken by the system once a post collects enough open reports.
+const (
+	ModerationDismiss  = "dismiss"
+	ModerationHide     = "hide"
+	ModerationDelete   = "delete"
+	ModerationWarn     = "warn"
+	ModerationAutoHide = "auto-hide"
+)
+
+// Report flags a post as abusive
+type Report struct {
+	ID          string
+	PostID      string
+	ReporterID  string
+	Reason      string // spam, harassment, hate, illegal or other
+	Details     string // free text from the reporter
+	Status      string // open, dismissed or actioned
+	ModeratorID string // who closed the report
+	CreatedAt   time.Time
+	ResolvedAt  time.Time
+}
+
+// ModerationAction records what was done about a reported post, and by whom
+type ModerationAction struct {
+	ID          string
+	ReportID    string // the report acted on, empty for automatic actions
+	PostID      string
+	AuthorID    string // owner of the post at the time
+	ModeratorID string // empty for automatic actions
+	Action      string
+	Note        string
+	CreatedAt   time.Time
+}
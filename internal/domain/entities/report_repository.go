@@ -0,0 +1,25 @@
+package entities
+
+import (
+	"context"
+	"time"
+)
+
+// IReportRepository defines the interface for report data operations.
+type IReportRepository interface {
+	// Create fails with ErrAlreadyReported while the reporter has an open report on the post
+	Create(ctx context.Context, report *Report) (*Report, error)
+	FindByID(ctx context.Context, id string) (*Report, error)
+	// FindAll lists reports oldest first; an empty status matches every report
+	FindAll(ctx context.Context, status string, opts PaginationOptions) ([]*Report, error)
+	CountOpenByPost(ctx context.Context, postID string) (int64, error)
+	// ResolveByPost closes every open report of a post and returns how many it closed
+	ResolveByPost(ctx context.Context, postID, status, moderatorID string, at time.Time) (int64, error)
+}
+
+// IModerationActionRepository stores the moderation log
+type IModerationActionRepository interface {
+	Create(ctx context.Context, action *ModerationAction) (*ModerationAction, error)
+	// FindAll lists actions newest first, optionally narrowed to a post or to a post author
+	FindAll(ctx context.Context, postID, authorID string, opts PaginationOptions) ([]*ModerationAction, error)
+}
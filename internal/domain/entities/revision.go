@@ -0,0 +1,18 @@
+package entities
+
+import (
+	"time"
+)
+
+// PostRevision is an immutable snapshot of a post taken every time it changes.
+type PostRevision struct {
+	ID           string
+	PostID       string
+	Number       int // 1 for the original version, increasing with every edit
+	EditorID     string
+	Title        string
+	Content      string
+	Tags         []string
+	RestoredFrom int // revision number this one was restored from, 0 for regular edits
+	CreatedAt    time.Time
+}
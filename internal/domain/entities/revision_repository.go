@@ -0,0 +1,15 @@
+package entities
+
+import (
+	"context"
+)
+
+// IRevisionRepository defines the interface for post revision data operations.
+type IRevisionRepository interface {
+	// Create stores a revision and assigns it the next number for its post
+	Create(ctx context.Context, revision *PostRevision) (*PostRevision, error)
+	FindByPost(ctx context.Context, postID string, opts PaginationOptions) ([]*PostRevision, error)
+	FindByNumber(ctx context.Context, postID string, number int) (*PostRevision, error)
+	CountByPost(ctx context.Context, postID string) (int64, error)
+	DeleteByPostID(ctx context.Context, postID string) error
+}
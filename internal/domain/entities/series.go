@@ -0,0 +1,17 @@
+package entities
+
+import (
+	"time"
+)
+
+// Series groups the parts of a multi-part post in reading order.
+// A post belongs to at most one series.
+type Series struct {
+	ID          string
+	AuthorID    string
+	Title       string
+	Description string
+	PostIDs     []string // in reading order
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
@@ -0,0 +1,19 @@
+package entities
+
+import (
+	"context"
+)
+
+// ISeriesRepository defines the interface for post series data operations.
+type ISeriesRepository interface {
+	Create(ctx context.Context, series *Series) (*Series, error)
+	FindByID(ctx context.Context, id string) (*Series, error)
+	FindByAuthor(ctx context.Context, authorID string, opts PaginationOptions) ([]*Series, error)
+	// FindByPostIDs returns the series containing any of the posts
+	FindByPostIDs(ctx context.Context, postIDs []string) ([]*Series, error)
+	Update(ctx context.Context, id string, series *Series) (*Series, error)
+	Delete(ctx context.Context, id string) error
+
+	// RemovePost takes a deleted post out of the series it was part of
+	RemovePost(ctx context.Context, postID string) error
+}
@@ -0,0 +1,15 @@
+package entities
+
+import (
+	"time"
+)
+
+// Tag is a registry entry for a normalized tag name.
+type Tag struct {
+	Name        string
+	Description string
+	Aliases     []string // former names that resolve to this tag, kept by rename and merge
+	PostCount   int      // published posts carrying the tag; computed, not stored
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
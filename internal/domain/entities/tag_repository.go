@@ -0,0 +1,19 @@
+package entities
+
+import (
+	"context"
+)
+
+// ITagRepository defines the interface for tag registry operations.
+type ITagRepository interface {
+	// EnsureTags registers the names that are not in the registry yet
+	EnsureTags(ctx context.Context, names []string) error
+	FindByName(ctx context.Context, name string) (*Tag, error)
+	FindAll(ctx context.Context) ([]*Tag, error)
+	// FindByAliases returns the tags that list any of the names as an alias
+	FindByAliases(ctx context.Context, names []string) ([]*Tag, error)
+	UpdateDescription(ctx context.Context, name, description string) (*Tag, error)
+	// Merge folds the source entries into target, creating it when missing,
+	// and records the source names as aliases of target
+	Merge(ctx context.Context, sources []string, target string) (*Tag, error)
+}
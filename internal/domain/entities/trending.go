@@ -0,0 +1,32 @@
+package entities
+
+import (
+	"time"
+)
+
+// Trending windows; each one ranks posts by the activity they received within it
+const (
+	TrendingDay   = "day"
+	TrendingWeek  = "week"
+	TrendingMonth = "month"
+)
+
+// TrendingWindows maps every trending window to its length
+var TrendingWindows = map[string]time.Duration{
+	TrendingDay:   24 * time.Hour,
+	TrendingWeek:  7 * 24 * time.Hour,
+	TrendingMonth: 30 * 24 * time.Hour,
+}
+
+// TrendingEntry is a ranked post of a trending snapshot
+type TrendingEntry struct {
+	PostID string
+	Score  float64
+}
+
+// TrendingSnapshot is the ranking of a window as of its last recomputation, best first
+type TrendingSnapshot struct {
+	Window     string
+	Entries    []TrendingEntry
+	ComputedAt time.Time
+}
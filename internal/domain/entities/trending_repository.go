@@ -0,0 +1,12 @@
+package entities
+
+import (
+	"context"
+)
+
+// ITrendingRepository stores the latest trending snapshot of each window.
+type ITrendingRepository interface {
+	// SaveSnapshot replaces the snapshot of the window
+	SaveSnapshot(ctx context.Context, snapshot *TrendingSnapshot) error
+	FindSnapshot(ctx context.Context, window string) (*TrendingSnapshot, error)
+}
@@ -0,0 +1,13 @@
+package entities
+
+import (
+	"context"
+	"time"
+)
+
+// IViewStatsRepository keeps hourly view counts per post, so recent activity can be told apart from lifetime totals.
+type IViewStatsRepository interface {
+	RecordView(ctx context.Context, postID string, at time.Time) error
+	// CountSince sums the views recorded since the given time, keyed by post ID
+	CountSince(ctx context.Context, since time.Time) (map[string]int, error)
+}
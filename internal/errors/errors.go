@@ -3,27 +3,45 @@ package errors
 import "errors"
 
 var (
-	ErrNotFound               = errors.New("not found") // broad sense: the resource in question doesn't exist
-	ErrUserNotFound           = errors.New("user not found")
-	ErrInvalidUserID          = errors.New("invalid user id")
-	ErrEmailAlreadyExists     = errors.New("email already exists")
-	ErrUsernameTaken          = errors.New("username already taken")
-	ErrInvalidCredentials     = errors.New("invalid credentials")
-	ErrUnauthorized           = errors.New("unauthorized access")
-	ErrForbidden              = errors.New("forbidden action")
-	ErrValidationFailed       = errors.New("validation failed")
-	ErrInternalServer         = errors.New("internal server error")
-	ErrInvalidToken           = errors.New("invalid token")
-	ErrUserIsUnverified       = errors.New("user is unverified")
-	ErrUserAlreadyAdmin       = errors.New("user is already an admin")
-	ErrUserNotAdmin           = errors.New("user is not an admin")
-	ErrCannotDemoteThemselves = errors.New("admin can not demote themself")
-	ErrInvalidPostID          = errors.New("invalid post id")
-	ErrNameCannotEmpty        = errors.New("name cannot be less that three alphabet")
-	ErrInvalidUsername        = errors.New("invalid username")
-	ErrInvalidInput           = errors.New("invalid input parameters")
-	ErrContentBlocked         = errors.New("content blocked by safety filters")
-	ErrPIILeak                = errors.New("potential PII detected")
-	ErrIllegalContent         = errors.New("illegal content request")
-	ErrFailedToParse          = errors.New("failed to parse content")
+	ErrNotFound                = errors.New("not found") // broad sense: the resource in question doesn't exist
+	ErrUserNotFound            = errors.New("user not found")
+	ErrInvalidUserID           = errors.New("invalid user id")
+	ErrEmailAlreadyExists      = errors.New("email already exists")
+	ErrUsernameTaken           = errors.New("username already taken")
+	ErrInvalidCredentials      = errors.New("invalid credentials")
+	ErrUnauthorized            = errors.New("unauthorized access")
+	ErrForbidden               = errors.New("forbidden action")
+	ErrValidationFailed        = errors.New("validation failed")
+	ErrInternalServer          = errors.New("internal server error")
+	ErrInvalidToken            = errors.New("invalid token")
+	ErrUserIsUnverified        = errors.New("user is unverified")
+	ErrUserAlreadyAdmin        = errors.New("user is already an admin")
+	ErrUserNotAdmin            = errors.New("user is not an admin")
+	ErrCannotDemoteThemselves  = errors.New("admin can not demote themself")
+	ErrInvalidPostID           = errors.New("invalid post id")
+	ErrNameCannotEmpty         = errors.New("name cannot be less that three alphabet")
+	ErrInvalidUsername         = errors.New("invalid username")
+	ErrInvalidInput            = errors.New("invalid input parameters")
+	ErrContentBlocked          = errors.New("content blocked by safety filters")
+	ErrPIILeak                 = errors.New("potential PII detected")
+	ErrIllegalContent          = errors.New("illegal content request")
+	ErrFailedToParse           = errors.New("failed to parse content")
+	ErrInvalidCommentID        = errors.New("invalid comment id")
+	ErrInvalidCursor           = errors.New("invalid pagination cursor")
+	ErrTagExists               = errors.New("tag already exists")
+	ErrInvalidSeriesID         = errors.New("invalid series id")
+	ErrPostInSeries            = errors.New("post already belongs to another series")
+	ErrInvalidImportJobID      = errors.New("invalid import job id")
+	ErrImportJobRunning        = errors.New("import job is already running")
+	ErrAlreadyCollaborator     = errors.New("user already works on this post")
+	ErrInvalidReportID         = errors.New("invalid report id")
+	ErrAlreadyReported         = errors.New("post already reported")
+	ErrReportClosed            = errors.New("report is already closed")
+	ErrInvalidMediaID          = errors.New("invalid media id")
+	ErrUnsupportedMediaType    = errors.New("unsupported media type")
+	ErrMediaTooLarge           = errors.New("media file is too large")
+	ErrMediaInUse              = errors.New("media is used by posts")
+	ErrVersionConflict         = errors.New("post was changed since the given version")
+	ErrVersionRequired         = errors.New("the version being edited is required")
+	ErrInvalidStatusTransition = errors.New("post can't move to that status")
 )
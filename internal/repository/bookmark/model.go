@@ -0,0 +1,49 @@
+package bookmarkrepo
+
+import (
+	"anchor-blog/internal/domain/entities"
+	"anchor-blog/internal/errors"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Bookmark struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	UserID    primitive.ObjectID `bson:"user_id"`
+	PostID    primitive.ObjectID `bson:"post_id"`
+	Folder    string             `bson:"folder"`
+	CreatedAt time.Time          `bson:"created_at"`
+}
+
+// ::::::: Mapping functions :::::::::::
+func ToDomainBookmark(b *Bookmark) *entities.Bookmark {
+	return &entities.Bookmark{
+		ID:        b.ID.Hex(),
+		UserID:    b.UserID.Hex(),
+		PostID:    b.PostID.Hex(),
+		Folder:    b.Folder,
+		CreatedAt: b.CreatedAt,
+	}
+}
+
+func FromDomainBookmark(b *entities.Bookmark) (*Bookmark, error) {
+	userID, err := primitive.ObjectIDFromHex(b.UserID)
+	if err != nil {
+		log.Println("invalid user id ", b.UserID)
+		return nil, errors.ErrInvalidUserID
+	}
+	postID, err := primitive.ObjectIDFromHex(b.PostID)
+	if err != nil {
+		log.Println("invalid post id ", b.PostID)
+		return nil, errors.ErrInvalidPostID
+	}
+
+	return &Bookmark{
+		UserID:    userID,
+		PostID:    postID,
+		Folder:    b.Folder,
+		CreatedAt: b.CreatedAt,
+	}, nil
+}
@@ -0,0 +1,203 @@
+package bookmarkrepo
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"anchor-blog/internal/domain/entities"
+	AppError "anchor-blog/internal/errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoBookmarkRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoBookmarkRepository creates a new bookmark repository with MongoDB implementation.
+func NewMongoBookmarkRepository(collection *mongo.Collection) entities.IBookmarkRepository {
+	ctx := context.Background()
+	if err := ensureBookmarkIndexes(ctx, collection); err != nil {
+		log.Printf("failed to create indexes on bookmarks: %v", err)
+	}
+	return &mongoBookmarkRepository{collection}
+}
+
+// a post is bookmarked at most once per user; the other indexes serve the reading list
+func ensureBookmarkIndexes(ctx context.Context, col *mongo.Collection) error {
+	_, err := col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "post_id", Value: 1}},
+			Options: options.Index().
+				SetName("idx_bookmark_user_post").
+				SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "folder", Value: 1}, {Key: "created_at", Value: -1}},
+			Options: options.Index().SetName("idx_bookmark_user_folder"),
+		},
+		{
+			Keys:    bson.D{{Key: "post_id", Value: 1}},
+			Options: options.Index().SetName("idx_bookmark_post"),
+		},
+	})
+	return err
+}
+
+func (r *mongoBookmarkRepository) Save(ctx context.Context, dBookmark *entities.Bookmark) (*entities.Bookmark, error) {
+	bookmark, err := FromDomainBookmark(dBookmark)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"user_id": bookmark.UserID, "post_id": bookmark.PostID}
+	update := bson.M{
+		"$set":         bson.M{"folder": bookmark.Folder},
+		"$setOnInsert": bson.M{"created_at": time.Now()},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var saved Bookmark
+	err = r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&saved)
+	if mongo.IsDuplicateKeyError(err) {
+		// a concurrent request inserted the same bookmark first; this now updates it
+		err = r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&saved)
+	}
+	if err != nil {
+		log.Printf("Error saving bookmark of post %s for user %s: %v", dBookmark.PostID, dBookmark.UserID, err)
+		return nil, AppError.ErrInternalServer
+	}
+
+	return ToDomainBookmark(&saved), nil
+}
+
+func (r *mongoBookmarkRepository) Delete(ctx context.Context, userID, postID string) error {
+	filter, err := userPostFilter(userID, postID)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.collection.DeleteOne(ctx, filter)
+	if err != nil {
+		log.Printf("Error deleting bookmark of post %s for user %s: %v", postID, userID, err)
+		return AppError.ErrInternalServer
+	}
+	if result.DeletedCount == 0 {
+		return AppError.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *mongoBookmarkRepository) FindByUser(ctx context.Context, userID, folder string, opts entities.PaginationOptions) ([]*entities.Bookmark, error) {
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, AppError.ErrInvalidUserID
+	}
+
+	filter := bson.M{"user_id": userObjID}
+	if folder != "" {
+		filter["folder"] = folder
+	}
+
+	findOptions := options.Find()
+	findOptions.SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}) // Newest first
+	findOptions.SetSkip((opts.Page - 1) * opts.Limit)
+	findOptions.SetLimit(opts.Limit)
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, AppError.ErrInternalServer
+	}
+	defer cursor.Close(ctx)
+
+	var bookmarks []Bookmark
+	if err := cursor.All(ctx, &bookmarks); err != nil {
+		return nil, AppError.ErrInternalServer
+	}
+
+	result := make([]*entities.Bookmark, len(bookmarks))
+	for idx := range bookmarks {
+		result[idx] = ToDomainBookmark(&bookmarks[idx])
+	}
+	return result, nil
+}
+
+func (r *mongoBookmarkRepository) Exists(ctx context.Context, userID, postID string) (bool, error) {
+	filter, err := userPostFilter(userID, postID)
+	if err != nil {
+		return false, err
+	}
+
+	count, err := r.collection.CountDocuments(ctx, filter, options.Count().SetLimit(1))
+	if err != nil {
+		return false, AppError.ErrInternalServer
+	}
+	return count > 0, nil
+}
+
+// ListFolders returns the folders of a user's bookmarks sorted by name, unfiled ones first
+func (r *mongoBookmarkRepository) ListFolders(ctx context.Context, userID string) ([]*entities.BookmarkFolder, error) {
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, AppError.ErrInvalidUserID
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"user_id": userObjID}}},
+		{{Key: "$group", Value: bson.M{"_id": "$folder", "count": bson.M{"$sum": 1}}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, AppError.ErrInternalServer
+	}
+	defer cursor.Close(ctx)
+
+	var groups []struct {
+		Name  string `bson:"_id"`
+		Count int    `bson:"count"`
+	}
+	if err := cursor.All(ctx, &groups); err != nil {
+		return nil, AppError.ErrInternalServer
+	}
+
+	result := make([]*entities.BookmarkFolder, len(groups))
+	for idx, group := range groups {
+		result[idx] = &entities.BookmarkFolder{Name: group.Name, Count: group.Count}
+	}
+	return result, nil
+}
+
+// DeleteByPostID removes the bookmarks of a deleted post
+func (r *mongoBookmarkRepository) DeleteByPostID(ctx context.Context, postID string) error {
+	postObjID, err := primitive.ObjectIDFromHex(postID)
+	if err != nil {
+		return AppError.ErrInvalidPostID
+	}
+
+	_, err = r.collection.DeleteMany(ctx, bson.M{"post_id": postObjID})
+	if err != nil {
+		log.Printf("Error deleting bookmarks of post %s: %v", postID, err)
+		return AppError.ErrInternalServer
+	}
+
+	return nil
+}
+
+func userPostFilter(userID, postID string) (bson.M, error) {
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, AppError.ErrInvalidUserID
+	}
+	postObjID, err := primitive.ObjectIDFromHex(postID)
+	if err != nil {
+		return nil, AppError.ErrInvalidPostID
+	}
+	return bson.M{"user_id": userObjID, "post_id": postObjID}, nil
+}
@@ -0,0 +1,80 @@
+package commentrepo
+
+import (
+	"anchor-blog/internal/domain/entities"
+	"anchor-blog/internal/errors"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Comment struct {
+	ID         primitive.ObjectID  `bson:"_id,omitempty"`
+	PostID     primitive.ObjectID  `bson:"post_id"`
+	AuthorID   primitive.ObjectID  `bson:"author_id"`
+	ParentID   *primitive.ObjectID `bson:"parent_id"`
+	Content    string              `bson:"content"`
+	ReplyCount int                 `bson:"reply_count"`
+	Deleted    bool                `bson:"deleted"`
+	CreatedAt  time.Time           `bson:"created_at"`
+	UpdatedAt  time.Time           `bson:"updated_at"`
+}
+
+// ::::::: Mapping functions :::::::::::
+func ToDomainComment(c *Comment) *entities.Comment {
+	parentID := ""
+	if c.ParentID != nil {
+		parentID = c.ParentID.Hex()
+	}
+	return &entities.Comment{
+		ID:         c.ID.Hex(),
+		PostID:     c.PostID.Hex(),
+		AuthorID:   c.AuthorID.Hex(),
+		ParentID:   parentID,
+		Content:    c.Content,
+		ReplyCount: c.ReplyCount,
+		Deleted:    c.Deleted,
+		CreatedAt:  c.CreatedAt,
+		UpdatedAt:  c.UpdatedAt,
+	}
+}
+
+func FromDomainComment(c *entities.Comment) (*Comment, error) {
+	id, err := primitive.ObjectIDFromHex(c.ID)
+	if err != nil {
+		log.Println("invalid comment id ", c.ID)
+		return nil, errors.ErrInvalidCommentID
+	}
+	postID, err := primitive.ObjectIDFromHex(c.PostID)
+	if err != nil {
+		log.Println("invalid post id ", c.PostID)
+		return nil, errors.ErrInvalidPostID
+	}
+	authorID, err := primitive.ObjectIDFromHex(c.AuthorID)
+	if err != nil {
+		log.Println("invalid author id ", c.AuthorID)
+		return nil, errors.ErrInvalidUserID
+	}
+	var parentID *primitive.ObjectID
+	if c.ParentID != "" {
+		pid, err := primitive.ObjectIDFromHex(c.ParentID)
+		if err != nil {
+			log.Println("invalid parent comment id ", c.ParentID)
+			return nil, errors.ErrInvalidCommentID
+		}
+		parentID = &pid
+	}
+
+	return &Comment{
+		ID:         id,
+		PostID:     postID,
+		AuthorID:   authorID,
+		ParentID:   parentID,
+		Content:    c.Content,
+		ReplyCount: c.ReplyCount,
+		Deleted:    c.Deleted,
+		CreatedAt:  c.CreatedAt,
+		UpdatedAt:  c.UpdatedAt,
+	}, nil
+}
@@ -0,0 +1,223 @@
+package commentrepo
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"anchor-blog/internal/domain/entities"
+	AppError "anchor-blog/internal/errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoCommentRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoCommentRepository creates a new comment repository with MongoDB implementation.
+func NewMongoCommentRepository(collection *mongo.Collection) entities.ICommentRepository {
+	ctx := context.Background()
+	if err := ensureCommentIndexes(ctx, collection); err != nil {
+		log.Printf("failed to create indexes on comments: %v", err)
+	}
+	return &mongoCommentRepository{collection}
+}
+
+// creates the indexes used for listing a post's thread
+func ensureCommentIndexes(ctx context.Context, col *mongo.Collection) error {
+	_, err := col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "post_id", Value: 1}, {Key: "parent_id", Value: 1}, {Key: "created_at", Value: 1}},
+			Options: options.Index().SetName("idx_comment_post_parent"),
+		},
+		{
+			Keys:    bson.D{{Key: "parent_id", Value: 1}, {Key: "created_at", Value: 1}},
+			Options: options.Index().SetName("idx_comment_parent"),
+		},
+	})
+	return err
+}
+
+func (r *mongoCommentRepository) Create(ctx context.Context, dComment *entities.Comment) (*entities.Comment, error) {
+	dComment.ID = primitive.NewObjectID().Hex()
+	comment, err := FromDomainComment(dComment)
+	if err != nil {
+		return nil, err
+	}
+	comment.CreatedAt = time.Now()
+	comment.UpdatedAt = time.Now()
+	comment.ReplyCount = 0
+	comment.Deleted = false
+
+	_, err = r.collection.InsertOne(ctx, comment)
+	if err != nil {
+		log.Printf("Error creating comment on post %s: %v", dComment.PostID, err)
+		return nil, AppError.ErrInternalServer
+	}
+
+	return ToDomainComment(comment), nil
+}
+
+func (r *mongoCommentRepository) FindByID(ctx context.Context, id string) (*entities.Comment, error) {
+	objId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		log.Println("unable to convert id to object id", id)
+		return nil, AppError.ErrInvalidCommentID
+	}
+
+	var comment Comment
+	err = r.collection.FindOne(ctx, bson.M{"_id": objId}).Decode(&comment)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, AppError.ErrNotFound
+		}
+		return nil, AppError.ErrInternalServer
+	}
+	return ToDomainComment(&comment), nil
+}
+
+// FindByPost returns the top-level comments of a post, oldest first
+func (r *mongoCommentRepository) FindByPost(ctx context.Context, postID string, opts entities.PaginationOptions) ([]*entities.Comment, error) {
+	postObjID, err := primitive.ObjectIDFromHex(postID)
+	if err != nil {
+		return nil, AppError.ErrInvalidPostID
+	}
+
+	filter := bson.M{"post_id": postObjID, "parent_id": nil}
+	return r.findWithFilter(ctx, filter, opts)
+}
+
+// FindReplies returns the direct replies to a comment, oldest first
+func (r *mongoCommentRepository) FindReplies(ctx context.Context, parentID string, opts entities.PaginationOptions) ([]*entities.Comment, error) {
+	parentObjID, err := primitive.ObjectIDFromHex(parentID)
+	if err != nil {
+		return nil, AppError.ErrInvalidCommentID
+	}
+
+	filter := bson.M{"parent_id": parentObjID}
+	return r.findWithFilter(ctx, filter, opts)
+}
+
+// UpdateContent edits the text of a live comment
+func (r *mongoCommentRepository) UpdateContent(ctx context.Context, id, content string) (*entities.Comment, error) {
+	objId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		log.Println("unable to convert id to object id", id)
+		return nil, AppError.ErrInvalidCommentID
+	}
+
+	filter := bson.M{"_id": objId, "deleted": false}
+	update := bson.M{"$set": bson.M{
+		"content":    content,
+		"updated_at": time.Now(),
+	}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		log.Printf("Error updating comment %s: %v", id, err)
+		return nil, AppError.ErrInternalServer
+	}
+
+	if result.MatchedCount == 0 {
+		return nil, AppError.ErrNotFound
+	}
+
+	return r.FindByID(ctx, id)
+}
+
+// Tombstone marks a comment as deleted and clears its content
+func (r *mongoCommentRepository) Tombstone(ctx context.Context, id string) error {
+	objId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		log.Println("unable to convert id to object id", id)
+		return AppError.ErrInvalidCommentID
+	}
+
+	filter := bson.M{"_id": objId, "deleted": false}
+	update := bson.M{"$set": bson.M{
+		"content":    "",
+		"deleted":    true,
+		"updated_at": time.Now(),
+	}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		log.Printf("Error deleting comment %s: %v", id, err)
+		return AppError.ErrInternalServer
+	}
+
+	if result.MatchedCount == 0 {
+		return AppError.ErrNotFound
+	}
+
+	return nil
+}
+
+// IncrementReplyCount adjusts the reply counter of a parent comment
+func (r *mongoCommentRepository) IncrementReplyCount(ctx context.Context, id string, delta int) error {
+	objId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		log.Println("unable to convert id to object id", id)
+		return AppError.ErrInvalidCommentID
+	}
+
+	filter := bson.M{"_id": objId}
+	update := bson.M{"$inc": bson.M{"reply_count": delta}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		log.Printf("Error updating reply count for comment %s: %v", id, err)
+		return AppError.ErrInternalServer
+	}
+
+	if result.MatchedCount == 0 {
+		return AppError.ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteByPostID removes every comment of a post (used when the post is deleted)
+func (r *mongoCommentRepository) DeleteByPostID(ctx context.Context, postID string) error {
+	postObjID, err := primitive.ObjectIDFromHex(postID)
+	if err != nil {
+		return AppError.ErrInvalidPostID
+	}
+
+	_, err = r.collection.DeleteMany(ctx, bson.M{"post_id": postObjID})
+	if err != nil {
+		log.Printf("Error deleting comments of post %s: %v", postID, err)
+		return AppError.ErrInternalServer
+	}
+
+	return nil
+}
+
+// findWithFilter is a helper method for paginated thread listings
+func (r *mongoCommentRepository) findWithFilter(ctx context.Context, filter bson.M, opts entities.PaginationOptions) ([]*entities.Comment, error) {
+	findOptions := options.Find()
+	findOptions.SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}) // Oldest first, like a conversation
+	findOptions.SetSkip((opts.Page - 1) * opts.Limit)
+	findOptions.SetLimit(opts.Limit)
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, AppError.ErrInternalServer
+	}
+	defer cursor.Close(ctx)
+
+	var comments []Comment
+	if err := cursor.All(ctx, &comments); err != nil {
+		return nil, AppError.ErrInternalServer
+	}
+
+	result := make([]*entities.Comment, len(comments))
+	for idx, comment := range comments {
+		result[idx] = ToDomainComment(&comment)
+	}
+	return result, nil
+}
@@ -0,0 +1,87 @@
+package importjobrepo
+
+import (
+	"anchor-blog/internal/domain/entities"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ImportJob struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty"`
+	Source       string             `bson:"source"`
+	FileName     string             `bson:"file_name"`
+	FilePath     string             `bson:"file_path"`
+	CreatedBy    string             `bson:"created_by"`
+	Status       string             `bson:"status"`
+	Total        int                `bson:"total"`
+	Processed    int                `bson:"processed"`
+	Imported     int                `bson:"imported"`
+	Skipped      int                `bson:"skipped"`
+	Failed       int                `bson:"failed"`
+	UsersCreated int                `bson:"users_created"`
+	Errors       []ImportItemError  `bson:"errors"`
+	Error        string             `bson:"error,omitempty"`
+	CreatedAt    time.Time          `bson:"created_at"`
+	UpdatedAt    time.Time          `bson:"updated_at"`
+	FinishedAt   time.Time          `bson:"finished_at,omitempty"`
+}
+
+type ImportItemError struct {
+	Item  string `bson:"item"`
+	Error string `bson:"error"`
+}
+
+// ::::::: Mapping functions :::::::::::
+func ToDomainImportJob(j *ImportJob) *entities.ImportJob {
+	errs := make([]entities.ImportItemError, len(j.Errors))
+	for i, e := range j.Errors {
+		errs[i] = entities.ImportItemError{Item: e.Item, Error: e.Error}
+	}
+	return &entities.ImportJob{
+		ID:           j.ID.Hex(),
+		Source:       j.Source,
+		FileName:     j.FileName,
+		FilePath:     j.FilePath,
+		CreatedBy:    j.CreatedBy,
+		Status:       j.Status,
+		Total:        j.Total,
+		Processed:    j.Processed,
+		Imported:     j.Imported,
+		Skipped:      j.Skipped,
+		Failed:       j.Failed,
+		UsersCreated: j.UsersCreated,
+		Errors:       errs,
+		Error:        j.Error,
+		CreatedAt:    j.CreatedAt,
+		UpdatedAt:    j.UpdatedAt,
+		FinishedAt:   j.FinishedAt,
+	}
+}
+
+func FromDomainImportJob(j *entities.ImportJob) *ImportJob {
+	errs := make([]ImportItemError, len(j.Errors))
+	for i, e := range j.Errors {
+		errs[i] = ImportItemError{Item: e.Item, Error: e.Error}
+	}
+	id, _ := primitive.ObjectIDFromHex(j.ID)
+	return &ImportJob{
+		ID:           id,
+		Source:       j.Source,
+		FileName:     j.FileName,
+		FilePath:     j.FilePath,
+		CreatedBy:    j.CreatedBy,
+		Status:       j.Status,
+		Total:        j.Total,
+		Processed:    j.Processed,
+		Imported:     j.Imported,
+		Skipped:      j.Skipped,
+		Failed:       j.Failed,
+		UsersCreated: j.UsersCreated,
+		Errors:       errs,
+		Error:        j.Error,
+		CreatedAt:    j.CreatedAt,
+		UpdatedAt:    j.UpdatedAt,
+		FinishedAt:   j.FinishedAt,
+	}
+}
@@ -0,0 +1,139 @@
+package importjobrepo
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"anchor-blog/internal/domain/entities"
+	AppError "anchor-blog/internal/errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoImportJobRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoImportJobRepository creates a new import job repository with MongoDB implementation.
+func NewMongoImportJobRepository(collection *mongo.Collection) entities.IImportJobRepository {
+	ctx := context.Background()
+	if err := ensureImportJobIndexes(ctx, collection); err != nil {
+		log.Printf("failed to create indexes on import jobs: %v", err)
+	}
+	return &mongoImportJobRepository{collection}
+}
+
+// creates the index used to find interrupted jobs
+func ensureImportJobIndexes(ctx context.Context, col *mongo.Collection) error {
+	_, err := col.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "status", Value: 1}, {Key: "updated_at", Value: 1}},
+		Options: options.Index().SetName("idx_import_job_status"),
+	})
+	return err
+}
+
+func (r *mongoImportJobRepository) Create(ctx context.Context, dJob *entities.ImportJob) (*entities.ImportJob, error) {
+	job := FromDomainImportJob(dJob)
+	job.ID = primitive.NewObjectID()
+	job.CreatedAt = time.Now()
+	job.UpdatedAt = job.CreatedAt
+
+	_, err := r.collection.InsertOne(ctx, job)
+	if err != nil {
+		log.Printf("Error creating import job for %s: %v", dJob.FileName, err)
+		return nil, AppError.ErrInternalServer
+	}
+	return ToDomainImportJob(job), nil
+}
+
+func (r *mongoImportJobRepository) FindByID(ctx context.Context, id string) (*entities.ImportJob, error) {
+	objId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, AppError.ErrInvalidImportJobID
+	}
+
+	var job ImportJob
+	err = r.collection.FindOne(ctx, bson.M{"_id": objId}).Decode(&job)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, AppError.ErrNotFound
+		}
+		log.Printf("Error finding import job %s: %v", id, err)
+		return nil, AppError.ErrInternalServer
+	}
+	return ToDomainImportJob(&job), nil
+}
+
+func (r *mongoImportJobRepository) FindAll(ctx context.Context, opts entities.PaginationOptions) ([]*entities.ImportJob, error) {
+	findOptions := options.Find()
+	findOptions.SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}})
+	findOptions.SetSkip((opts.Page - 1) * opts.Limit)
+	findOptions.SetLimit(opts.Limit)
+	return r.find(ctx, bson.M{}, findOptions)
+}
+
+func (r *mongoImportJobRepository) FindStale(ctx context.Context, before time.Time) ([]*entities.ImportJob, error) {
+	filter := bson.M{"status": entities.ImportJobRunning, "updated_at": bson.M{"$lt": before}}
+	return r.find(ctx, filter, options.Find())
+}
+
+func (r *mongoImportJobRepository) Claim(ctx context.Context, id string, staleBefore time.Time) (bool, error) {
+	objId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, AppError.ErrInvalidImportJobID
+	}
+
+	filter := bson.M{
+		"_id": objId,
+		"$or": bson.A{
+			bson.M{"status": bson.M{"$in": bson.A{entities.ImportJobQueued, entities.ImportJobFailed}}},
+			bson.M{"status": entities.ImportJobRunning, "updated_at": bson.M{"$lt": staleBefore}},
+		},
+	}
+	update := bson.M{"$set": bson.M{"status": entities.ImportJobRunning, "error": "", "updated_at": time.Now()}}
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		log.Printf("Error claiming import job %s: %v", id, err)
+		return false, AppError.ErrInternalServer
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+func (r *mongoImportJobRepository) Save(ctx context.Context, dJob *entities.ImportJob) error {
+	dJob.UpdatedAt = time.Now()
+	job := FromDomainImportJob(dJob)
+	if job.ID.IsZero() {
+		return AppError.ErrInvalidImportJobID
+	}
+
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": job.ID}, job)
+	if err != nil {
+		log.Printf("Error saving import job %s: %v", dJob.ID, err)
+		return AppError.ErrInternalServer
+	}
+	return nil
+}
+
+func (r *mongoImportJobRepository) find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]*entities.ImportJob, error) {
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		log.Printf("Error listing import jobs: %v", err)
+		return nil, AppError.ErrInternalServer
+	}
+	defer cursor.Close(ctx)
+
+	var jobs []ImportJob
+	if err := cursor.All(ctx, &jobs); err != nil {
+		return nil, AppError.ErrInternalServer
+	}
+
+	result := make([]*entities.ImportJob, len(jobs))
+	for i := range jobs {
+		result[i] = ToDomainImportJob(&jobs[i])
+	}
+	return result, nil
+}
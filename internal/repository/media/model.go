@@ -0,0 +1,88 @@
+package mediarepo
+
+import (
+	"anchor-blog/internal/domain/entities"
+	"anchor-blog/internal/errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Media struct {
+	ID          primitive.ObjectID   `bson:"_id,omitempty"`
+	OwnerID     primitive.ObjectID   `bson:"owner_id"`
+	Filename    string               `bson:"filename"`
+	ContentType string               `bson:"content_type"`
+	Size        int64                `bson:"size"`
+	Width       int                  `bson:"width,omitempty"`
+	Height      int                  `bson:"height,omitempty"`
+	Key         string               `bson:"key"`
+	Thumbnails  []Thumbnail          `bson:"thumbnails,omitempty"`
+	PostIDs     []primitive.ObjectID `bson:"post_ids"`
+	CreatedAt   time.Time            `bson:"created_at"`
+}
+
+type Thumbnail struct {
+	Name   string `bson:"name"`
+	Key    string `bson:"key"`
+	Width  int    `bson:"width"`
+	Height int    `bson:"height"`
+}
+
+// ::::::: Mapping functions :::::::::::
+func ToDomainMedia(m *Media) *entities.Media {
+	thumbnails := make([]entities.Thumbnail, len(m.Thumbnails))
+	for i, t := range m.Thumbnails {
+		thumbnails[i] = entities.Thumbnail{Name: t.Name, Key: t.Key, Width: t.Width, Height: t.Height}
+	}
+	postIDs := make([]string, len(m.PostIDs))
+	for i, id := range m.PostIDs {
+		postIDs[i] = id.Hex()
+	}
+
+	return &entities.Media{
+		ID:          m.ID.Hex(),
+		OwnerID:     m.OwnerID.Hex(),
+		Filename:    m.Filename,
+		ContentType: m.ContentType,
+		Size:        m.Size,
+		Width:       m.Width,
+		Height:      m.Height,
+		Key:         m.Key,
+		Thumbnails:  thumbnails,
+		PostIDs:     postIDs,
+		CreatedAt:   m.CreatedAt,
+	}
+}
+
+func FromDomainMedia(m *entities.Media) (*Media, error) {
+	ownerID, err := primitive.ObjectIDFromHex(m.OwnerID)
+	if err != nil {
+		return nil, errors.ErrInvalidUserID
+	}
+	var id primitive.ObjectID
+	if m.ID != "" {
+		if id, err = primitive.ObjectIDFromHex(m.ID); err != nil {
+			return nil, errors.ErrInvalidMediaID
+		}
+	}
+
+	thumbnails := make([]Thumbnail, len(m.Thumbnails))
+	for i, t := range m.Thumbnails {
+		thumbnails[i] = Thumbnail{Name: t.Name, Key: t.Key, Width: t.Width, Height: t.Height}
+	}
+
+	return &Media{
+		ID:          id,
+		OwnerID:     ownerID,
+		Filename:    m.Filename,
+		ContentType: m.ContentType,
+		Size:        m.Size,
+		Width:       m.Width,
+		Height:      m.Height,
+		Key:         m.Key,
+		Thumbnails:  thumbnails,
+		PostIDs:     []primitive.ObjectID{},
+		CreatedAt:   m.CreatedAt,
+	}, nil
+}
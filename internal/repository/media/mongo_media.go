@@ -0,0 +1,173 @@
+package mediarepo
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"anchor-blog/internal/domain/entities"
+	AppError "anchor-blog/internal/errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoMediaRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoMediaRepository creates a new media repository with MongoDB implementation.
+func NewMongoMediaRepository(collection *mongo.Collection) entities.IMediaRepository {
+	ctx := context.Background()
+	if err := ensureMediaIndexes(ctx, collection); err != nil {
+		log.Printf("failed to create indexes on media: %v", err)
+	}
+	return &mongoMediaRepository{collection}
+}
+
+// the library lists a user's uploads newest first; references are looked up by post
+func ensureMediaIndexes(ctx context.Context, col *mongo.Collection) error {
+	_, err := col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "owner_id", Value: 1}, {Key: "created_at", Value: -1}},
+			Options: options.Index().SetName("idx_media_owner"),
+		},
+		{
+			Keys:    bson.D{{Key: "post_ids", Value: 1}},
+			Options: options.Index().SetName("idx_media_post_ids"),
+		},
+	})
+	return err
+}
+
+func (r *mongoMediaRepository) Create(ctx context.Context, dMedia *entities.Media) (*entities.Media, error) {
+	media, err := FromDomainMedia(dMedia)
+	if err != nil {
+		return nil, err
+	}
+	if media.ID.IsZero() {
+		media.ID = primitive.NewObjectID()
+	}
+	media.CreatedAt = time.Now()
+
+	if _, err := r.collection.InsertOne(ctx, media); err != nil {
+		log.Printf("Error creating media of user %s: %v", dMedia.OwnerID, err)
+		return nil, AppError.ErrInternalServer
+	}
+	return ToDomainMedia(media), nil
+}
+
+func (r *mongoMediaRepository) FindByID(ctx context.Context, id string) (*entities.Media, error) {
+	objId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, AppError.ErrInvalidMediaID
+	}
+
+	var media Media
+	err = r.collection.FindOne(ctx, bson.M{"_id": objId}).Decode(&media)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, AppError.ErrNotFound
+		}
+		log.Printf("Error finding media %s: %v", id, err)
+		return nil, AppError.ErrInternalServer
+	}
+	return ToDomainMedia(&media), nil
+}
+
+func (r *mongoMediaRepository) FindByOwner(ctx context.Context, ownerID string, opts entities.PaginationOptions) ([]*entities.Media, error) {
+	ownerObjID, err := primitive.ObjectIDFromHex(ownerID)
+	if err != nil {
+		return nil, AppError.ErrInvalidUserID
+	}
+
+	findOptions := options.Find()
+	findOptions.SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}) // Newest first
+	findOptions.SetSkip((opts.Page - 1) * opts.Limit)
+	findOptions.SetLimit(opts.Limit)
+
+	cursor, err := r.collection.Find(ctx, bson.M{"owner_id": ownerObjID}, findOptions)
+	if err != nil {
+		log.Printf("Error listing media of user %s: %v", ownerID, err)
+		return nil, AppError.ErrInternalServer
+	}
+	defer cursor.Close(ctx)
+
+	var media []Media
+	if err := cursor.All(ctx, &media); err != nil {
+		return nil, AppError.ErrInternalServer
+	}
+
+	result := make([]*entities.Media, len(media))
+	for i := range media {
+		result[i] = ToDomainMedia(&media[i])
+	}
+	return result, nil
+}
+
+// Delete removes the media only while no post references it, so a post saved
+// at the same moment can't end up pointing at a deleted file
+func (r *mongoMediaRepository) Delete(ctx context.Context, id string) error {
+	objId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return AppError.ErrInvalidMediaID
+	}
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": objId, "post_ids": bson.M{"$size": 0}})
+	if err != nil {
+		log.Printf("Error deleting media %s: %v", id, err)
+		return AppError.ErrInternalServer
+	}
+	if result.DeletedCount > 0 {
+		return nil
+	}
+
+	count, err := r.collection.CountDocuments(ctx, bson.M{"_id": objId}, options.Count().SetLimit(1))
+	if err != nil {
+		return AppError.ErrInternalServer
+	}
+	if count == 0 {
+		return AppError.ErrNotFound
+	}
+	return AppError.ErrMediaInUse
+}
+
+func (r *mongoMediaRepository) SetPostReferences(ctx context.Context, postID string, mediaIDs []string) error {
+	postObjID, err := primitive.ObjectIDFromHex(postID)
+	if err != nil {
+		return AppError.ErrInvalidPostID
+	}
+	objIDs := make([]primitive.ObjectID, 0, len(mediaIDs))
+	for _, id := range mediaIDs {
+		if objId, err := primitive.ObjectIDFromHex(id); err == nil {
+			objIDs = append(objIDs, objId)
+		}
+	}
+
+	// media the post no longer uses
+	_, err = r.collection.UpdateMany(ctx,
+		bson.M{"post_ids": postObjID, "_id": bson.M{"$nin": objIDs}},
+		bson.M{"$pull": bson.M{"post_ids": postObjID}})
+	if err != nil {
+		log.Printf("Error dropping media references of post %s: %v", postID, err)
+		return AppError.ErrInternalServer
+	}
+	if len(objIDs) == 0 {
+		return nil
+	}
+
+	_, err = r.collection.UpdateMany(ctx,
+		bson.M{"_id": bson.M{"$in": objIDs}},
+		bson.M{"$addToSet": bson.M{"post_ids": postObjID}})
+	if err != nil {
+		log.Printf("Error recording media references of post %s: %v", postID, err)
+		return AppError.ErrInternalServer
+	}
+	return nil
+}
+
+func (r *mongoMediaRepository) RemovePostReferences(ctx context.Context, postID string) error {
+	return r.SetPostReferences(ctx, postID, nil)
+}
@@ -0,0 +1,392 @@
+package postrepo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"anchor-blog/internal/domain/entities"
+	redisclient "anchor-blog/pkg/redis"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// used when no TTL is configured
+const (
+	defaultPostCacheTTL = 5 * time.Minute
+	defaultListCacheTTL = 30 * time.Second
+)
+
+const (
+	// every cached entry is named after the current generation; bumping it drops them all at once
+	cacheGenerationKey = "posts:generation"
+	// how long reads bypass Redis after it failed
+	cacheRetryInterval = 10 * time.Second
+	// how long a shared load may take; it doesn't end with the request that started it
+	cacheLoadTimeout = 10 * time.Second
+)
+
+// PostCache is the part of the Redis client the cache needs; Get returns redisclient.ErrMiss for unknown keys
+type PostCache interface {
+	Get(ctx context.Context, key string) (string, error)
+	SetWithExpiration(ctx context.Context, key string, value interface{}, expiration time.Duration) error
+	Increment(ctx context.Context, key string) (int64, error)
+}
+
+// cachedPostRepository serves post lookups and list pages from Redis, reading through to the
+// repository behind it. Methods it doesn't override go straight to that repository.
+//
+// Writes that change what a post shows in listings drop every cached entry by bumping the
+// generation. Counter changes (views, reactions, comments) only bump the post's own stamp;
+// cached pages keep the old counters until they expire. Both are part of the entry names,
+// so a read that loaded a post before a write stores it under a name no one asks for again.
+//
+// When Redis fails the cache steps aside and everything is read from the repository. It is
+// tried again after a while, starting with a new generation since invalidations were missed.
+type cachedPostRepository struct {
+	entities.IPostRepository
+	cache         PostCache
+	postTTL       time.Duration
+	listTTL       time.Duration
+	retryInterval time.Duration
+	loads         singleflight.Group
+
+	mu      sync.Mutex
+	down    bool
+	retryAt time.Time
+}
+
+// NewCachedPostRepository puts a Redis cache in front of a post repository.
+// The TTLs are in seconds (0 uses 5 minutes for posts and 30 seconds for list pages).
+func NewCachedPostRepository(next entities.IPostRepository, cache PostCache, postTTL, listTTL int) entities.IPostRepository {
+	r := &cachedPostRepository{
+		IPostRepository: next,
+		cache:           cache,
+		postTTL:         defaultPostCacheTTL,
+		listTTL:         defaultListCacheTTL,
+		retryInterval:   cacheRetryInterval,
+	}
+	if postTTL > 0 {
+		r.postTTL = time.Duration(postTTL) * time.Second
+	}
+	if listTTL > 0 {
+		r.listTTL = time.Duration(listTTL) * time.Second
+	}
+	return r
+}
+
+func (r *cachedPostRepository) FindByID(ctx context.Context, id string) (*entities.Post, error) {
+	return readThrough(ctx, r, "id:"+id, postStampKey(id), r.postTTL, func(ctx context.Context) (*entities.Post, error) {
+		return r.IPostRepository.FindByID(ctx, id)
+	})
+}
+
+func (r *cachedPostRepository) FindAll(ctx context.Context, opts entities.PaginationOptions) ([]*entities.Post, error) {
+	return readThrough(ctx, r, listName("all", opts), "", r.listTTL, func(ctx context.Context) ([]*entities.Post, error) {
+		return r.IPostRepository.FindAll(ctx, opts)
+	})
+}
+
+func (r *cachedPostRepository) Query(ctx context.Context, query entities.PostQuery, opts entities.PaginationOptions) ([]*entities.Post, error) {
+	return readThrough(ctx, r, listName("query", query, opts), "", r.listTTL, func(ctx context.Context) ([]*entities.Post, error) {
+		return r.IPostRepository.Query(ctx, query, opts)
+	})
+}
+
+func (r *cachedPostRepository) GetPostsByViewCount(ctx context.Context, limit int) ([]*entities.Post, error) {
+	return readThrough(ctx, r, listName("popular", limit), "", r.listTTL, func(ctx context.Context) ([]*entities.Post, error) {
+		return r.IPostRepository.GetPostsByViewCount(ctx, limit)
+	})
+}
+
+// Writes that change listings
+
+func (r *cachedPostRepository) Create(ctx context.Context, post *entities.Post) (*entities.Post, error) {
+	return r.flushed(r.IPostRepository.Create(ctx, post))
+}
+
+func (r *cachedPostRepository) Import(ctx context.Context, post *entities.Post) (*entities.Post, error) {
+	return r.flushed(r.IPostRepository.Import(ctx, post))
+}
+
+func (r *cachedPostRepository) Update(ctx context.Context, id string, post *entities.Post) (*entities.Post, error) {
+	return r.flushed(r.IPostRepository.Update(ctx, id, post))
+}
+
+func (r *cachedPostRepository) Delete(ctx context.Context, id string, version int) error {
+	return r.flushedErr(r.IPostRepository.Delete(ctx, id, version))
+}
+
+func (r *cachedPostRepository) DeletePermanently(ctx context.Context, id string) error {
+	return r.flushedErr(r.IPostRepository.DeletePermanently(ctx, id))
+}
+
+func (r *cachedPostRepository) Restore(ctx context.Context, id string) (*entities.Post, error) {
+	return r.flushed(r.IPostRepository.Restore(ctx, id))
+}
+
+func (r *cachedPostRepository) PurgeTrashed(ctx context.Context, deletedBefore time.Time, limit int) ([]string, error) {
+	ids, err := r.IPostRepository.PurgeTrashed(ctx, deletedBefore, limit)
+	if len(ids) > 0 {
+		r.flush()
+	}
+	return ids, err
+}
+
+func (r *cachedPostRepository) AddCollaborator(ctx context.Context, postID string, collaborator entities.Collaborator) (*entities.Post, error) {
+	return r.flushed(r.IPostRepository.AddCollaborator(ctx, postID, collaborator))
+}
+
+func (r *cachedPostRepository) RemoveCollaborator(ctx context.Context, postID, userID string) (*entities.Post, error) {
+	return r.flushed(r.IPostRepository.RemoveCollaborator(ctx, postID, userID))
+}
+
+func (r *cachedPostRepository) TransferOwnership(ctx context.Context, postID, fromUserID, toUserID string) (*entities.Post, error) {
+	return r.flushed(r.IPostRepository.TransferOwnership(ctx, postID, fromUserID, toUserID))
+}
+
+func (r *cachedPostRepository) UpdateStatus(ctx context.Context, id, status string, publishAt time.Time) (*entities.Post, error) {
+	return r.flushed(r.IPostRepository.UpdateStatus(ctx, id, status, publishAt))
+}
+
+func (r *cachedPostRepository) PublishScheduled(ctx context.Context, now time.Time) ([]string, error) {
+	published, err := r.IPostRepository.PublishScheduled(ctx, now)
+	if len(published) > 0 {
+		r.flush()
+	}
+	return published, err
+}
+
+func (r *cachedPostRepository) SetHidden(ctx context.Context, id string, hidden bool) (*entities.Post, error) {
+	return r.flushed(r.IPostRepository.SetHidden(ctx, id, hidden))
+}
+
+func (r *cachedPostRepository) SetVisibility(ctx context.Context, id, visibility string) (*entities.Post, error) {
+	return r.flushed(r.IPostRepository.SetVisibility(ctx, id, visibility))
+}
+
+func (r *cachedPostRepository) ReplaceTags(ctx context.Context, from []string, to string) (int64, error) {
+	changed, err := r.IPostRepository.ReplaceTags(ctx, from, to)
+	if changed > 0 {
+		r.flush()
+	}
+	return changed, err
+}
+
+// Writes that only change one post
+
+func (r *cachedPostRepository) IncrementReactionCount(ctx context.Context, postID, kind string, delta int) error {
+	return r.forgotten(postID, r.IPostRepository.IncrementReactionCount(ctx, postID, kind, delta))
+}
+
+func (r *cachedPostRepository) ClearLegacyReactions(ctx context.Context, postID string, counts map[string]int) error {
+	return r.forgotten(postID, r.IPostRepository.ClearLegacyReactions(ctx, postID, counts))
+}
+
+func (r *cachedPostRepository) IncrementViewCount(ctx context.Context, postID string) error {
+	return r.forgotten(postID, r.IPostRepository.IncrementViewCount(ctx, postID))
+}
+
+func (r *cachedPostRepository) ResetViewCount(ctx context.Context, postID string) error {
+	return r.forgotten(postID, r.IPostRepository.ResetViewCount(ctx, postID))
+}
+
+func (r *cachedPostRepository) IncrementCommentCount(ctx context.Context, postID string, delta int) error {
+	return r.forgotten(postID, r.IPostRepository.IncrementCommentCount(ctx, postID, delta))
+}
+
+func (r *cachedPostRepository) SaveRendered(ctx context.Context, id string, rendered *entities.RenderedContent) error {
+	return r.forgotten(id, r.IPostRepository.SaveRendered(ctx, id, rendered))
+}
+
+// readThrough returns the cached value or loads and caches it. Concurrent misses of the same
+// entry share a single load; each caller decodes its own copy so results are never shared.
+// The load runs until it finishes or times out, whichever caller started it; a caller whose
+// own context ends stops waiting for it.
+func readThrough[T any](ctx context.Context, r *cachedPostRepository, name, stampKey string, ttl time.Duration, load func(context.Context) (T, error)) (T, error) {
+	var result T
+	key, cached := r.key(ctx, name, stampKey)
+	if cached {
+		data, err := r.cache.Get(ctx, key)
+		switch {
+		case err == nil:
+			if json.Unmarshal([]byte(data), &result) == nil {
+				return result, nil
+			}
+		case !errors.Is(err, redisclient.ErrMiss):
+			r.markDown(err)
+			cached = false
+		}
+	}
+
+	flight := key
+	if !cached {
+		flight = "uncached:" + name
+	}
+	loaded := r.loads.DoChan(flight, func() (interface{}, error) {
+		loadCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cacheLoadTimeout)
+		defer cancel()
+
+		value, err := load(loadCtx)
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		if cached {
+			if err := r.cache.SetWithExpiration(loadCtx, key, data, ttl); err != nil {
+				r.markDown(err)
+			}
+		}
+		return data, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return result, ctx.Err()
+	case res := <-loaded:
+		if res.Err != nil {
+			return result, res.Err
+		}
+		err := json.Unmarshal(res.Val.([]byte), &result)
+		return result, err
+	}
+}
+
+// key names an entry of the current generation; ok is false while Redis is unavailable.
+// An entry with a stamp key also carries the stamp stored there.
+func (r *cachedPostRepository) key(ctx context.Context, name, stampKey string) (key string, ok bool) {
+	if !r.available() {
+		return "", false
+	}
+	generation, err := r.counter(ctx, cacheGenerationKey)
+	if err != nil {
+		return "", false
+	}
+	key = "posts:" + generation + ":" + name
+	if stampKey != "" {
+		stamp, err := r.counter(ctx, stampKey)
+		if err != nil {
+			return "", false
+		}
+		key += ":" + stamp
+	}
+	return key, true
+}
+
+// counter reads a counter kept in Redis; a missing counter is 0
+func (r *cachedPostRepository) counter(ctx context.Context, key string) (string, error) {
+	value, err := r.cache.Get(ctx, key)
+	if errors.Is(err, redisclient.ErrMiss) {
+		return "0", nil
+	}
+	if err != nil {
+		r.markDown(err)
+		return "", err
+	}
+	return value, nil
+}
+
+// postStampKey names the counter bumped whenever a post's counters change
+func postStampKey(id string) string {
+	return "posts:stamp:" + id
+}
+
+// listName identifies a list page by its kind and arguments
+func listName(kind string, args ...interface{}) string {
+	data, _ := json.Marshal(args)
+	sum := sha256.Sum256(data)
+	return kind + ":" + hex.EncodeToString(sum[:16])
+}
+
+// flushed passes on the result of a write, dropping every cached entry when it succeeded
+func (r *cachedPostRepository) flushed(post *entities.Post, err error) (*entities.Post, error) {
+	if err == nil {
+		r.flush()
+	}
+	return post, err
+}
+
+func (r *cachedPostRepository) flushedErr(err error) error {
+	if err == nil {
+		r.flush()
+	}
+	return err
+}
+
+// forgotten passes on the result of a write, dropping the cached post when it succeeded
+func (r *cachedPostRepository) forgotten(id string, err error) error {
+	if err == nil {
+		r.forget(id)
+	}
+	return err
+}
+
+// Invalidation runs on its own context: a request that was cancelled after its write went
+// through must not leave the old post in the cache.
+
+func (r *cachedPostRepository) flush() {
+	if !r.available() {
+		return
+	}
+	if _, err := r.cache.Increment(context.Background(), cacheGenerationKey); err != nil {
+		r.markDown(err)
+	}
+}
+
+func (r *cachedPostRepository) forget(id string) {
+	if !r.available() {
+		return
+	}
+	if _, err := r.cache.Increment(context.Background(), postStampKey(id)); err != nil {
+		r.markDown(err)
+	}
+}
+
+// available reports whether Redis can be used. Once the retry interval has passed after a
+// failure, one caller starts a new generation; the cache is back when that succeeds.
+func (r *cachedPostRepository) available() bool {
+	r.mu.Lock()
+	if !r.down {
+		r.mu.Unlock()
+		return true
+	}
+	if time.Now().Before(r.retryAt) {
+		r.mu.Unlock()
+		return false
+	}
+	// the others keep bypassing the cache while this one tries
+	r.retryAt = time.Now().Add(r.retryInterval)
+	r.mu.Unlock()
+
+	generation, err := r.cache.Increment(context.Background(), cacheGenerationKey)
+	if err != nil {
+		return false
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.down {
+		r.down = false
+		log.Printf("Post cache is available again (generation %d)", generation)
+	}
+	return true
+}
+
+// markDown makes reads bypass Redis for the retry interval
+func (r *cachedPostRepository) markDown(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.down {
+		log.Printf("Post cache unavailable, reading posts from the database: %v", err)
+	}
+	r.down = true
+	r.retryAt = time.Now().Add(r.retryInterval)
+}
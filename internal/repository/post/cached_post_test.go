@@ -0,0 +1,322 @@
+package postrepo
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"anchor-blog/internal/domain/entities"
+	AppError "anchor-blog/internal/errors"
+	redisclient "anchor-blog/pkg/redis"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryCache is an in-memory stand-in for Redis that can be switched off
+type memoryCache struct {
+	mu     sync.Mutex
+	values map[string]string
+	down   bool
+}
+
+var errCacheDown = errors.New("connection refused")
+
+func newMemoryCache() *memoryCache {
+	return &memoryCache{values: map[string]string{}}
+}
+
+func (c *memoryCache) Get(ctx context.Context, key string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.down {
+		return "", errCacheDown
+	}
+	value, ok := c.values[key]
+	if !ok {
+		return "", redisclient.ErrMiss
+	}
+	return value, nil
+}
+
+func (c *memoryCache) SetWithExpiration(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.down {
+		return errCacheDown
+	}
+	c.values[key] = string(value.([]byte))
+	return nil
+}
+
+func (c *memoryCache) Increment(ctx context.Context, key string) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.down {
+		return 0, errCacheDown
+	}
+	n, _ := strconv.ParseInt(c.values[key], 10, 64)
+	n++
+	c.values[key] = strconv.FormatInt(n, 10)
+	return n, nil
+}
+
+func (c *memoryCache) setDown(down bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.down = down
+}
+
+// countingRepository serves a fixed set of posts and counts the reads that reach it
+type countingRepository struct {
+	entities.IPostRepository
+	mu      sync.Mutex
+	posts   map[string]*entities.Post
+	finds   atomic.Int32
+	lists   atomic.Int32
+	release chan struct{} // when set, FindByID waits for it
+}
+
+func (r *countingRepository) FindByID(ctx context.Context, id string) (*entities.Post, error) {
+	r.finds.Add(1)
+	if r.release != nil {
+		<-r.release
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	post, ok := r.posts[id]
+	if !ok {
+		return nil, AppError.ErrNotFound
+	}
+	copied := *post
+	return &copied, nil
+}
+
+func (r *countingRepository) FindAll(ctx context.Context, opts entities.PaginationOptions) ([]*entities.Post, error) {
+	r.lists.Add(1)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	posts := []*entities.Post{}
+	for _, post := range r.posts {
+		copied := *post
+		posts = append(posts, &copied)
+	}
+	return posts, nil
+}
+
+func (r *countingRepository) Update(ctx context.Context, id string, post *entities.Post) (*entities.Post, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	updated := *post
+	updated.Version++
+	r.posts[id] = &updated
+	return &updated, nil
+}
+
+func (r *countingRepository) IncrementViewCount(ctx context.Context, postID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.posts[postID].ViewCount++
+	return nil
+}
+
+func newCountingRepository() *countingRepository {
+	return &countingRepository{posts: map[string]*entities.Post{
+		"post-1": {ID: "post-1", Title: "First", Version: 1, Reactions: map[string]int{"like": 2}},
+	}}
+}
+
+func TestCachedPostRepository_FindByID_ReadsThrough(t *testing.T) {
+	// Setup
+	next := newCountingRepository()
+	repo := NewCachedPostRepository(next, newMemoryCache(), 0, 0)
+	ctx := context.Background()
+
+	// Execute
+	first, err := repo.FindByID(ctx, "post-1")
+	require.NoError(t, err)
+	first.Title = "changed by the caller"
+	second, err := repo.FindByID(ctx, "post-1")
+	require.NoError(t, err)
+	_, missingErr := repo.FindByID(ctx, "post-2")
+	_, missingAgainErr := repo.FindByID(ctx, "post-2")
+
+	// Assert: errors are not cached
+	assert.Equal(t, "First", second.Title)
+	assert.Equal(t, 2, second.Reactions["like"])
+	assert.Equal(t, AppError.ErrNotFound, missingErr)
+	assert.Equal(t, AppError.ErrNotFound, missingAgainErr)
+	assert.Equal(t, int32(3), next.finds.Load())
+}
+
+func TestCachedPostRepository_Invalidation(t *testing.T) {
+	// Setup
+	next := newCountingRepository()
+	repo := NewCachedPostRepository(next, newMemoryCache(), 0, 0)
+	ctx := context.Background()
+	opts := entities.PaginationOptions{Page: 1, Limit: 10}
+
+	post, _ := repo.FindByID(ctx, "post-1")
+	repo.FindAll(ctx, opts)
+
+	// Execute: a view only drops the post itself
+	require.NoError(t, repo.IncrementViewCount(ctx, "post-1"))
+	viewed, _ := repo.FindByID(ctx, "post-1")
+	page, _ := repo.FindAll(ctx, opts)
+
+	// Assert
+	assert.Equal(t, 1, viewed.ViewCount)
+	assert.Equal(t, 0, page[0].ViewCount, "pages keep their counters until they expire")
+	assert.Equal(t, int32(2), next.finds.Load())
+	assert.Equal(t, int32(1), next.lists.Load())
+
+	// Execute: an update drops posts and pages
+	post.Title = "Renamed"
+	_, err := repo.Update(ctx, "post-1", post)
+	require.NoError(t, err)
+	updated, _ := repo.FindByID(ctx, "post-1")
+	page, _ = repo.FindAll(ctx, opts)
+
+	// Assert
+	assert.Equal(t, "Renamed", updated.Title)
+	assert.Equal(t, "Renamed", page[0].Title)
+	assert.Equal(t, int32(3), next.finds.Load())
+	assert.Equal(t, int32(2), next.lists.Load())
+}
+
+func TestCachedPostRepository_ConcurrentMissesLoadOnce(t *testing.T) {
+	// Setup
+	next := newCountingRepository()
+	next.release = make(chan struct{})
+	repo := NewCachedPostRepository(next, newMemoryCache(), 0, 0)
+
+	// Execute
+	var wg sync.WaitGroup
+	titles := make([]string, 10)
+	for i := range titles {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			post, err := repo.FindByID(context.Background(), "post-1")
+			if err == nil {
+				titles[i] = post.Title
+			}
+		}(i)
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(next.release)
+	wg.Wait()
+
+	// Assert
+	assert.Equal(t, int32(1), next.finds.Load())
+	for _, title := range titles {
+		assert.Equal(t, "First", title)
+	}
+}
+
+func TestCachedPostRepository_RedisDown(t *testing.T) {
+	// Setup
+	next := newCountingRepository()
+	cache := newMemoryCache()
+	repo := NewCachedPostRepository(next, cache, 0, 0)
+	ctx := context.Background()
+
+	repo.FindByID(ctx, "post-1")
+	cache.setDown(true)
+
+	// Execute: reads fall back to the repository, and the missed invalidation is not lost
+	post, err := repo.FindByID(ctx, "post-1")
+	require.NoError(t, err)
+	post.Title = "Renamed while Redis was down"
+	_, updateErr := repo.Update(ctx, "post-1", post)
+
+	repo.(*cachedPostRepository).retryInterval = 0
+	repo.(*cachedPostRepository).retryAt = time.Time{}
+	cache.setDown(false)
+	recovered, recoveredErr := repo.FindByID(ctx, "post-1")
+
+	// Assert
+	assert.NoError(t, updateErr)
+	assert.NoError(t, recoveredErr)
+	assert.Equal(t, "Renamed while Redis was down", recovered.Title)
+	assert.Equal(t, int32(3), next.finds.Load())
+}
+
+// lateRepository hands out what it read before a write once it is released, like a slow query
+type lateRepository struct {
+	*countingRepository
+	read    chan struct{}
+	release chan struct{}
+}
+
+func (r *lateRepository) FindByID(ctx context.Context, id string) (*entities.Post, error) {
+	post, err := r.countingRepository.FindByID(ctx, id)
+	if r.read != nil {
+		close(r.read)
+		r.read = nil
+		<-r.release
+	}
+	return post, err
+}
+
+func TestCachedPostRepository_LoadOutlivesCancelledCaller(t *testing.T) {
+	// Setup
+	next := newCountingRepository()
+	next.release = make(chan struct{})
+	repo := NewCachedPostRepository(next, newMemoryCache(), 0, 0)
+	first, cancel := context.WithCancel(context.Background())
+
+	// Execute: the caller that started the load gives up while another one waits for it
+	firstErr := make(chan error)
+	go func() {
+		_, err := repo.FindByID(first, "post-1")
+		firstErr <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+	secondDone := make(chan struct{})
+	var second *entities.Post
+	var secondErr error
+	go func() {
+		second, secondErr = repo.FindByID(context.Background(), "post-1")
+		close(secondDone)
+	}()
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	cancelledErr := <-firstErr
+	close(next.release)
+	<-secondDone
+
+	// Assert
+	assert.ErrorIs(t, cancelledErr, context.Canceled)
+	require.NoError(t, secondErr)
+	assert.Equal(t, "First", second.Title)
+	assert.Equal(t, int32(1), next.finds.Load())
+}
+
+func TestCachedPostRepository_StaleLoadNotServed(t *testing.T) {
+	// Setup
+	next := &lateRepository{countingRepository: newCountingRepository(), read: make(chan struct{}), release: make(chan struct{})}
+	repo := NewCachedPostRepository(next, newMemoryCache(), 0, 0)
+	ctx := context.Background()
+
+	// Execute: a view lands after a read has loaded the post but before it is cached
+	loaded := make(chan struct{})
+	read := next.read
+	go func() {
+		repo.FindByID(ctx, "post-1")
+		close(loaded)
+	}()
+	<-read
+	require.NoError(t, repo.IncrementViewCount(ctx, "post-1"))
+	close(next.release)
+	<-loaded
+	post, err := repo.FindByID(ctx, "post-1")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, post.ViewCount)
+}
@@ -10,31 +10,125 @@ import (
 )
 
 type Post struct {
-	ID        primitive.ObjectID   `bson:"_id,omitempty" json:"id"`
-	Title     string               `bson:"title" json:"title"`
-	Content   string               `bson:"content" json:"content"`
-	AuthorID  primitive.ObjectID   `bson:"author_id" json:"author_id"`
-	Tags      []string             `bson:"tags" json:"tags"`
-	ViewCount int                  `bson:"view_count" json:"view_count"`
-	Likes     []primitive.ObjectID `bson:"likes" json:"likes"`
-	Dislikes  []primitive.ObjectID `bson:"dislikes" json:"dislikes"`
-	CreatedAt time.Time            `bson:"created_at" json:"created_at"`
-	UpdatedAt time.Time            `bson:"updated_at" json:"updated_at"`
+	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Title         string             `bson:"title" json:"title"`
+	Slug          string             `bson:"slug,omitempty" json:"slug"`
+	OldSlugs      []string           `bson:"old_slugs,omitempty" json:"old_slugs"`
+	Content       string             `bson:"content" json:"content"`
+	AuthorID      primitive.ObjectID `bson:"author_id" json:"author_id"`
+	Collaborators []Collaborator     `bson:"collaborators,omitempty" json:"collaborators"`
+	Tags          []string           `bson:"tags" json:"tags"`
+	ViewCount     int                `bson:"view_count" json:"view_count"`
+	Reactions     map[string]int     `bson:"reaction_counts" json:"reaction_counts"`
+	CommentCount  int                `bson:"comment_count" json:"comment_count"`
+	Status        string             `bson:"status" json:"status"`
+	PublishAt     time.Time          `bson:"publish_at" json:"publish_at"`
+	Hidden        bool               `bson:"hidden,omitempty" json:"hidden"`
+	Visibility    string             `bson:"visibility,omitempty" json:"visibility"`
+	Rendered      *RenderedContent   `bson:"rendered,omitempty" json:"rendered"`
+	Version       int                `bson:"version" json:"version"`
+	CreatedAt     time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt     time.Time          `bson:"updated_at" json:"updated_at"`
+	DeletedAt     time.Time          `bson:"deleted_at,omitempty" json:"deleted_at"`
+}
+
+type Collaborator struct {
+	UserID  primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Role    string             `bson:"role" json:"role"`
+	AddedAt time.Time          `bson:"added_at" json:"added_at"`
+}
+
+type RenderedContent struct {
+	HTML        string     `bson:"html" json:"html"`
+	TOC         []TOCEntry `bson:"toc" json:"toc"`
+	Excerpt     string     `bson:"excerpt" json:"excerpt"`
+	ReadingTime int        `bson:"reading_time" json:"reading_time"`
+	Version     int        `bson:"version" json:"version"`
+}
+
+type TOCEntry struct {
+	Level int    `bson:"level" json:"level"`
+	Text  string `bson:"text" json:"text"`
+	ID    string `bson:"id" json:"id"`
 }
 
 // ::::::: Mapping functions :::::::::::
 func ToDomainPost(p *Post) *entities.Post {
 	return &entities.Post{
-		ID:        p.ID.Hex(),
-		Title:     p.Title,
-		Content:   p.Content,
-		AuthorID:  p.AuthorID.Hex(),
-		Tags:      p.Tags,
-		ViewCount: p.ViewCount,
-		Likes:     objectIDsToHex(p.Likes),
-		Dislikes:  objectIDsToHex(p.Dislikes),
-		CreatedAt: p.CreatedAt,
-		UpdatedAt: p.UpdatedAt,
+		ID:            p.ID.Hex(),
+		Title:         p.Title,
+		Slug:          p.Slug,
+		OldSlugs:      p.OldSlugs,
+		Content:       p.Content,
+		AuthorID:      p.AuthorID.Hex(),
+		Collaborators: ToDomainCollaborators(p.Collaborators),
+		Tags:          p.Tags,
+		ViewCount:     p.ViewCount,
+		Reactions:     copyCounts(p.Reactions),
+		CommentCount:  p.CommentCount,
+		Status:        p.Status,
+		PublishAt:     p.PublishAt,
+		Hidden:        p.Hidden,
+		Visibility:    p.Visibility,
+		Rendered:      ToDomainRendered(p.Rendered),
+		Version:       p.Version,
+		CreatedAt:     p.CreatedAt,
+		UpdatedAt:     p.UpdatedAt,
+		DeletedAt:     p.DeletedAt,
+	}
+}
+
+func ToDomainCollaborators(collaborators []Collaborator) []entities.Collaborator {
+	result := make([]entities.Collaborator, len(collaborators))
+	for i, collaborator := range collaborators {
+		result[i] = entities.Collaborator{
+			UserID:  collaborator.UserID.Hex(),
+			Role:    collaborator.Role,
+			AddedAt: collaborator.AddedAt,
+		}
+	}
+	return result
+}
+
+func FromDomainCollaborator(c entities.Collaborator) (Collaborator, error) {
+	userID, err := primitive.ObjectIDFromHex(c.UserID)
+	if err != nil {
+		return Collaborator{}, err
+	}
+	return Collaborator{UserID: userID, Role: c.Role, AddedAt: c.AddedAt}, nil
+}
+
+func ToDomainRendered(r *RenderedContent) *entities.RenderedContent {
+	if r == nil {
+		return nil
+	}
+	toc := make([]entities.TOCEntry, len(r.TOC))
+	for i, entry := range r.TOC {
+		toc[i] = entities.TOCEntry{Level: entry.Level, Text: entry.Text, ID: entry.ID}
+	}
+	return &entities.RenderedContent{
+		HTML:        r.HTML,
+		TOC:         toc,
+		Excerpt:     r.Excerpt,
+		ReadingTime: r.ReadingTime,
+		Version:     r.Version,
+	}
+}
+
+func FromDomainRendered(r *entities.RenderedContent) *RenderedContent {
+	if r == nil {
+		return nil
+	}
+	toc := make([]TOCEntry, len(r.TOC))
+	for i, entry := range r.TOC {
+		toc[i] = TOCEntry{Level: entry.Level, Text: entry.Text, ID: entry.ID}
+	}
+	return &RenderedContent{
+		HTML:        r.HTML,
+		TOC:         toc,
+		Excerpt:     r.Excerpt,
+		ReadingTime: r.ReadingTime,
+		Version:     r.Version,
 	}
 }
 
@@ -46,6 +140,15 @@ func objectIDsToHex(ids []primitive.ObjectID) []string {
 	return result
 }
 
+// copyCounts never returns nil, so posts without reactions carry an empty map
+func copyCounts(counts map[string]int) map[string]int {
+	result := make(map[string]int, len(counts))
+	for kind, count := range counts {
+		result[kind] = count
+	}
+	return result
+}
+
 func FromDomainPost(p *entities.Post) (*Post, error) {
 	id, err := primitive.ObjectIDFromHex(p.ID)
 	if err != nil {
@@ -55,26 +158,33 @@ func FromDomainPost(p *entities.Post) (*Post, error) {
 	if err != nil {
 		return nil, err
 	}
-	likes, err := hexToObjectIDs(p.Likes)
-	if err != nil {
-		return nil, err
-	}
-	dislikes, err := hexToObjectIDs(p.Dislikes)
-	if err != nil {
-		return nil, err
+	collaborators := make([]Collaborator, len(p.Collaborators))
+	for i, c := range p.Collaborators {
+		collaborators[i], err = FromDomainCollaborator(c)
+		if err != nil {
+			return nil, err
+		}
 	}
-
 	return &Post{
-		ID:        id,
-		Title:     p.Title,
-		Content:   p.Content,
-		AuthorID:  authorID,
-		Tags:      p.Tags,
-		ViewCount: p.ViewCount,
-		Likes:     likes,
-		Dislikes:  dislikes,
-		CreatedAt: p.CreatedAt,
-		UpdatedAt: p.UpdatedAt,
+		ID:            id,
+		Title:         p.Title,
+		Slug:          p.Slug,
+		OldSlugs:      p.OldSlugs,
+		Content:       p.Content,
+		AuthorID:      authorID,
+		Collaborators: collaborators,
+		Tags:          p.Tags,
+		ViewCount:     p.ViewCount,
+		Reactions:     copyCounts(p.Reactions),
+		CommentCount:  p.CommentCount,
+		Status:        p.Status,
+		PublishAt:     p.PublishAt,
+		Hidden:        p.Hidden,
+		Visibility:    p.Visibility,
+		Rendered:      FromDomainRendered(p.Rendered),
+		CreatedAt:     p.CreatedAt,
+		UpdatedAt:     p.UpdatedAt,
+		DeletedAt:     p.DeletedAt,
 	}, nil
 }
 
@@ -3,10 +3,12 @@ package postrepo
 import (
 	"context"
 	"log"
+	"regexp"
 	"time"
 
 	"anchor-blog/internal/domain/entities"
 	AppError "anchor-blog/internal/errors"
+	"anchor-blog/pkg/slugutil"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -18,27 +20,143 @@ type mongoPostRepository struct {
 	collection *mongo.Collection
 }
 
+// how many collision suffixes are tried before giving up on a slug
+const maxSlugAttempts = 50
+
 // NewMongoPostRepository creates a new post repository with MongoDB implementation.
 func NewMongoPostRepository(collection *mongo.Collection) entities.IPostRepository {
+	ctx := context.Background()
+	if err := ensurePostIndexes(ctx, collection); err != nil {
+		log.Printf("failed to create indexes on posts: %v", err)
+	}
 	return &mongoPostRepository{
 		collection,
 	}
 }
 
+// creates the slug indexes; posts stored before slugs existed are left out of the unique one.
+// The trash indexes only cover deleted posts.
+func ensurePostIndexes(ctx context.Context, col *mongo.Collection) error {
+	_, err := col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "slug", Value: 1}},
+			Options: options.Index().
+				SetName("idx_post_slug").
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{"slug": bson.M{"$type": "string"}}),
+		},
+		{
+			Keys:    bson.D{{Key: "old_slugs", Value: 1}},
+			Options: options.Index().SetName("idx_post_old_slugs"),
+		},
+		{
+			Keys:    bson.D{{Key: "collaborators.user_id", Value: 1}},
+			Options: options.Index().SetName("idx_post_collaborators"),
+		},
+		{
+			Keys: bson.D{{Key: "author_id", Value: 1}, {Key: "deleted_at", Value: -1}},
+			Options: options.Index().
+				SetName("idx_post_trash").
+				SetPartialFilterExpression(bson.M{"deleted_at": bson.M{"$exists": true}}),
+		},
+		{
+			Keys: bson.D{{Key: "deleted_at", Value: 1}},
+			Options: options.Index().
+				SetName("idx_post_deleted_at").
+				SetPartialFilterExpression(bson.M{"deleted_at": bson.M{"$exists": true}}),
+		},
+	})
+	return err
+}
+
 func (r *mongoPostRepository) Create(ctx context.Context, dPost *entities.Post) (*entities.Post, error) {
 	dPost.ID = primitive.NewObjectID().Hex()
 	post, err := FromDomainPost(dPost)
 	if err != nil {
 		return nil, err
 	}
+	post.Slug, err = r.uniqueSlug(ctx, dPost.Title, post.ID)
+	if err != nil {
+		return nil, err
+	}
+	post.OldSlugs = nil
 	post.CreatedAt = time.Now()
 	post.UpdatedAt = time.Now()
-	post.Likes = []primitive.ObjectID{}
-	post.Dislikes = []primitive.ObjectID{}
+	if post.Status == "" {
+		post.Status = entities.PostStatusPublished
+	}
+	if post.Visibility == "" {
+		post.Visibility = entities.PostVisibilityPublic
+	}
+	if post.Status == entities.PostStatusPublished && post.PublishAt.IsZero() {
+		post.PublishAt = post.CreatedAt
+	}
+	post.Reactions = map[string]int{}
 	post.ViewCount = 0
+	post.Version = 1
+
+	_, err = r.collection.InsertOne(ctx, post)
+	if mongo.IsDuplicateKeyError(err) {
+		// another post claimed the slug between the check and the insert
+		post.Slug, err = r.uniqueSlug(ctx, dPost.Title, post.ID)
+		if err != nil {
+			return nil, err
+		}
+		_, err = r.collection.InsertOne(ctx, post)
+	}
+	if err != nil {
+		return nil, AppError.ErrInternalServer
+	}
+
+	return ToDomainPost(post), nil
+}
+
+// Import stores a post brought over from elsewhere, keeping its status, view count and timestamps.
+// The given slug is kept when it is free, otherwise it gets a collision suffix like any new post.
+func (r *mongoPostRepository) Import(ctx context.Context, dPost *entities.Post) (*entities.Post, error) {
+	dPost.ID = primitive.NewObjectID().Hex()
+	post, err := FromDomainPost(dPost)
+	if err != nil {
+		return nil, AppError.ErrInvalidUserID
+	}
+	base := dPost.Slug
+	if base == "" {
+		base = dPost.Title
+	}
+	post.Slug, err = r.uniqueSlug(ctx, base, post.ID)
+	if err != nil {
+		return nil, err
+	}
+	post.OldSlugs = nil
+	if post.CreatedAt.IsZero() {
+		post.CreatedAt = time.Now()
+	}
+	if post.UpdatedAt.IsZero() {
+		post.UpdatedAt = post.CreatedAt
+	}
+	if post.Status == "" {
+		post.Status = entities.PostStatusPublished
+	}
+	if post.Visibility == "" {
+		post.Visibility = entities.PostVisibilityPublic
+	}
+	if post.Status == entities.PostStatusPublished && post.PublishAt.IsZero() {
+		post.PublishAt = post.CreatedAt
+	}
+	post.Reactions = map[string]int{}
+	post.CommentCount = 0
+	post.Version = 1
 
 	_, err = r.collection.InsertOne(ctx, post)
+	if mongo.IsDuplicateKeyError(err) {
+		post.Slug, err = r.uniqueSlug(ctx, base, post.ID)
+		if err != nil {
+			return nil, err
+		}
+		_, err = r.collection.InsertOne(ctx, post)
+	}
 	if err != nil {
+		log.Printf("Error importing post %q: %v", dPost.Title, err)
 		return nil, AppError.ErrInternalServer
 	}
 
@@ -52,38 +170,212 @@ func (r *mongoPostRepository) FindByID(ctx context.Context, id string) (*entitie
 		return nil, AppError.ErrInvalidPostID
 	}
 	var post Post
-	err = r.collection.FindOne(ctx, bson.M{"_id": objId}).Decode(&post)
+	err = r.collection.FindOne(ctx, notDeleted(bson.M{"_id": objId})).Decode(&post)
 	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, AppError.ErrNotFound
+		}
 		return nil, err
 	}
 	return ToDomainPost(&post), nil
 }
 
-func (r *mongoPostRepository) FindAll(ctx context.Context, opts entities.PaginationOptions) ([]*entities.Post, error) {
-	findOptions := options.Find()
-	findOptions.SetSort(bson.D{{Key: "created_at", Value: -1}}) // Sort by most recent
-	findOptions.SetSkip((opts.Page - 1) * opts.Limit)
-	findOptions.SetLimit(opts.Limit)
-
-	cursor, err := r.collection.Find(ctx, bson.D{}, findOptions)
+// FindByIDs returns the posts with the given ids in the order of ids, skipping the ones that don't exist
+func (r *mongoPostRepository) FindByIDs(ctx context.Context, ids []string) ([]*entities.Post, error) {
+	objIDs, err := hexToObjectIDs(ids)
 	if err != nil {
 		return nil, err
 	}
+	if len(objIDs) == 0 {
+		return []*entities.Post{}, nil
+	}
+
+	cursor, err := r.collection.Find(ctx, notDeleted(bson.M{"_id": bson.M{"$in": objIDs}}))
+	if err != nil {
+		return nil, AppError.ErrInternalServer
+	}
 	defer cursor.Close(ctx)
 
 	var posts []Post
 	if err := cursor.All(ctx, &posts); err != nil {
-		return nil, err
+		return nil, AppError.ErrInternalServer
 	}
 
-	result := make([]*entities.Post, len(posts))
-	for idx, post := range posts {
-		result[idx] = ToDomainPost(&post)
+	byID := make(map[string]*entities.Post, len(posts))
+	for idx := range posts {
+		byID[posts[idx].ID.Hex()] = ToDomainPost(&posts[idx])
+	}
+	result := make([]*entities.Post, 0, len(posts))
+	for _, id := range ids {
+		if post, ok := byID[id]; ok {
+			result = append(result, post)
+		}
 	}
 	return result, nil
 }
 
-// IncrementViewCount increments the view count for a specific post
+// FindBySlug looks a post up by its current slug or any slug it had before
+func (r *mongoPostRepository) FindBySlug(ctx context.Context, slug string) (*entities.Post, error) {
+	var post Post
+	filter := notDeleted(bson.M{"$or": bson.A{
+		bson.M{"slug": slug},
+		bson.M{"old_slugs": slug},
+	}})
+	err := r.collection.FindOne(ctx, filter).Decode(&post)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, AppError.ErrNotFound
+		}
+		return nil, AppError.ErrInternalServer
+	}
+	return ToDomainPost(&post), nil
+}
+
+func (r *mongoPostRepository) FindAll(ctx context.Context, opts entities.PaginationOptions) ([]*entities.Post, error) {
+	return r.findWithFilter(ctx, withListed(bson.M{}), opts)
+}
+
+// FindByAuthorAndStatus lists the posts an author owns or co-authors regardless of visibility.
+// An empty status returns posts in every state, an empty author posts of every author.
+func (r *mongoPostRepository) FindByAuthorAndStatus(ctx context.Context, authorID, status string, opts entities.PaginationOptions) ([]*entities.Post, error) {
+	filter := bson.M{}
+	if authorID != "" {
+		authorObjID, err := primitive.ObjectIDFromHex(authorID)
+		if err != nil {
+			return nil, AppError.ErrInvalidUserID
+		}
+		filter["$or"] = authoredBy(authorObjID)
+	}
+	if status != "" {
+		filter["status"] = status
+	}
+	return r.findWithFilter(ctx, notDeleted(filter), opts)
+}
+
+// UpdateStatus moves a post to another lifecycle state
+func (r *mongoPostRepository) UpdateStatus(ctx context.Context, id, status string, publishAt time.Time) (*entities.Post, error) {
+	objId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		log.Println("unable to convert id to object id", id)
+		return nil, AppError.ErrInvalidPostID
+	}
+
+	filter := notDeleted(bson.M{"_id": objId})
+	update := bson.M{
+		"$set": bson.M{
+			"status":     status,
+			"publish_at": publishAt,
+			"updated_at": time.Now(),
+		},
+		"$inc": nextVersion(),
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		log.Printf("Error updating status of post %s: %v", id, err)
+		return nil, AppError.ErrInternalServer
+	}
+
+	if result.MatchedCount == 0 {
+		return nil, AppError.ErrNotFound
+	}
+
+	return r.FindByID(ctx, id)
+}
+
+// SetHidden sets or clears the moderation flag of a post
+func (r *mongoPostRepository) SetHidden(ctx context.Context, id string, hidden bool) (*entities.Post, error) {
+	objId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, AppError.ErrInvalidPostID
+	}
+
+	update := bson.M{"$set": bson.M{"hidden": hidden, "updated_at": time.Now()}, "$inc": nextVersion()}
+	if !hidden {
+		update = bson.M{"$unset": bson.M{"hidden": ""}, "$set": bson.M{"updated_at": time.Now()}, "$inc": nextVersion()}
+	}
+
+	result, err := r.collection.UpdateOne(ctx, notDeleted(bson.M{"_id": objId}), update)
+	if err != nil {
+		log.Printf("Error changing visibility of post %s: %v", id, err)
+		return nil, AppError.ErrInternalServer
+	}
+	if result.MatchedCount == 0 {
+		return nil, AppError.ErrNotFound
+	}
+
+	return r.FindByID(ctx, id)
+}
+
+// SetVisibility changes who can read a post
+func (r *mongoPostRepository) SetVisibility(ctx context.Context, id, visibility string) (*entities.Post, error) {
+	objId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, AppError.ErrInvalidPostID
+	}
+
+	update := bson.M{"$set": bson.M{"visibility": visibility, "updated_at": time.Now()}, "$inc": nextVersion()}
+	result, err := r.collection.UpdateOne(ctx, notDeleted(bson.M{"_id": objId}), update)
+	if err != nil {
+		log.Printf("Error changing visibility level of post %s: %v", id, err)
+		return nil, AppError.ErrInternalServer
+	}
+	if result.MatchedCount == 0 {
+		return nil, AppError.ErrNotFound
+	}
+
+	return r.FindByID(ctx, id)
+}
+
+// PublishScheduled flips every scheduled post whose publish time has passed to published
+// and returns the ids of the posts it looked at
+func (r *mongoPostRepository) PublishScheduled(ctx context.Context, now time.Time) ([]string, error) {
+	filter := notDeleted(bson.M{
+		"status":     entities.PostStatusScheduled,
+		"publish_at": bson.M{"$lte": now},
+	})
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		log.Printf("Error finding scheduled posts: %v", err)
+		return nil, AppError.ErrInternalServer
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, AppError.ErrInternalServer
+	}
+	if len(rows) == 0 {
+		return []string{}, nil
+	}
+
+	objIDs := make([]primitive.ObjectID, len(rows))
+	ids := make([]string, len(rows))
+	for i, row := range rows {
+		objIDs[i] = row.ID
+		ids[i] = row.ID.Hex()
+	}
+	// the status is checked again so a post unpublished in the meantime stays a draft
+	filter["_id"] = bson.M{"$in": objIDs}
+	update := bson.M{
+		"$set": bson.M{
+			"status":     entities.PostStatusPublished,
+			"updated_at": now,
+		},
+		"$inc": nextVersion(),
+	}
+
+	if _, err := r.collection.UpdateMany(ctx, filter, update); err != nil {
+		log.Printf("Error publishing scheduled posts: %v", err)
+		return nil, AppError.ErrInternalServer
+	}
+
+	return ids, nil
+}
+
+// IncrementViewCount increments the view count for a specific post; posts in the trash aren't counted
 func (r *mongoPostRepository) IncrementViewCount(ctx context.Context, postID string) error {
 	objId, err := primitive.ObjectIDFromHex(postID)
 	if err != nil {
@@ -91,7 +383,7 @@ func (r *mongoPostRepository) IncrementViewCount(ctx context.Context, postID str
 		return AppError.ErrInvalidPostID
 	}
 
-	filter := bson.M{"_id": objId}
+	filter := notDeleted(bson.M{"_id": objId})
 	update := bson.M{"$inc": bson.M{"view_count": 1}}
 
 	result, err := r.collection.UpdateOne(ctx, filter, update)
@@ -116,7 +408,7 @@ func (r *mongoPostRepository) GetViewCount(ctx context.Context, postID string) (
 	}
 
 	var post Post
-	filter := bson.M{"_id": objId}
+	filter := notDeleted(bson.M{"_id": objId})
 	projection := bson.M{"view_count": 1}
 
 	err = r.collection.FindOne(ctx, filter, options.FindOne().SetProjection(projection)).Decode(&post)
@@ -133,6 +425,7 @@ func (r *mongoPostRepository) GetViewCount(ctx context.Context, postID string) (
 // GetTotalViews calculates the total view count across all posts
 func (r *mongoPostRepository) GetTotalViews(ctx context.Context) (int64, error) {
 	pipeline := []bson.M{
+		{"$match": notDeleted(bson.M{})},
 		{
 			"$group": bson.M{
 				"_id":         nil,
@@ -167,10 +460,11 @@ func (r *mongoPostRepository) GetTotalViews(ctx context.Context) (int64, error)
 // GetPostsByViewCount retrieves posts ordered by view count (most viewed first)
 func (r *mongoPostRepository) GetPostsByViewCount(ctx context.Context, limit int) ([]*entities.Post, error) {
 	findOptions := options.Find()
-	findOptions.SetSort(bson.D{{Key: "view_count", Value: -1}}) // Sort by most viewed
+	// Sort by most viewed; ties are broken like the views sort of Query so cursors continue from here
+	findOptions.SetSort(bson.D{{Key: "view_count", Value: -1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}})
 	findOptions.SetLimit(int64(limit))
 
-	cursor, err := r.collection.Find(ctx, bson.D{}, findOptions)
+	cursor, err := r.collection.Find(ctx, withListed(bson.M{}), findOptions)
 	if err != nil {
 		return nil, AppError.ErrInternalServer
 	}
@@ -211,7 +505,32 @@ func (r *mongoPostRepository) ResetViewCount(ctx context.Context, postID string)
 
 	return nil
 }
-// Update updates an existing post
+
+// IncrementCommentCount adjusts the denormalized comment counter of a post
+func (r *mongoPostRepository) IncrementCommentCount(ctx context.Context, postID string, delta int) error {
+	objId, err := primitive.ObjectIDFromHex(postID)
+	if err != nil {
+		log.Println("unable to convert id to object id", postID)
+		return AppError.ErrInvalidPostID
+	}
+
+	filter := bson.M{"_id": objId}
+	update := bson.M{"$inc": bson.M{"comment_count": delta}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		log.Printf("Error updating comment count for post %s: %v", postID, err)
+		return AppError.ErrInternalServer
+	}
+
+	if result.MatchedCount == 0 {
+		return AppError.ErrNotFound
+	}
+
+	return nil
+}
+
+// Update updates an existing post, provided it is still at post.Version
 func (r *mongoPostRepository) Update(ctx context.Context, id string, post *entities.Post) (*entities.Post, error) {
 	objId, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
@@ -219,13 +538,50 @@ func (r *mongoPostRepository) Update(ctx context.Context, id string, post *entit
 		return nil, AppError.ErrInvalidPostID
 	}
 
-	filter := bson.M{"_id": objId}
-	update := bson.M{"$set": bson.M{
+	var current Post
+	err = r.collection.FindOne(ctx, notDeleted(bson.M{"_id": objId})).Decode(&current)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, AppError.ErrNotFound
+		}
+		return nil, AppError.ErrInternalServer
+	}
+	if current.Version != post.Version {
+		return nil, AppError.ErrVersionConflict
+	}
+
+	set := bson.M{
 		"title":      post.Title,
 		"content":    post.Content,
 		"tags":       post.Tags,
 		"updated_at": time.Now(),
-	}}
+	}
+
+	// A new title gets a new slug; the old one keeps resolving to this post
+	if current.Slug == "" || (post.Title != current.Title && slugutil.Slugify(post.Title) != slugutil.Slugify(current.Title)) {
+		slug, err := r.uniqueSlug(ctx, post.Title, objId)
+		if err != nil {
+			return nil, err
+		}
+		oldSlugs := []string{}
+		for _, old := range append(current.OldSlugs, current.Slug) {
+			if old != "" && old != slug {
+				oldSlugs = append(oldSlugs, old)
+			}
+		}
+		set["slug"] = slug
+		set["old_slugs"] = oldSlugs
+	}
+
+	// the version is checked again so an edit that slipped in since the read isn't overwritten
+	filter := atVersion(notDeleted(bson.M{"_id": objId}), post.Version)
+	update := bson.M{"$set": set, "$inc": nextVersion()}
+	if post.Rendered != nil {
+		set["rendered"] = FromDomainRendered(post.Rendered)
+	} else {
+		// the cached render no longer matches the content
+		update["$unset"] = bson.M{"rendered": ""}
+	}
 
 	result, err := r.collection.UpdateOne(ctx, filter, update)
 	if err != nil {
@@ -234,250 +590,534 @@ func (r *mongoPostRepository) Update(ctx context.Context, id string, post *entit
 	}
 
 	if result.MatchedCount == 0 {
-		return nil, AppError.ErrNotFound
+		return nil, r.missingOr(ctx, objId, AppError.ErrVersionConflict)
 	}
 
 	// Return updated post
 	return r.FindByID(ctx, id)
 }
 
-// Delete removes a post by ID
-func (r *mongoPostRepository) Delete(ctx context.Context, id string) error {
-	objId, err := primitive.ObjectIDFromHex(id)
+// AddCollaborator adds a user to the post unless they already own it or work on it
+func (r *mongoPostRepository) AddCollaborator(ctx context.Context, postID string, dCollaborator entities.Collaborator) (*entities.Post, error) {
+	objId, err := primitive.ObjectIDFromHex(postID)
 	if err != nil {
-		log.Println("unable to convert id to object id", id)
-		return AppError.ErrInvalidPostID
+		return nil, AppError.ErrInvalidPostID
 	}
-
-	filter := bson.M{"_id": objId}
-	result, err := r.collection.DeleteOne(ctx, filter)
+	collaborator, err := FromDomainCollaborator(dCollaborator)
 	if err != nil {
-		log.Printf("Error deleting post %s: %v", id, err)
-		return AppError.ErrInternalServer
+		return nil, AppError.ErrInvalidUserID
 	}
 
-	if result.DeletedCount == 0 {
-		return AppError.ErrNotFound
+	filter := notDeleted(bson.M{
+		"_id":                   objId,
+		"author_id":             bson.M{"$ne": collaborator.UserID},
+		"collaborators.user_id": bson.M{"$ne": collaborator.UserID},
+	})
+	update := bson.M{
+		"$push": bson.M{"collaborators": collaborator},
+		"$set":  bson.M{"updated_at": time.Now()},
+		"$inc":  nextVersion(),
 	}
 
-	return nil
-}
-
-// SearchByTitle searches posts by title
-func (r *mongoPostRepository) SearchByTitle(ctx context.Context, query string, opts entities.PaginationOptions) ([]*entities.Post, error) {
-	filter := bson.M{
-		"title": bson.M{
-			"$regex":   query,
-			"$options": "i", // case insensitive
-		},
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		log.Printf("Error adding collaborator to post %s: %v", postID, err)
+		return nil, AppError.ErrInternalServer
+	}
+	if result.MatchedCount == 0 {
+		return nil, r.missingOr(ctx, objId, AppError.ErrAlreadyCollaborator)
 	}
 
-	return r.findWithFilter(ctx, filter, opts)
+	return r.FindByID(ctx, postID)
 }
 
-// SearchByAuthor searches posts by author ID
-func (r *mongoPostRepository) SearchByAuthor(ctx context.Context, authorID string, opts entities.PaginationOptions) ([]*entities.Post, error) {
-	authorObjID, err := primitive.ObjectIDFromHex(authorID)
+// RemoveCollaborator takes a user off the post's collaborators
+func (r *mongoPostRepository) RemoveCollaborator(ctx context.Context, postID, userID string) (*entities.Post, error) {
+	objId, err := primitive.ObjectIDFromHex(postID)
+	if err != nil {
+		return nil, AppError.ErrInvalidPostID
+	}
+	userObjID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
 		return nil, AppError.ErrInvalidUserID
 	}
 
-	filter := bson.M{"author_id": authorObjID}
-	return r.findWithFilter(ctx, filter, opts)
-}
+	filter := notDeleted(bson.M{"_id": objId, "collaborators.user_id": userObjID})
+	update := bson.M{
+		"$pull": bson.M{"collaborators": bson.M{"user_id": userObjID}},
+		"$set":  bson.M{"updated_at": time.Now()},
+		"$inc":  nextVersion(),
+	}
 
-// FilterByTags filters posts by tags
-func (r *mongoPostRepository) FilterByTags(ctx context.Context, tags []string, opts entities.PaginationOptions) ([]*entities.Post, error) {
-	filter := bson.M{
-		"tags": bson.M{
-			"$in": tags,
-		},
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		log.Printf("Error removing collaborator from post %s: %v", postID, err)
+		return nil, AppError.ErrInternalServer
+	}
+	if result.MatchedCount == 0 {
+		return nil, AppError.ErrNotFound
 	}
 
-	return r.findWithFilter(ctx, filter, opts)
+	return r.FindByID(ctx, postID)
 }
 
-// FilterByDateRange filters posts by date range
-func (r *mongoPostRepository) FilterByDateRange(ctx context.Context, startDate, endDate string, opts entities.PaginationOptions) ([]*entities.Post, error) {
-	start, err := time.Parse("2006-01-02", startDate)
+// TransferOwnership makes toUserID the owner of a post still owned by fromUserID.
+// The new owner leaves the collaborators and the previous owner joins them as a co-author,
+// all in one update so the post never has two owners or none.
+func (r *mongoPostRepository) TransferOwnership(ctx context.Context, postID, fromUserID, toUserID string) (*entities.Post, error) {
+	objId, err := primitive.ObjectIDFromHex(postID)
 	if err != nil {
-		return nil, AppError.ErrValidationFailed
+		return nil, AppError.ErrInvalidPostID
 	}
-
-	end, err := time.Parse("2006-01-02", endDate)
+	fromObjID, err := primitive.ObjectIDFromHex(fromUserID)
 	if err != nil {
-		return nil, AppError.ErrValidationFailed
+		return nil, AppError.ErrInvalidUserID
+	}
+	toObjID, err := primitive.ObjectIDFromHex(toUserID)
+	if err != nil {
+		return nil, AppError.ErrInvalidUserID
 	}
 
-	// Set end date to end of day
-	end = end.Add(23*time.Hour + 59*time.Minute + 59*time.Second)
+	now := time.Now()
+	filter := notDeleted(bson.M{"_id": objId, "author_id": fromObjID})
+	update := mongo.Pipeline{{{Key: "$set", Value: bson.M{
+		"author_id":  toObjID,
+		"updated_at": now,
+		"version":    nextVersionExpr,
+		"collaborators": bson.M{"$concatArrays": bson.A{
+			bson.M{"$filter": bson.M{
+				"input": bson.M{"$ifNull": bson.A{"$collaborators", bson.A{}}},
+				"cond":  bson.M{"$ne": bson.A{"$$this.user_id", toObjID}},
+			}},
+			bson.A{Collaborator{UserID: fromObjID, Role: entities.PostRoleCoAuthor, AddedAt: now}},
+		}},
+	}}}}
 
-	filter := bson.M{
-		"created_at": bson.M{
-			"$gte": start,
-			"$lte": end,
-		},
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		log.Printf("Error transferring post %s: %v", postID, err)
+		return nil, AppError.ErrInternalServer
 	}
+	if result.MatchedCount == 0 {
+		return nil, r.missingOr(ctx, objId, AppError.ErrForbidden)
+	}
+
+	return r.FindByID(ctx, postID)
+}
 
-	return r.findWithFilter(ctx, filter, opts)
+// missingOr explains a conditional update that matched nothing:
+// ErrNotFound when the post is gone, err when it exists but failed the condition
+func (r *mongoPostRepository) missingOr(ctx context.Context, id primitive.ObjectID, err error) error {
+	count, countErr := r.collection.CountDocuments(ctx, notDeleted(bson.M{"_id": id}), options.Count().SetLimit(1))
+	if countErr != nil {
+		return AppError.ErrInternalServer
+	}
+	if count == 0 {
+		return AppError.ErrNotFound
+	}
+	return err
 }
 
-// AddLike adds a like to a post
-func (r *mongoPostRepository) AddLike(ctx context.Context, postID, userID string) error {
-	postObjID, err := primitive.ObjectIDFromHex(postID)
+// CountTags counts the posts carrying each tag
+func (r *mongoPostRepository) CountTags(ctx context.Context, publishedOnly bool) (map[string]int, error) {
+	match := notDeleted(bson.M{})
+	if publishedOnly {
+		match = withListed(match)
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$unwind", Value: "$tags"}},
+		{{Key: "$group", Value: bson.M{"_id": "$tags", "count": bson.M{"$sum": 1}}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
 	if err != nil {
-		return AppError.ErrInvalidPostID
+		log.Printf("Error counting tags: %v", err)
+		return nil, AppError.ErrInternalServer
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		Tag   string `bson:"_id"`
+		Count int    `bson:"count"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, AppError.ErrInternalServer
 	}
 
-	userObjID, err := primitive.ObjectIDFromHex(userID)
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.Tag] = row.Count
+	}
+	return counts, nil
+}
+
+// FindByTags returns every post carrying any of the tags, including the ones in the trash
+func (r *mongoPostRepository) FindByTags(ctx context.Context, tags []string) ([]*entities.Post, error) {
+	if len(tags) == 0 {
+		return []*entities.Post{}, nil
+	}
+
+	cursor, err := r.collection.Find(ctx, bson.M{"tags": bson.M{"$in": tags}})
 	if err != nil {
-		return AppError.ErrInvalidUserID
+		log.Printf("Error finding posts tagged %v: %v", tags, err)
+		return nil, AppError.ErrInternalServer
 	}
+	defer cursor.Close(ctx)
 
-	filter := bson.M{"_id": postObjID}
-	update := bson.M{
-		"$addToSet": bson.M{"likes": userObjID},
-		"$pull":     bson.M{"dislikes": userObjID}, // Remove from dislikes if exists
+	var posts []Post
+	if err := cursor.All(ctx, &posts); err != nil {
+		return nil, AppError.ErrInternalServer
 	}
 
-	_, err = r.collection.UpdateOne(ctx, filter, update)
+	result := make([]*entities.Post, len(posts))
+	for idx := range posts {
+		result[idx] = ToDomainPost(&posts[idx])
+	}
+	return result, nil
+}
+
+// ReplaceTags swaps every tag listed in from for to, on all posts carrying any of them.
+// Each post is rewritten by a single update that keeps the tag order and drops the
+// duplicates the swap creates, so no reader ever sees a half-renamed post. The posts are
+// not rewritten in one transaction: a failure can leave some of them done, and running
+// the swap again rewrites only the rest, as the done ones no longer carry the old tags.
+func (r *mongoPostRepository) ReplaceTags(ctx context.Context, from []string, to string) (int64, error) {
+	filter := bson.M{"tags": bson.M{"$in": from}}
+	replaced := bson.M{"$map": bson.M{
+		"input": "$tags",
+		"as":    "tag",
+		"in":    bson.M{"$cond": bson.A{bson.M{"$in": bson.A{"$$tag", from}}, to, "$$tag"}},
+	}}
+	deduplicated := bson.M{"$reduce": bson.M{
+		"input":        replaced,
+		"initialValue": bson.A{},
+		"in": bson.M{"$cond": bson.A{
+			bson.M{"$in": bson.A{"$$this", "$$value"}},
+			"$$value",
+			bson.M{"$concatArrays": bson.A{"$$value", bson.A{"$$this"}}},
+		}},
+	}}
+	update := mongo.Pipeline{{{Key: "$set", Value: bson.M{
+		"tags":       deduplicated,
+		"version":    nextVersionExpr,
+		"updated_at": time.Now(),
+	}}}}
+
+	result, err := r.collection.UpdateMany(ctx, filter, update)
 	if err != nil {
-		log.Printf("Error adding like to post %s: %v", postID, err)
-		return AppError.ErrInternalServer
+		log.Printf("Error replacing tags %v with %s: %v", from, to, err)
+		return 0, AppError.ErrInternalServer
 	}
 
-	return nil
+	return result.ModifiedCount, nil
 }
 
-// RemoveLike removes a like from a post
-func (r *mongoPostRepository) RemoveLike(ctx context.Context, postID, userID string) error {
-	postObjID, err := primitive.ObjectIDFromHex(postID)
+// SaveRendered stores the rendered form of a post's content
+func (r *mongoPostRepository) SaveRendered(ctx context.Context, id string, rendered *entities.RenderedContent) error {
+	objId, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
+		log.Println("unable to convert id to object id", id)
 		return AppError.ErrInvalidPostID
 	}
 
-	userObjID, err := primitive.ObjectIDFromHex(userID)
+	filter := notDeleted(bson.M{"_id": objId})
+	update := bson.M{"$set": bson.M{"rendered": FromDomainRendered(rendered)}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
 	if err != nil {
-		return AppError.ErrInvalidUserID
+		log.Printf("Error saving rendered content of post %s: %v", id, err)
+		return AppError.ErrInternalServer
 	}
 
-	filter := bson.M{"_id": postObjID}
-	update := bson.M{
-		"$pull": bson.M{"likes": userObjID},
+	if result.MatchedCount == 0 {
+		return AppError.ErrNotFound
+	}
+
+	return nil
+}
+
+// Delete moves a post to the trash by marking it deleted, provided it is still at the given version
+func (r *mongoPostRepository) Delete(ctx context.Context, id string, version int) error {
+	objId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		log.Println("unable to convert id to object id", id)
+		return AppError.ErrInvalidPostID
 	}
 
-	_, err = r.collection.UpdateOne(ctx, filter, update)
+	filter := atVersion(notDeleted(bson.M{"_id": objId}), version)
+	update := bson.M{"$set": bson.M{"deleted_at": time.Now()}, "$inc": nextVersion()}
+	result, err := r.collection.UpdateOne(ctx, filter, update)
 	if err != nil {
-		log.Printf("Error removing like from post %s: %v", postID, err)
+		log.Printf("Error deleting post %s: %v", id, err)
 		return AppError.ErrInternalServer
 	}
 
+	if result.MatchedCount == 0 {
+		return r.missingOr(ctx, objId, AppError.ErrVersionConflict)
+	}
+
 	return nil
 }
 
-// AddDislike adds a dislike to a post
-func (r *mongoPostRepository) AddDislike(ctx context.Context, postID, userID string) error {
-	postObjID, err := primitive.ObjectIDFromHex(postID)
+// DeletePermanently removes a post by ID, whether it is in the trash or not
+func (r *mongoPostRepository) DeletePermanently(ctx context.Context, id string) error {
+	objId, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
+		log.Println("unable to convert id to object id", id)
 		return AppError.ErrInvalidPostID
 	}
 
-	userObjID, err := primitive.ObjectIDFromHex(userID)
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": objId})
 	if err != nil {
-		return AppError.ErrInvalidUserID
+		log.Printf("Error deleting post %s: %v", id, err)
+		return AppError.ErrInternalServer
 	}
 
-	filter := bson.M{"_id": postObjID}
-	update := bson.M{
-		"$addToSet": bson.M{"dislikes": userObjID},
-		"$pull":     bson.M{"likes": userObjID}, // Remove from likes if exists
+	if result.DeletedCount == 0 {
+		return AppError.ErrNotFound
 	}
 
-	_, err = r.collection.UpdateOne(ctx, filter, update)
+	return nil
+}
+
+// FindTrashed lists the deleted posts owned by an author, most recently deleted first
+func (r *mongoPostRepository) FindTrashed(ctx context.Context, authorID string, opts entities.PaginationOptions) ([]*entities.Post, error) {
+	authorObjID, err := primitive.ObjectIDFromHex(authorID)
 	if err != nil {
-		log.Printf("Error adding dislike to post %s: %v", postID, err)
-		return AppError.ErrInternalServer
+		return nil, AppError.ErrInvalidUserID
 	}
 
-	return nil
+	filter := bson.M{"author_id": authorObjID, "deleted_at": bson.M{"$exists": true}}
+	findOptions := options.Find()
+	findOptions.SetSort(bson.D{{Key: "deleted_at", Value: -1}, {Key: "_id", Value: -1}})
+	findOptions.SetSkip((opts.Page - 1) * opts.Limit)
+	findOptions.SetLimit(opts.Limit)
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		log.Printf("Error listing trash of %s: %v", authorID, err)
+		return nil, AppError.ErrInternalServer
+	}
+	defer cursor.Close(ctx)
+
+	var posts []Post
+	if err := cursor.All(ctx, &posts); err != nil {
+		return nil, AppError.ErrInternalServer
+	}
+
+	result := make([]*entities.Post, len(posts))
+	for idx := range posts {
+		result[idx] = ToDomainPost(&posts[idx])
+	}
+	return result, nil
 }
 
-// RemoveDislike removes a dislike from a post
-func (r *mongoPostRepository) RemoveDislike(ctx context.Context, postID, userID string) error {
-	postObjID, err := primitive.ObjectIDFromHex(postID)
+// FindTrashedByID returns a post only while it is in the trash
+func (r *mongoPostRepository) FindTrashedByID(ctx context.Context, id string) (*entities.Post, error) {
+	objId, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return AppError.ErrInvalidPostID
+		return nil, AppError.ErrInvalidPostID
 	}
 
-	userObjID, err := primitive.ObjectIDFromHex(userID)
+	var post Post
+	err = r.collection.FindOne(ctx, bson.M{"_id": objId, "deleted_at": bson.M{"$exists": true}}).Decode(&post)
 	if err != nil {
-		return AppError.ErrInvalidUserID
+		if err == mongo.ErrNoDocuments {
+			return nil, AppError.ErrNotFound
+		}
+		log.Printf("Error finding deleted post %s: %v", id, err)
+		return nil, AppError.ErrInternalServer
 	}
+	return ToDomainPost(&post), nil
+}
 
-	filter := bson.M{"_id": postObjID}
-	update := bson.M{
-		"$pull": bson.M{"dislikes": userObjID},
+// Restore takes a post out of the trash. It keeps its slug, which stays reserved while the post is deleted.
+func (r *mongoPostRepository) Restore(ctx context.Context, id string) (*entities.Post, error) {
+	objId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, AppError.ErrInvalidPostID
 	}
 
-	_, err = r.collection.UpdateOne(ctx, filter, update)
+	filter := bson.M{"_id": objId, "deleted_at": bson.M{"$exists": true}}
+	update := bson.M{
+		"$unset": bson.M{"deleted_at": ""},
+		"$set":   bson.M{"updated_at": time.Now()},
+		"$inc":   nextVersion(),
+	}
+	result, err := r.collection.UpdateOne(ctx, filter, update)
 	if err != nil {
-		log.Printf("Error removing dislike from post %s: %v", postID, err)
-		return AppError.ErrInternalServer
+		log.Printf("Error restoring post %s: %v", id, err)
+		return nil, AppError.ErrInternalServer
+	}
+	if result.MatchedCount == 0 {
+		return nil, AppError.ErrNotFound
 	}
 
-	return nil
+	return r.FindByID(ctx, id)
 }
 
-// GetLikeStatus checks if user has liked or disliked a post
-func (r *mongoPostRepository) GetLikeStatus(ctx context.Context, postID, userID string) (bool, bool, error) {
-	postObjID, err := primitive.ObjectIDFromHex(postID)
+// PurgeTrashed permanently removes up to limit posts deleted before the given time and returns their ids
+func (r *mongoPostRepository) PurgeTrashed(ctx context.Context, deletedBefore time.Time, limit int) ([]string, error) {
+	filter := bson.M{"deleted_at": bson.M{"$lt": deletedBefore}}
+	findOptions := options.Find().
+		SetProjection(bson.M{"_id": 1}).
+		SetSort(bson.D{{Key: "deleted_at", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
 	if err != nil {
-		return false, false, AppError.ErrInvalidPostID
+		log.Printf("Error finding posts to purge: %v", err)
+		return nil, AppError.ErrInternalServer
 	}
+	defer cursor.Close(ctx)
 
-	userObjID, err := primitive.ObjectIDFromHex(userID)
+	var rows []struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, AppError.ErrInternalServer
+	}
+	if len(rows) == 0 {
+		return []string{}, nil
+	}
+
+	objIDs := make([]primitive.ObjectID, len(rows))
+	for i, row := range rows {
+		objIDs[i] = row.ID
+	}
+	// deleted_at is checked again so a post restored in the meantime survives
+	_, err = r.collection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": objIDs}, "deleted_at": bson.M{"$lt": deletedBefore}})
 	if err != nil {
-		return false, false, AppError.ErrInvalidUserID
+		log.Printf("Error purging deleted posts: %v", err)
+		return nil, AppError.ErrInternalServer
 	}
 
-	var post Post
-	filter := bson.M{"_id": postObjID}
-	projection := bson.M{"likes": 1, "dislikes": 1}
+	return objectIDsToHex(objIDs), nil
+}
 
-	err = r.collection.FindOne(ctx, filter, options.FindOne().SetProjection(projection)).Decode(&post)
+// Query returns listed posts matching every filter set on the query
+func (r *mongoPostRepository) Query(ctx context.Context, query entities.PostQuery, opts entities.PaginationOptions) ([]*entities.Post, error) {
+	filter, err := buildQueryFilter(query)
 	if err != nil {
-		if err == mongo.ErrNoDocuments {
-			return false, false, AppError.ErrNotFound
+		return nil, err
+	}
+	sort, needsLikeCount := buildQuerySort(query.Sort)
+
+	return r.listPage(ctx, withListed(filter), sort, needsLikeCount, opts)
+}
+
+// buildQueryFilter turns a post query into a Mongo filter
+func buildQueryFilter(query entities.PostQuery) (bson.M, error) {
+	filter := bson.M{}
+
+	if query.Text != "" {
+		filter["title"] = bson.M{
+			"$regex":   regexp.QuoteMeta(query.Text),
+			"$options": "i", // case insensitive
 		}
-		return false, false, AppError.ErrInternalServer
 	}
 
-	liked := false
-	disliked := false
+	if len(query.Tags) > 0 {
+		operator := "$in"
+		if query.MatchAllTags {
+			operator = "$all"
+		}
+		filter["tags"] = bson.M{operator: query.Tags}
+	}
 
-	for _, likeID := range post.Likes {
-		if likeID == userObjID {
-			liked = true
-			break
+	if query.AuthorID != "" {
+		authorObjID, err := primitive.ObjectIDFromHex(query.AuthorID)
+		if err != nil {
+			return nil, AppError.ErrInvalidUserID
 		}
+		filter["$or"] = authoredBy(authorObjID)
+	}
+
+	createdAt := bson.M{}
+	if !query.After.IsZero() {
+		createdAt["$gte"] = query.After
 	}
+	if !query.Before.IsZero() {
+		createdAt["$lte"] = query.Before
+	}
+	if len(createdAt) > 0 {
+		filter["created_at"] = createdAt
+	}
+
+	return filter, nil
+}
 
-	for _, dislikeID := range post.Dislikes {
-		if dislikeID == userObjID {
-			disliked = true
-			break
+// buildQuerySort maps sort keys to Mongo sort fields, always ending with _id so pages are stable.
+// The second return value tells whether the pipeline has to compute like_count.
+func buildQuerySort(keys []string) (bson.D, bool) {
+	sort := bson.D{}
+	used := map[string]bool{}
+	needsLikeCount := false
+
+	for _, key := range keys {
+		var field string
+		direction := -1
+		switch key {
+		case entities.SortNewest:
+			field = "created_at"
+		case entities.SortOldest:
+			field, direction = "created_at", 1
+		case entities.SortViews:
+			field = "view_count"
+		case entities.SortLikes:
+			field = "like_count"
+			needsLikeCount = true
+		default:
+			continue
 		}
+		if used[field] {
+			continue
+		}
+		used[field] = true
+		sort = append(sort, bson.E{Key: field, Value: direction})
 	}
 
-	return liked, disliked, nil
+	if !used["created_at"] {
+		sort = append(sort, bson.E{Key: "created_at", Value: -1})
+	}
+	sort = append(sort, bson.E{Key: "_id", Value: -1})
+	return sort, needsLikeCount
 }
 
-// findWithFilter is a helper method for search and filter operations
-func (r *mongoPostRepository) findWithFilter(ctx context.Context, filter bson.M, opts entities.PaginationOptions) ([]*entities.Post, error) {
-	findOptions := options.Find()
-	findOptions.SetSort(bson.D{{Key: "created_at", Value: -1}}) // Sort by most recent
-	findOptions.SetSkip((opts.Page - 1) * opts.Limit)
-	findOptions.SetLimit(opts.Limit)
+// IncrementReactionCount adjusts the counter of one reaction kind, unless the post is in the trash
+func (r *mongoPostRepository) IncrementReactionCount(ctx context.Context, postID, kind string, delta int) error {
+	objId, err := primitive.ObjectIDFromHex(postID)
+	if err != nil {
+		log.Println("unable to convert id to object id", postID)
+		return AppError.ErrInvalidPostID
+	}
+
+	filter := notDeleted(bson.M{"_id": objId})
+	update := bson.M{"$inc": bson.M{"reaction_counts." + kind: delta}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		log.Printf("Error updating %s count for post %s: %v", kind, postID, err)
+		return AppError.ErrInternalServer
+	}
+
+	if result.MatchedCount == 0 {
+		return AppError.ErrNotFound
+	}
+
+	return nil
+}
+
+// FindLegacyReactions returns up to limit posts that still store likes and dislikes as arrays
+func (r *mongoPostRepository) FindLegacyReactions(ctx context.Context, limit int) ([]*entities.LegacyReactions, error) {
+	filter := bson.M{"$or": bson.A{
+		bson.M{"likes": bson.M{"$exists": true}},
+		bson.M{"dislikes": bson.M{"$exists": true}},
+	}}
+	findOptions := options.Find().
+		SetProjection(bson.M{"likes": 1, "dislikes": 1}).
+		SetLimit(int64(limit))
 
 	cursor, err := r.collection.Find(ctx, filter, findOptions)
 	if err != nil {
@@ -485,6 +1125,87 @@ func (r *mongoPostRepository) findWithFilter(ctx context.Context, filter bson.M,
 	}
 	defer cursor.Close(ctx)
 
+	var posts []struct {
+		ID       primitive.ObjectID   `bson:"_id"`
+		Likes    []primitive.ObjectID `bson:"likes"`
+		Dislikes []primitive.ObjectID `bson:"dislikes"`
+	}
+	if err := cursor.All(ctx, &posts); err != nil {
+		return nil, AppError.ErrInternalServer
+	}
+
+	result := make([]*entities.LegacyReactions, len(posts))
+	for idx, post := range posts {
+		result[idx] = &entities.LegacyReactions{
+			PostID:   post.ID.Hex(),
+			Likes:    objectIDsToHex(post.Likes),
+			Dislikes: objectIDsToHex(post.Dislikes),
+		}
+	}
+	return result, nil
+}
+
+// ClearLegacyReactions replaces the like and dislike arrays of a post with its counters
+func (r *mongoPostRepository) ClearLegacyReactions(ctx context.Context, postID string, counts map[string]int) error {
+	objId, err := primitive.ObjectIDFromHex(postID)
+	if err != nil {
+		log.Println("unable to convert id to object id", postID)
+		return AppError.ErrInvalidPostID
+	}
+
+	update := bson.M{
+		"$set":   bson.M{"reaction_counts": counts},
+		"$unset": bson.M{"likes": "", "dislikes": ""},
+	}
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": objId}, update)
+	if err != nil {
+		log.Printf("Error migrating reactions of post %s: %v", postID, err)
+		return AppError.ErrInternalServer
+	}
+
+	return nil
+}
+
+// findWithFilter is a helper method for listings ordered by most recent
+func (r *mongoPostRepository) findWithFilter(ctx context.Context, filter bson.M, opts entities.PaginationOptions) ([]*entities.Post, error) {
+	sort := bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}} // Sort by most recent
+	return r.listPage(ctx, filter, sort, false, opts)
+}
+
+// listPage runs a sorted listing. With a cursor the page is selected by keyset on the sort
+// fields instead of skipping, which stays fast on deep pages and doesn't shift when posts are added.
+func (r *mongoPostRepository) listPage(ctx context.Context, filter bson.M, sort bson.D, needsLikeCount bool, opts entities.PaginationOptions) ([]*entities.Post, error) {
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: filter}}}
+	if needsLikeCount {
+		pipeline = append(pipeline, bson.D{{Key: "$addFields", Value: bson.M{
+			"like_count": bson.M{"$ifNull": bson.A{"$reaction_counts." + entities.ReactionLike, 0}},
+		}}})
+	}
+
+	backward := opts.Cursor != nil && opts.Cursor.Backward
+	if opts.Cursor != nil {
+		after, err := keysetFilter(sort, opts.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: after}})
+	}
+	if backward {
+		sort = reverseSort(sort)
+	}
+	pipeline = append(pipeline, bson.D{{Key: "$sort", Value: sort}})
+	if opts.Cursor == nil && opts.Page > 1 {
+		pipeline = append(pipeline, bson.D{{Key: "$skip", Value: (opts.Page - 1) * opts.Limit}})
+	}
+	pipeline = append(pipeline, bson.D{{Key: "$limit", Value: opts.Limit}})
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		log.Printf("Error listing posts: %v", err)
+		return nil, AppError.ErrInternalServer
+	}
+	defer cursor.Close(ctx)
+
 	var posts []Post
 	if err := cursor.All(ctx, &posts); err != nil {
 		return nil, AppError.ErrInternalServer
@@ -494,5 +1215,129 @@ func (r *mongoPostRepository) findWithFilter(ctx context.Context, filter bson.M,
 	for idx, post := range posts {
 		result[idx] = ToDomainPost(&post)
 	}
+	if backward {
+		// Fetched in reverse to find the closest posts; hand them back in display order
+		for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+			result[i], result[j] = result[j], result[i]
+		}
+	}
 	return result, nil
-}
\ No newline at end of file
+}
+
+// keysetFilter matches the posts that sort strictly after the cursor (or before it for backward cursors):
+// (k1 > v1) OR (k1 = v1 AND k2 > v2) OR ...
+func keysetFilter(sort bson.D, cursor *entities.PageCursor) (bson.M, error) {
+	id, err := primitive.ObjectIDFromHex(cursor.ID)
+	if err != nil {
+		return nil, AppError.ErrInvalidCursor
+	}
+
+	values := map[string]interface{}{
+		"created_at": cursor.CreatedAt,
+		"view_count": cursor.ViewCount,
+		"like_count": cursor.LikeCount,
+		"_id":        id,
+	}
+
+	branches := bson.A{}
+	equal := bson.M{}
+	for _, field := range sort {
+		value, ok := values[field.Key]
+		if !ok {
+			return nil, AppError.ErrInvalidCursor
+		}
+		operator := "$lt"
+		if (field.Value == 1) != cursor.Backward {
+			operator = "$gt"
+		}
+
+		branch := bson.M{field.Key: bson.M{operator: value}}
+		for key, v := range equal {
+			branch[key] = v
+		}
+		branches = append(branches, branch)
+		equal[field.Key] = value
+	}
+
+	return bson.M{"$or": branches}, nil
+}
+
+// reverseSort flips every direction of a sort specification
+func reverseSort(sort bson.D) bson.D {
+	reversed := make(bson.D, len(sort))
+	for idx, field := range sort {
+		reversed[idx] = bson.E{Key: field.Key, Value: -field.Value.(int)}
+	}
+	return reversed
+}
+
+// authoredBy matches the posts a user owns or co-authors
+func authoredBy(userID primitive.ObjectID) bson.A {
+	return bson.A{
+		bson.M{"author_id": userID},
+		bson.M{"collaborators.user_id": userID},
+	}
+}
+
+// withListed restricts a filter to the posts shown in public listings: published, public ones.
+// Posts stored before the lifecycle or the visibility levels existed have neither field and stay
+// listed; hidden and deleted posts never are.
+func withListed(filter bson.M) bson.M {
+	filter["status"] = bson.M{"$in": bson.A{entities.PostStatusPublished, nil}}
+	filter["visibility"] = bson.M{"$in": bson.A{entities.PostVisibilityPublic, nil}}
+	filter["hidden"] = bson.M{"$ne": true}
+	return notDeleted(filter)
+}
+
+// notDeleted leaves posts in the trash out of a filter
+func notDeleted(filter bson.M) bson.M {
+	filter["deleted_at"] = bson.M{"$exists": false}
+	return filter
+}
+
+// atVersion restricts a filter to a post still at the given version.
+// Posts stored before versioning have no version field and are at version 0.
+func atVersion(filter bson.M, version int) bson.M {
+	if version == 0 {
+		filter["version"] = bson.M{"$in": bson.A{0, nil}}
+	} else {
+		filter["version"] = version
+	}
+	return filter
+}
+
+// nextVersion is the $inc that goes with every edit of a post
+func nextVersion() bson.M {
+	return bson.M{"version": 1}
+}
+
+// nextVersionExpr bumps the version in pipeline updates, which have no $inc
+var nextVersionExpr = bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$version", 0}}, 1}}
+
+// uniqueSlug derives a slug from the title and appends -2, -3, ... until no other
+// post uses it as a current or historical slug. Slugs owned by postID are free to reuse.
+// Posts in the trash keep their slugs so they can be restored.
+func (r *mongoPostRepository) uniqueSlug(ctx context.Context, title string, postID primitive.ObjectID) (string, error) {
+	base := slugutil.Slugify(title)
+	for n := 1; n <= maxSlugAttempts; n++ {
+		candidate := slugutil.WithSuffix(base, n)
+		filter := bson.M{
+			"_id": bson.M{"$ne": postID},
+			"$or": bson.A{
+				bson.M{"slug": candidate},
+				bson.M{"old_slugs": candidate},
+			},
+		}
+		count, err := r.collection.CountDocuments(ctx, filter, options.Count().SetLimit(1))
+		if err != nil {
+			log.Printf("Error checking slug %s: %v", candidate, err)
+			return "", AppError.ErrInternalServer
+		}
+		if count == 0 {
+			return candidate, nil
+		}
+	}
+
+	// Extremely common title: fall back to the id, which is unique by definition
+	return base + "-" + postID.Hex(), nil
+}
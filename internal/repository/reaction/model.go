@@ -0,0 +1,49 @@
+package reactionrepo
+
+import (
+	"anchor-blog/internal/domain/entities"
+	"anchor-blog/internal/errors"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Reaction struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	PostID    primitive.ObjectID `bson:"post_id"`
+	UserID    primitive.ObjectID `bson:"user_id"`
+	Kind      string             `bson:"kind"`
+	CreatedAt time.Time          `bson:"created_at"`
+}
+
+// ::::::: Mapping functions :::::::::::
+func ToDomainReaction(r *Reaction) *entities.Reaction {
+	return &entities.Reaction{
+		ID:        r.ID.Hex(),
+		PostID:    r.PostID.Hex(),
+		UserID:    r.UserID.Hex(),
+		Kind:      r.Kind,
+		CreatedAt: r.CreatedAt,
+	}
+}
+
+func FromDomainReaction(r *entities.Reaction) (*Reaction, error) {
+	postID, err := primitive.ObjectIDFromHex(r.PostID)
+	if err != nil {
+		log.Println("invalid post id ", r.PostID)
+		return nil, errors.ErrInvalidPostID
+	}
+	userID, err := primitive.ObjectIDFromHex(r.UserID)
+	if err != nil {
+		log.Println("invalid user id ", r.UserID)
+		return nil, errors.ErrInvalidUserID
+	}
+
+	return &Reaction{
+		PostID:    postID,
+		UserID:    userID,
+		Kind:      r.Kind,
+		CreatedAt: r.CreatedAt,
+	}, nil
+}
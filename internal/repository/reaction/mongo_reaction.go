@@ -0,0 +1,228 @@
+package reactionrepo
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"anchor-blog/internal/domain/entities"
+	AppError "anchor-blog/internal/errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoReactionRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoReactionRepository creates a new reaction repository with MongoDB implementation.
+func NewMongoReactionRepository(collection *mongo.Collection) entities.IReactionRepository {
+	ctx := context.Background()
+	if err := ensureReactionIndexes(ctx, collection); err != nil {
+		log.Printf("failed to create indexes on reactions: %v", err)
+	}
+	return &mongoReactionRepository{collection}
+}
+
+// the unique index makes reacting idempotent: a user holds each kind at most once per post
+func ensureReactionIndexes(ctx context.Context, col *mongo.Collection) error {
+	_, err := col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "post_id", Value: 1}, {Key: "user_id", Value: 1}, {Key: "kind", Value: 1}},
+			Options: options.Index().
+				SetName("idx_reaction_post_user_kind").
+				SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "kind", Value: 1}, {Key: "created_at", Value: 1}},
+			Options: options.Index().SetName("idx_reaction_kind_created"),
+		},
+	})
+	return err
+}
+
+func (r *mongoReactionRepository) Add(ctx context.Context, dReaction *entities.Reaction) (bool, error) {
+	reaction, err := FromDomainReaction(dReaction)
+	if err != nil {
+		return false, err
+	}
+	reaction.ID = primitive.NewObjectID()
+	reaction.CreatedAt = time.Now()
+
+	_, err = r.collection.InsertOne(ctx, reaction)
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		log.Printf("Error adding %s reaction to post %s: %v", dReaction.Kind, dReaction.PostID, err)
+		return false, AppError.ErrInternalServer
+	}
+	return true, nil
+}
+
+func (r *mongoReactionRepository) AddMany(ctx context.Context, dReactions []*entities.Reaction) error {
+	if len(dReactions) == 0 {
+		return nil
+	}
+
+	docs := make([]interface{}, len(dReactions))
+	for idx, dReaction := range dReactions {
+		reaction, err := FromDomainReaction(dReaction)
+		if err != nil {
+			return err
+		}
+		reaction.ID = primitive.NewObjectID()
+		if reaction.CreatedAt.IsZero() {
+			reaction.CreatedAt = time.Now()
+		}
+		docs[idx] = reaction
+	}
+
+	_, err := r.collection.InsertMany(ctx, docs, options.InsertMany().SetOrdered(false))
+	if err != nil && !onlyDuplicateKeys(err) {
+		log.Printf("Error adding reactions: %v", err)
+		return AppError.ErrInternalServer
+	}
+	return nil
+}
+
+func (r *mongoReactionRepository) Remove(ctx context.Context, postID, userID, kind string) (bool, error) {
+	filter, err := postUserFilter(postID, userID)
+	if err != nil {
+		return false, err
+	}
+	filter["kind"] = kind
+
+	result, err := r.collection.DeleteOne(ctx, filter)
+	if err != nil {
+		log.Printf("Error removing %s reaction from post %s: %v", kind, postID, err)
+		return false, AppError.ErrInternalServer
+	}
+	return result.DeletedCount > 0, nil
+}
+
+func (r *mongoReactionRepository) FindKinds(ctx context.Context, postID, userID string) ([]string, error) {
+	filter, err := postUserFilter(postID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	kinds, err := r.collection.Distinct(ctx, "kind", filter)
+	if err != nil {
+		return nil, AppError.ErrInternalServer
+	}
+
+	result := make([]string, 0, len(kinds))
+	for _, kind := range kinds {
+		if name, ok := kind.(string); ok {
+			result = append(result, name)
+		}
+	}
+	return result, nil
+}
+
+func (r *mongoReactionRepository) CountByPost(ctx context.Context, postID string) (map[string]int, error) {
+	postObjID, err := primitive.ObjectIDFromHex(postID)
+	if err != nil {
+		return nil, AppError.ErrInvalidPostID
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"post_id": postObjID}}},
+		{{Key: "$group", Value: bson.M{"_id": "$kind", "count": bson.M{"$sum": 1}}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, AppError.ErrInternalServer
+	}
+	defer cursor.Close(ctx)
+
+	var groups []struct {
+		Kind  string `bson:"_id"`
+		Count int    `bson:"count"`
+	}
+	if err := cursor.All(ctx, &groups); err != nil {
+		return nil, AppError.ErrInternalServer
+	}
+
+	counts := make(map[string]int, len(groups))
+	for _, group := range groups {
+		counts[group.Kind] = group.Count
+	}
+	return counts, nil
+}
+
+// CountSince counts the reactions of a kind left since the given time, per post
+func (r *mongoReactionRepository) CountSince(ctx context.Context, kind string, since time.Time) (map[string]int, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"kind": kind, "created_at": bson.M{"$gte": since}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$post_id", "count": bson.M{"$sum": 1}}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		log.Printf("Error counting %s reactions: %v", kind, err)
+		return nil, AppError.ErrInternalServer
+	}
+	defer cursor.Close(ctx)
+
+	var groups []struct {
+		PostID primitive.ObjectID `bson:"_id"`
+		Count  int                `bson:"count"`
+	}
+	if err := cursor.All(ctx, &groups); err != nil {
+		return nil, AppError.ErrInternalServer
+	}
+
+	counts := make(map[string]int, len(groups))
+	for _, group := range groups {
+		counts[group.PostID.Hex()] = group.Count
+	}
+	return counts, nil
+}
+
+// DeleteByPostID removes the reactions of a deleted post
+func (r *mongoReactionRepository) DeleteByPostID(ctx context.Context, postID string) error {
+	postObjID, err := primitive.ObjectIDFromHex(postID)
+	if err != nil {
+		return AppError.ErrInvalidPostID
+	}
+
+	_, err = r.collection.DeleteMany(ctx, bson.M{"post_id": postObjID})
+	if err != nil {
+		log.Printf("Error deleting reactions of post %s: %v", postID, err)
+		return AppError.ErrInternalServer
+	}
+
+	return nil
+}
+
+func postUserFilter(postID, userID string) (bson.M, error) {
+	postObjID, err := primitive.ObjectIDFromHex(postID)
+	if err != nil {
+		return nil, AppError.ErrInvalidPostID
+	}
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, AppError.ErrInvalidUserID
+	}
+	return bson.M{"post_id": postObjID, "user_id": userObjID}, nil
+}
+
+// onlyDuplicateKeys reports whether every failed insert of a bulk write was a duplicate
+func onlyDuplicateKeys(err error) bool {
+	bulkErr, ok := err.(mongo.BulkWriteException)
+	if !ok || bulkErr.WriteConcernError != nil {
+		return false
+	}
+	for _, writeErr := range bulkErr.WriteErrors {
+		if writeErr.Code != 11000 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,97 @@
+package reportrepo
+
+import (
+	"anchor-blog/internal/domain/entities"
+	"anchor-blog/internal/errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Report struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	PostID      primitive.ObjectID `bson:"post_id"`
+	ReporterID  primitive.ObjectID `bson:"reporter_id"`
+	Reason      string             `bson:"reason"`
+	Details     string             `bson:"details,omitempty"`
+	Status      string             `bson:"status"`
+	ModeratorID string             `bson:"moderator_id,omitempty"`
+	CreatedAt   time.Time          `bson:"created_at"`
+	ResolvedAt  time.Time          `bson:"resolved_at,omitempty"`
+}
+
+type ModerationAction struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	ReportID    string             `bson:"report_id,omitempty"`
+	PostID      primitive.ObjectID `bson:"post_id"`
+	AuthorID    string             `bson:"author_id"`
+	ModeratorID string             `bson:"moderator_id,omitempty"`
+	Action      string             `bson:"action"`
+	Note        string             `bson:"note,omitempty"`
+	CreatedAt   time.Time          `bson:"created_at"`
+}
+
+// ::::::: Mapping functions :::::::::::
+func ToDomainReport(r *Report) *entities.Report {
+	return &entities.Report{
+		ID:          r.ID.Hex(),
+		PostID:      r.PostID.Hex(),
+		ReporterID:  r.ReporterID.Hex(),
+		Reason:      r.Reason,
+		Details:     r.Details,
+		Status:      r.Status,
+		ModeratorID: r.ModeratorID,
+		CreatedAt:   r.CreatedAt,
+		ResolvedAt:  r.ResolvedAt,
+	}
+}
+
+func FromDomainReport(r *entities.Report) (*Report, error) {
+	postID, err := primitive.ObjectIDFromHex(r.PostID)
+	if err != nil {
+		return nil, errors.ErrInvalidPostID
+	}
+	reporterID, err := primitive.ObjectIDFromHex(r.ReporterID)
+	if err != nil {
+		return nil, errors.ErrInvalidUserID
+	}
+	return &Report{
+		PostID:      postID,
+		ReporterID:  reporterID,
+		Reason:      r.Reason,
+		Details:     r.Details,
+		Status:      r.Status,
+		ModeratorID: r.ModeratorID,
+		CreatedAt:   r.CreatedAt,
+		ResolvedAt:  r.ResolvedAt,
+	}, nil
+}
+
+func ToDomainModerationAction(a *ModerationAction) *entities.ModerationAction {
+	return &entities.ModerationAction{
+		ID:          a.ID.Hex(),
+		ReportID:    a.ReportID,
+		PostID:      a.PostID.Hex(),
+		AuthorID:    a.AuthorID,
+		ModeratorID: a.ModeratorID,
+		Action:      a.Action,
+		Note:        a.Note,
+		CreatedAt:   a.CreatedAt,
+	}
+}
+
+func FromDomainModerationAction(a *entities.ModerationAction) (*ModerationAction, error) {
+	postID, err := primitive.ObjectIDFromHex(a.PostID)
+	if err != nil {
+		return nil, errors.ErrInvalidPostID
+	}
+	return &ModerationAction{
+		ReportID:    a.ReportID,
+		PostID:      postID,
+		AuthorID:    a.AuthorID,
+		ModeratorID: a.ModeratorID,
+		Action:      a.Action,
+		Note:        a.Note,
+		CreatedAt:   a.CreatedAt,
+	}, nil
+}
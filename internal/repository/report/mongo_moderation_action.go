@@ -0,0 +1,96 @@
+package reportrepo
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"anchor-blog/internal/domain/entities"
+	AppError "anchor-blog/internal/errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoModerationActionRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoModerationActionRepository creates a new moderation log repository with MongoDB implementation.
+func NewMongoModerationActionRepository(collection *mongo.Collection) entities.IModerationActionRepository {
+	ctx := context.Background()
+	if err := ensureModerationActionIndexes(ctx, collection); err != nil {
+		log.Printf("failed to create indexes on moderation actions: %v", err)
+	}
+	return &mongoModerationActionRepository{collection}
+}
+
+// the log is read per post and per author, newest first
+func ensureModerationActionIndexes(ctx context.Context, col *mongo.Collection) error {
+	_, err := col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "post_id", Value: 1}, {Key: "created_at", Value: -1}},
+			Options: options.Index().SetName("idx_moderation_action_post"),
+		},
+		{
+			Keys:    bson.D{{Key: "author_id", Value: 1}, {Key: "created_at", Value: -1}},
+			Options: options.Index().SetName("idx_moderation_action_author"),
+		},
+	})
+	return err
+}
+
+func (r *mongoModerationActionRepository) Create(ctx context.Context, dAction *entities.ModerationAction) (*entities.ModerationAction, error) {
+	action, err := FromDomainModerationAction(dAction)
+	if err != nil {
+		return nil, err
+	}
+	action.ID = primitive.NewObjectID()
+	action.CreatedAt = time.Now()
+
+	_, err = r.collection.InsertOne(ctx, action)
+	if err != nil {
+		log.Printf("Error recording moderation action on post %s: %v", dAction.PostID, err)
+		return nil, AppError.ErrInternalServer
+	}
+	return ToDomainModerationAction(action), nil
+}
+
+func (r *mongoModerationActionRepository) FindAll(ctx context.Context, postID, authorID string, opts entities.PaginationOptions) ([]*entities.ModerationAction, error) {
+	filter := bson.M{}
+	if postID != "" {
+		postObjID, err := primitive.ObjectIDFromHex(postID)
+		if err != nil {
+			return nil, AppError.ErrInvalidPostID
+		}
+		filter["post_id"] = postObjID
+	}
+	if authorID != "" {
+		filter["author_id"] = authorID
+	}
+
+	findOptions := options.Find()
+	findOptions.SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}) // Newest first
+	findOptions.SetSkip((opts.Page - 1) * opts.Limit)
+	findOptions.SetLimit(opts.Limit)
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		log.Printf("Error listing moderation actions: %v", err)
+		return nil, AppError.ErrInternalServer
+	}
+	defer cursor.Close(ctx)
+
+	var actions []ModerationAction
+	if err := cursor.All(ctx, &actions); err != nil {
+		return nil, AppError.ErrInternalServer
+	}
+
+	result := make([]*entities.ModerationAction, len(actions))
+	for i := range actions {
+		result[i] = ToDomainModerationAction(&actions[i])
+	}
+	return result, nil
+}
@@ -0,0 +1,150 @@
+package reportrepo
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"anchor-blog/internal/domain/entities"
+	AppError "anchor-blog/internal/errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoReportRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoReportRepository creates a new report repository with MongoDB implementation.
+func NewMongoReportRepository(collection *mongo.Collection) entities.IReportRepository {
+	ctx := context.Background()
+	if err := ensureReportIndexes(ctx, collection); err != nil {
+		log.Printf("failed to create indexes on reports: %v", err)
+	}
+	return &mongoReportRepository{collection}
+}
+
+// a reader has at most one open report per post, so one person can't push a post over the
+// auto-hide threshold; closed reports don't count, the post can be reported again afterwards
+func ensureReportIndexes(ctx context.Context, col *mongo.Collection) error {
+	_, err := col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "post_id", Value: 1}, {Key: "reporter_id", Value: 1}},
+			Options: options.Index().
+				SetName("idx_report_open_post_reporter").
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{"status": entities.ReportStatusOpen}),
+		},
+		{
+			Keys:    bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: 1}},
+			Options: options.Index().SetName("idx_report_status"),
+		},
+	})
+	return err
+}
+
+func (r *mongoReportRepository) Create(ctx context.Context, dReport *entities.Report) (*entities.Report, error) {
+	report, err := FromDomainReport(dReport)
+	if err != nil {
+		return nil, err
+	}
+	report.ID = primitive.NewObjectID()
+	report.Status = entities.ReportStatusOpen
+	report.CreatedAt = time.Now()
+
+	_, err = r.collection.InsertOne(ctx, report)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil, AppError.ErrAlreadyReported
+	}
+	if err != nil {
+		log.Printf("Error creating report on post %s: %v", dReport.PostID, err)
+		return nil, AppError.ErrInternalServer
+	}
+	return ToDomainReport(report), nil
+}
+
+func (r *mongoReportRepository) FindByID(ctx context.Context, id string) (*entities.Report, error) {
+	objId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, AppError.ErrInvalidReportID
+	}
+
+	var report Report
+	err = r.collection.FindOne(ctx, bson.M{"_id": objId}).Decode(&report)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, AppError.ErrNotFound
+		}
+		log.Printf("Error finding report %s: %v", id, err)
+		return nil, AppError.ErrInternalServer
+	}
+	return ToDomainReport(&report), nil
+}
+
+func (r *mongoReportRepository) FindAll(ctx context.Context, status string, opts entities.PaginationOptions) ([]*entities.Report, error) {
+	filter := bson.M{}
+	if status != "" {
+		filter["status"] = status
+	}
+
+	findOptions := options.Find()
+	findOptions.SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}) // Oldest first, like a queue
+	findOptions.SetSkip((opts.Page - 1) * opts.Limit)
+	findOptions.SetLimit(opts.Limit)
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		log.Printf("Error listing reports: %v", err)
+		return nil, AppError.ErrInternalServer
+	}
+	defer cursor.Close(ctx)
+
+	var reports []Report
+	if err := cursor.All(ctx, &reports); err != nil {
+		return nil, AppError.ErrInternalServer
+	}
+
+	result := make([]*entities.Report, len(reports))
+	for i := range reports {
+		result[i] = ToDomainReport(&reports[i])
+	}
+	return result, nil
+}
+
+func (r *mongoReportRepository) CountOpenByPost(ctx context.Context, postID string) (int64, error) {
+	postObjID, err := primitive.ObjectIDFromHex(postID)
+	if err != nil {
+		return 0, AppError.ErrInvalidPostID
+	}
+
+	count, err := r.collection.CountDocuments(ctx, bson.M{"post_id": postObjID, "status": entities.ReportStatusOpen})
+	if err != nil {
+		log.Printf("Error counting reports of post %s: %v", postID, err)
+		return 0, AppError.ErrInternalServer
+	}
+	return count, nil
+}
+
+func (r *mongoReportRepository) ResolveByPost(ctx context.Context, postID, status, moderatorID string, at time.Time) (int64, error) {
+	postObjID, err := primitive.ObjectIDFromHex(postID)
+	if err != nil {
+		return 0, AppError.ErrInvalidPostID
+	}
+
+	filter := bson.M{"post_id": postObjID, "status": entities.ReportStatusOpen}
+	update := bson.M{"$set": bson.M{
+		"status":       status,
+		"moderator_id": moderatorID,
+		"resolved_at":  at,
+	}}
+
+	result, err := r.collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		log.Printf("Error resolving reports of post %s: %v", postID, err)
+		return 0, AppError.ErrInternalServer
+	}
+	return result.ModifiedCount, nil
+}
@@ -0,0 +1,61 @@
+package revisionrepo
+
+import (
+	"anchor-blog/internal/domain/entities"
+	"anchor-blog/internal/errors"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type PostRevision struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty"`
+	PostID       primitive.ObjectID `bson:"post_id"`
+	Number       int                `bson:"number"`
+	EditorID     primitive.ObjectID `bson:"editor_id"`
+	Title        string             `bson:"title"`
+	Content      string             `bson:"content"`
+	Tags         []string           `bson:"tags"`
+	RestoredFrom int                `bson:"restored_from,omitempty"`
+	CreatedAt    time.Time          `bson:"created_at"`
+}
+
+// ::::::: Mapping functions :::::::::::
+func ToDomainRevision(r *PostRevision) *entities.PostRevision {
+	return &entities.PostRevision{
+		ID:           r.ID.Hex(),
+		PostID:       r.PostID.Hex(),
+		Number:       r.Number,
+		EditorID:     r.EditorID.Hex(),
+		Title:        r.Title,
+		Content:      r.Content,
+		Tags:         r.Tags,
+		RestoredFrom: r.RestoredFrom,
+		CreatedAt:    r.CreatedAt,
+	}
+}
+
+func FromDomainRevision(r *entities.PostRevision) (*PostRevision, error) {
+	postID, err := primitive.ObjectIDFromHex(r.PostID)
+	if err != nil {
+		log.Println("invalid post id ", r.PostID)
+		return nil, errors.ErrInvalidPostID
+	}
+	editorID, err := primitive.ObjectIDFromHex(r.EditorID)
+	if err != nil {
+		log.Println("invalid editor id ", r.EditorID)
+		return nil, errors.ErrInvalidUserID
+	}
+
+	return &PostRevision{
+		PostID:       postID,
+		Number:       r.Number,
+		EditorID:     editorID,
+		Title:        r.Title,
+		Content:      r.Content,
+		Tags:         r.Tags,
+		RestoredFrom: r.RestoredFrom,
+		CreatedAt:    r.CreatedAt,
+	}, nil
+}
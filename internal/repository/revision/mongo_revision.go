@@ -0,0 +1,165 @@
+package revisionrepo
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"anchor-blog/internal/domain/entities"
+	AppError "anchor-blog/internal/errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// number of times Create retries when a concurrent edit took the same revision number
+const maxNumberRetries = 3
+
+type mongoRevisionRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoRevisionRepository creates a new revision repository with MongoDB implementation.
+func NewMongoRevisionRepository(collection *mongo.Collection) entities.IRevisionRepository {
+	ctx := context.Background()
+	if err := ensureRevisionIndexes(ctx, collection); err != nil {
+		log.Printf("failed to create indexes on post revisions: %v", err)
+	}
+	return &mongoRevisionRepository{collection}
+}
+
+// the unique index guarantees revision numbers are never reused for a post
+func ensureRevisionIndexes(ctx context.Context, col *mongo.Collection) error {
+	_, err := col.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "post_id", Value: 1}, {Key: "number", Value: -1}},
+		Options: options.Index().
+			SetName("idx_revision_post_number").
+			SetUnique(true),
+	})
+	return err
+}
+
+func (r *mongoRevisionRepository) Create(ctx context.Context, dRevision *entities.PostRevision) (*entities.PostRevision, error) {
+	revision, err := FromDomainRevision(dRevision)
+	if err != nil {
+		return nil, err
+	}
+	if revision.CreatedAt.IsZero() {
+		revision.CreatedAt = time.Now()
+	}
+
+	for attempt := 0; attempt < maxNumberRetries; attempt++ {
+		latest, err := r.latestNumber(ctx, revision.PostID)
+		if err != nil {
+			return nil, err
+		}
+		revision.ID = primitive.NewObjectID()
+		revision.Number = latest + 1
+
+		_, err = r.collection.InsertOne(ctx, revision)
+		if err == nil {
+			return ToDomainRevision(revision), nil
+		}
+		if !mongo.IsDuplicateKeyError(err) {
+			log.Printf("Error storing revision for post %s: %v", dRevision.PostID, err)
+			return nil, AppError.ErrInternalServer
+		}
+	}
+
+	log.Printf("Error storing revision for post %s: revision number kept colliding", dRevision.PostID)
+	return nil, AppError.ErrInternalServer
+}
+
+// FindByPost lists the revisions of a post, newest first
+func (r *mongoRevisionRepository) FindByPost(ctx context.Context, postID string, opts entities.PaginationOptions) ([]*entities.PostRevision, error) {
+	postObjID, err := primitive.ObjectIDFromHex(postID)
+	if err != nil {
+		return nil, AppError.ErrInvalidPostID
+	}
+
+	findOptions := options.Find()
+	findOptions.SetSort(bson.D{{Key: "number", Value: -1}})
+	findOptions.SetSkip((opts.Page - 1) * opts.Limit)
+	findOptions.SetLimit(opts.Limit)
+
+	cursor, err := r.collection.Find(ctx, bson.M{"post_id": postObjID}, findOptions)
+	if err != nil {
+		return nil, AppError.ErrInternalServer
+	}
+	defer cursor.Close(ctx)
+
+	var revisions []PostRevision
+	if err := cursor.All(ctx, &revisions); err != nil {
+		return nil, AppError.ErrInternalServer
+	}
+
+	result := make([]*entities.PostRevision, len(revisions))
+	for idx, revision := range revisions {
+		result[idx] = ToDomainRevision(&revision)
+	}
+	return result, nil
+}
+
+func (r *mongoRevisionRepository) FindByNumber(ctx context.Context, postID string, number int) (*entities.PostRevision, error) {
+	postObjID, err := primitive.ObjectIDFromHex(postID)
+	if err != nil {
+		return nil, AppError.ErrInvalidPostID
+	}
+
+	var revision PostRevision
+	err = r.collection.FindOne(ctx, bson.M{"post_id": postObjID, "number": number}).Decode(&revision)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, AppError.ErrNotFound
+		}
+		return nil, AppError.ErrInternalServer
+	}
+	return ToDomainRevision(&revision), nil
+}
+
+func (r *mongoRevisionRepository) CountByPost(ctx context.Context, postID string) (int64, error) {
+	postObjID, err := primitive.ObjectIDFromHex(postID)
+	if err != nil {
+		return 0, AppError.ErrInvalidPostID
+	}
+
+	count, err := r.collection.CountDocuments(ctx, bson.M{"post_id": postObjID})
+	if err != nil {
+		return 0, AppError.ErrInternalServer
+	}
+	return count, nil
+}
+
+// DeleteByPostID removes the whole history of a deleted post
+func (r *mongoRevisionRepository) DeleteByPostID(ctx context.Context, postID string) error {
+	postObjID, err := primitive.ObjectIDFromHex(postID)
+	if err != nil {
+		return AppError.ErrInvalidPostID
+	}
+
+	_, err = r.collection.DeleteMany(ctx, bson.M{"post_id": postObjID})
+	if err != nil {
+		log.Printf("Error deleting revisions of post %s: %v", postID, err)
+		return AppError.ErrInternalServer
+	}
+
+	return nil
+}
+
+func (r *mongoRevisionRepository) latestNumber(ctx context.Context, postID primitive.ObjectID) (int, error) {
+	var latest PostRevision
+	opts := options.FindOne().
+		SetSort(bson.D{{Key: "number", Value: -1}}).
+		SetProjection(bson.M{"number": 1})
+
+	err := r.collection.FindOne(ctx, bson.M{"post_id": postID}, opts).Decode(&latest)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return 0, nil
+		}
+		return 0, AppError.ErrInternalServer
+	}
+	return latest.Number, nil
+}
@@ -0,0 +1,71 @@
+package seriesrepo
+
+import (
+	"anchor-blog/internal/domain/entities"
+	"anchor-blog/internal/errors"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Series struct {
+	ID          primitive.ObjectID   `bson:"_id,omitempty"`
+	AuthorID    primitive.ObjectID   `bson:"author_id"`
+	Title       string               `bson:"title"`
+	Description string               `bson:"description"`
+	PostIDs     []primitive.ObjectID `bson:"post_ids"`
+	CreatedAt   time.Time            `bson:"created_at"`
+	UpdatedAt   time.Time            `bson:"updated_at"`
+}
+
+// ::::::: Mapping functions :::::::::::
+func ToDomainSeries(s *Series) *entities.Series {
+	postIDs := make([]string, len(s.PostIDs))
+	for i, id := range s.PostIDs {
+		postIDs[i] = id.Hex()
+	}
+	return &entities.Series{
+		ID:          s.ID.Hex(),
+		AuthorID:    s.AuthorID.Hex(),
+		Title:       s.Title,
+		Description: s.Description,
+		PostIDs:     postIDs,
+		CreatedAt:   s.CreatedAt,
+		UpdatedAt:   s.UpdatedAt,
+	}
+}
+
+func FromDomainSeries(s *entities.Series) (*Series, error) {
+	authorID, err := primitive.ObjectIDFromHex(s.AuthorID)
+	if err != nil {
+		log.Println("invalid author id ", s.AuthorID)
+		return nil, errors.ErrInvalidUserID
+	}
+	postIDs, err := toObjectIDs(s.PostIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Series{
+		AuthorID:    authorID,
+		Title:       s.Title,
+		Description: s.Description,
+		PostIDs:     postIDs,
+		CreatedAt:   s.CreatedAt,
+		UpdatedAt:   s.UpdatedAt,
+	}, nil
+}
+
+func toObjectIDs(hexIDs []string) ([]primitive.ObjectID, error) {
+	ids := make([]primitive.ObjectID, len(hexIDs))
+	for i, hex := range hexIDs {
+		id, err := primitive.ObjectIDFromHex(hex)
+		if err != nil {
+			log.Println("invalid post id ", hex)
+			return nil, errors.ErrInvalidPostID
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
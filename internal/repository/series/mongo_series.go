@@ -0,0 +1,193 @@
+package seriesrepo
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"anchor-blog/internal/domain/entities"
+	AppError "anchor-blog/internal/errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoSeriesRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoSeriesRepository creates a new series repository with MongoDB implementation.
+func NewMongoSeriesRepository(collection *mongo.Collection) entities.ISeriesRepository {
+	ctx := context.Background()
+	if err := ensureSeriesIndexes(ctx, collection); err != nil {
+		log.Printf("failed to create indexes on series: %v", err)
+	}
+	return &mongoSeriesRepository{collection}
+}
+
+// creates the indexes for author listings and for finding the series of a post
+func ensureSeriesIndexes(ctx context.Context, col *mongo.Collection) error {
+	_, err := col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "author_id", Value: 1}, {Key: "created_at", Value: -1}},
+			Options: options.Index().SetName("idx_series_author"),
+		},
+		{
+			Keys:    bson.D{{Key: "post_ids", Value: 1}},
+			Options: options.Index().SetName("idx_series_posts"),
+		},
+	})
+	return err
+}
+
+func (r *mongoSeriesRepository) Create(ctx context.Context, dSeries *entities.Series) (*entities.Series, error) {
+	series, err := FromDomainSeries(dSeries)
+	if err != nil {
+		return nil, err
+	}
+	series.ID = primitive.NewObjectID()
+	series.CreatedAt = time.Now()
+	series.UpdatedAt = series.CreatedAt
+
+	_, err = r.collection.InsertOne(ctx, series)
+	if err != nil {
+		log.Printf("Error creating series for author %s: %v", dSeries.AuthorID, err)
+		return nil, AppError.ErrInternalServer
+	}
+
+	return ToDomainSeries(series), nil
+}
+
+func (r *mongoSeriesRepository) FindByID(ctx context.Context, id string) (*entities.Series, error) {
+	objId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		log.Println("unable to convert id to object id", id)
+		return nil, AppError.ErrInvalidSeriesID
+	}
+
+	var series Series
+	err = r.collection.FindOne(ctx, bson.M{"_id": objId}).Decode(&series)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, AppError.ErrNotFound
+		}
+		return nil, AppError.ErrInternalServer
+	}
+	return ToDomainSeries(&series), nil
+}
+
+// FindByAuthor lists the series of an author, newest first
+func (r *mongoSeriesRepository) FindByAuthor(ctx context.Context, authorID string, opts entities.PaginationOptions) ([]*entities.Series, error) {
+	authorObjID, err := primitive.ObjectIDFromHex(authorID)
+	if err != nil {
+		return nil, AppError.ErrInvalidUserID
+	}
+
+	findOptions := options.Find()
+	findOptions.SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}})
+	findOptions.SetSkip((opts.Page - 1) * opts.Limit)
+	findOptions.SetLimit(opts.Limit)
+
+	return r.find(ctx, bson.M{"author_id": authorObjID}, findOptions)
+}
+
+func (r *mongoSeriesRepository) FindByPostIDs(ctx context.Context, postIDs []string) ([]*entities.Series, error) {
+	postObjIDs, err := toObjectIDs(postIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.find(ctx, bson.M{"post_ids": bson.M{"$in": postObjIDs}}, options.Find())
+}
+
+// Update replaces the title, description and parts of a series
+func (r *mongoSeriesRepository) Update(ctx context.Context, id string, dSeries *entities.Series) (*entities.Series, error) {
+	objId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		log.Println("unable to convert id to object id", id)
+		return nil, AppError.ErrInvalidSeriesID
+	}
+	postIDs, err := toObjectIDs(dSeries.PostIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	update := bson.M{"$set": bson.M{
+		"title":       dSeries.Title,
+		"description": dSeries.Description,
+		"post_ids":    postIDs,
+		"updated_at":  time.Now(),
+	}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var series Series
+	err = r.collection.FindOneAndUpdate(ctx, bson.M{"_id": objId}, update, opts).Decode(&series)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, AppError.ErrNotFound
+		}
+		log.Printf("Error updating series %s: %v", id, err)
+		return nil, AppError.ErrInternalServer
+	}
+	return ToDomainSeries(&series), nil
+}
+
+func (r *mongoSeriesRepository) Delete(ctx context.Context, id string) error {
+	objId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		log.Println("unable to convert id to object id", id)
+		return AppError.ErrInvalidSeriesID
+	}
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": objId})
+	if err != nil {
+		log.Printf("Error deleting series %s: %v", id, err)
+		return AppError.ErrInternalServer
+	}
+	if result.DeletedCount == 0 {
+		return AppError.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *mongoSeriesRepository) RemovePost(ctx context.Context, postID string) error {
+	postObjID, err := primitive.ObjectIDFromHex(postID)
+	if err != nil {
+		return AppError.ErrInvalidPostID
+	}
+
+	filter := bson.M{"post_ids": postObjID}
+	update := bson.M{
+		"$pull": bson.M{"post_ids": postObjID},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+	_, err = r.collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		log.Printf("Error removing post %s from its series: %v", postID, err)
+		return AppError.ErrInternalServer
+	}
+
+	return nil
+}
+
+func (r *mongoSeriesRepository) find(ctx context.Context, filter bson.M, findOptions *options.FindOptions) ([]*entities.Series, error) {
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, AppError.ErrInternalServer
+	}
+	defer cursor.Close(ctx)
+
+	var series []Series
+	if err := cursor.All(ctx, &series); err != nil {
+		return nil, AppError.ErrInternalServer
+	}
+
+	result := make([]*entities.Series, len(series))
+	for idx := range series {
+		result[idx] = ToDomainSeries(&series[idx])
+	}
+	return result, nil
+}
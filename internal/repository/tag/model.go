@@ -0,0 +1,32 @@
+package tagrepo
+
+import (
+	"anchor-blog/internal/domain/entities"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Tag struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	Name        string             `bson:"name"`
+	Description string             `bson:"description"`
+	Aliases     []string           `bson:"aliases"`
+	CreatedAt   time.Time          `bson:"created_at"`
+	UpdatedAt   time.Time          `bson:"updated_at"`
+}
+
+// ::::::: Mapping functions :::::::::::
+func ToDomainTag(t *Tag) *entities.Tag {
+	aliases := t.Aliases
+	if aliases == nil {
+		aliases = []string{}
+	}
+	return &entities.Tag{
+		Name:        t.Name,
+		Description: t.Description,
+		Aliases:     aliases,
+		CreatedAt:   t.CreatedAt,
+		UpdatedAt:   t.UpdatedAt,
+	}
+}
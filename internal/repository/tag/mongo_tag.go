@@ -0,0 +1,206 @@
+package tagrepo
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"anchor-blog/internal/domain/entities"
+	AppError "anchor-blog/internal/errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoTagRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoTagRepository creates a new tag repository with MongoDB implementation.
+func NewMongoTagRepository(collection *mongo.Collection) entities.ITagRepository {
+	ctx := context.Background()
+	if err := ensureTagIndexes(ctx, collection); err != nil {
+		log.Printf("failed to create indexes on tags: %v", err)
+	}
+	return &mongoTagRepository{collection}
+}
+
+// one registry entry per name; aliases are looked up whenever posts are tagged
+func ensureTagIndexes(ctx context.Context, col *mongo.Collection) error {
+	_, err := col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "name", Value: 1}},
+			Options: options.Index().
+				SetName("idx_tag_name").
+				SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "aliases", Value: 1}},
+			Options: options.Index().SetName("idx_tag_aliases"),
+		},
+	})
+	return err
+}
+
+// EnsureTags registers the names that are not in the registry yet
+func (r *mongoTagRepository) EnsureTags(ctx context.Context, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	models := make([]mongo.WriteModel, len(names))
+	for i, name := range names {
+		models[i] = mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"name": name}).
+			SetUpdate(bson.M{"$setOnInsert": bson.M{
+				"name":        name,
+				"description": "",
+				"aliases":     bson.A{},
+				"created_at":  now,
+				"updated_at":  now,
+			}}).
+			SetUpsert(true)
+	}
+
+	_, err := r.collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	if err != nil && !mongo.IsDuplicateKeyError(err) {
+		// a duplicate key only means a concurrent request registered the same tag
+		log.Printf("Error registering tags %v: %v", names, err)
+		return AppError.ErrInternalServer
+	}
+	return nil
+}
+
+func (r *mongoTagRepository) FindByName(ctx context.Context, name string) (*entities.Tag, error) {
+	var tag Tag
+	err := r.collection.FindOne(ctx, bson.M{"name": name}).Decode(&tag)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, AppError.ErrNotFound
+		}
+		return nil, AppError.ErrInternalServer
+	}
+	return ToDomainTag(&tag), nil
+}
+
+// FindAll lists the whole registry by name
+func (r *mongoTagRepository) FindAll(ctx context.Context) ([]*entities.Tag, error) {
+	return r.find(ctx, bson.M{})
+}
+
+// FindByAliases returns the tags that list any of the names as an alias
+func (r *mongoTagRepository) FindByAliases(ctx context.Context, names []string) ([]*entities.Tag, error) {
+	if len(names) == 0 {
+		return []*entities.Tag{}, nil
+	}
+	return r.find(ctx, bson.M{"aliases": bson.M{"$in": names}})
+}
+
+func (r *mongoTagRepository) UpdateDescription(ctx context.Context, name, description string) (*entities.Tag, error) {
+	filter := bson.M{"name": name}
+	update := bson.M{"$set": bson.M{
+		"description": description,
+		"updated_at":  time.Now(),
+	}}
+
+	var tag Tag
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&tag)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, AppError.ErrNotFound
+		}
+		log.Printf("Error updating description of tag %s: %v", name, err)
+		return nil, AppError.ErrInternalServer
+	}
+	return ToDomainTag(&tag), nil
+}
+
+// Merge folds the source entries into target. The target keeps its own description,
+// or takes the first one found on a source when it has none.
+func (r *mongoTagRepository) Merge(ctx context.Context, sources []string, target string) (*entities.Tag, error) {
+	sourceTags, err := r.find(ctx, bson.M{"name": bson.M{"$in": sources, "$ne": target}})
+	if err != nil {
+		return nil, err
+	}
+
+	var current Tag
+	err = r.collection.FindOne(ctx, bson.M{"name": target}).Decode(&current)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return nil, AppError.ErrInternalServer
+	}
+
+	description := current.Description
+	aliases := append([]string{}, current.Aliases...)
+	aliases = append(aliases, sources...)
+	for _, source := range sourceTags {
+		if description == "" {
+			description = source.Description
+		}
+		aliases = append(aliases, source.Aliases...)
+	}
+
+	now := time.Now()
+	filter := bson.M{"name": target}
+	update := bson.M{
+		"$set": bson.M{
+			"description": description,
+			"aliases":     uniqueExcept(aliases, target),
+			"updated_at":  now,
+		},
+		"$setOnInsert": bson.M{"created_at": now},
+	}
+
+	var merged Tag
+	err = r.collection.FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().
+		SetUpsert(true).
+		SetReturnDocument(options.After)).Decode(&merged)
+	if err != nil {
+		log.Printf("Error merging tags %v into %s: %v", sources, target, err)
+		return nil, AppError.ErrInternalServer
+	}
+
+	_, err = r.collection.DeleteMany(ctx, bson.M{"name": bson.M{"$in": sources, "$ne": target}})
+	if err != nil {
+		log.Printf("Error removing merged tags %v: %v", sources, err)
+		return nil, AppError.ErrInternalServer
+	}
+
+	return ToDomainTag(&merged), nil
+}
+
+func (r *mongoTagRepository) find(ctx context.Context, filter bson.M) ([]*entities.Tag, error) {
+	findOptions := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, AppError.ErrInternalServer
+	}
+	defer cursor.Close(ctx)
+
+	var tags []Tag
+	if err := cursor.All(ctx, &tags); err != nil {
+		return nil, AppError.ErrInternalServer
+	}
+
+	result := make([]*entities.Tag, len(tags))
+	for idx, tag := range tags {
+		result[idx] = ToDomainTag(&tag)
+	}
+	return result, nil
+}
+
+// uniqueExcept drops duplicates and the excluded name, keeping the order
+func uniqueExcept(names []string, exclude string) []string {
+	result := []string{}
+	seen := map[string]bool{exclude: true}
+	for _, name := range names {
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		result = append(result, name)
+	}
+	return result
+}
@@ -0,0 +1,58 @@
+package trendingrepo
+
+import (
+	"anchor-blog/internal/domain/entities"
+	"anchor-blog/internal/errors"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TrendingSnapshot is stored once per window, keyed by the window name
+type TrendingSnapshot struct {
+	Window     string          `bson:"_id"`
+	Entries    []TrendingEntry `bson:"entries"`
+	ComputedAt time.Time       `bson:"computed_at"`
+}
+
+type TrendingEntry struct {
+	PostID primitive.ObjectID `bson:"post_id"`
+	Score  float64            `bson:"score"`
+}
+
+// ::::::: Mapping functions :::::::::::
+func ToDomainTrendingSnapshot(s *TrendingSnapshot) *entities.TrendingSnapshot {
+	entries := make([]entities.TrendingEntry, len(s.Entries))
+	for i, entry := range s.Entries {
+		entries[i] = entities.TrendingEntry{
+			PostID: entry.PostID.Hex(),
+			Score:  entry.Score,
+		}
+	}
+	return &entities.TrendingSnapshot{
+		Window:     s.Window,
+		Entries:    entries,
+		ComputedAt: s.ComputedAt,
+	}
+}
+
+func FromDomainTrendingSnapshot(s *entities.TrendingSnapshot) (*TrendingSnapshot, error) {
+	entries := make([]TrendingEntry, len(s.Entries))
+	for i, entry := range s.Entries {
+		postID, err := primitive.ObjectIDFromHex(entry.PostID)
+		if err != nil {
+			log.Println("invalid post id ", entry.PostID)
+			return nil, errors.ErrInvalidPostID
+		}
+		entries[i] = TrendingEntry{
+			PostID: postID,
+			Score:  entry.Score,
+		}
+	}
+	return &TrendingSnapshot{
+		Window:     s.Window,
+		Entries:    entries,
+		ComputedAt: s.ComputedAt,
+	}, nil
+}
@@ -0,0 +1,50 @@
+package trendingrepo
+
+import (
+	"context"
+	"log"
+
+	"anchor-blog/internal/domain/entities"
+	AppError "anchor-blog/internal/errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoTrendingRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoTrendingRepository creates a new trending repository with MongoDB implementation.
+func NewMongoTrendingRepository(collection *mongo.Collection) entities.ITrendingRepository {
+	return &mongoTrendingRepository{collection}
+}
+
+// SaveSnapshot swaps the whole document, so readers never see a half-written ranking
+func (r *mongoTrendingRepository) SaveSnapshot(ctx context.Context, dSnapshot *entities.TrendingSnapshot) error {
+	snapshot, err := FromDomainTrendingSnapshot(dSnapshot)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.collection.ReplaceOne(ctx, bson.M{"_id": snapshot.Window}, snapshot, options.Replace().SetUpsert(true))
+	if err != nil {
+		log.Printf("Error saving %s trending snapshot: %v", snapshot.Window, err)
+		return AppError.ErrInternalServer
+	}
+	return nil
+}
+
+func (r *mongoTrendingRepository) FindSnapshot(ctx context.Context, window string) (*entities.TrendingSnapshot, error) {
+	var snapshot TrendingSnapshot
+	err := r.collection.FindOne(ctx, bson.M{"_id": window}).Decode(&snapshot)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, AppError.ErrNotFound
+		}
+		log.Printf("Error finding %s trending snapshot: %v", window, err)
+		return nil, AppError.ErrInternalServer
+	}
+	return ToDomainTrendingSnapshot(&snapshot), nil
+}
@@ -9,6 +9,7 @@ import (
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
@@ -21,6 +22,9 @@ func (ur *userRepository) GetUserByID(ctx context.Context, id string) (*entities
 	filter := bson.M{"_id": ObjID}
 	var foundUser User
 	err = ur.collection.FindOne(ctx, filter).Decode(&foundUser)
+	if err == mongo.ErrNoDocuments {
+		return &entities.User{}, errorr.ErrUserNotFound
+	}
 	if err != nil {
 		log.Printf("error while find user %v", err.Error())
 		return &entities.User{}, errorr.ErrInternalServer
@@ -0,0 +1,94 @@
+package viewstatsrepo
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"anchor-blog/internal/domain/entities"
+	AppError "anchor-blog/internal/errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// buckets outlive the longest trending window a little, then expire
+const bucketRetention = 32 * 24 * time.Hour
+
+type mongoViewStatsRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoViewStatsRepository creates a new view statistics repository with MongoDB implementation.
+func NewMongoViewStatsRepository(collection *mongo.Collection) entities.IViewStatsRepository {
+	ctx := context.Background()
+	if err := ensureViewStatsIndexes(ctx, collection); err != nil {
+		log.Printf("failed to create indexes on post views: %v", err)
+	}
+	return &mongoViewStatsRepository{collection}
+}
+
+func ensureViewStatsIndexes(ctx context.Context, col *mongo.Collection) error {
+	_, err := col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "post_id", Value: 1}, {Key: "hour", Value: 1}},
+			Options: options.Index().
+				SetName("idx_views_post_hour").
+				SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "hour", Value: 1}},
+			Options: options.Index().
+				SetName("idx_views_hour_ttl").
+				SetExpireAfterSeconds(int32(bucketRetention.Seconds())),
+		},
+	})
+	return err
+}
+
+func (r *mongoViewStatsRepository) RecordView(ctx context.Context, postID string, at time.Time) error {
+	postObjID, err := primitive.ObjectIDFromHex(postID)
+	if err != nil {
+		return AppError.ErrInvalidPostID
+	}
+
+	filter := bson.M{"post_id": postObjID, "hour": at.UTC().Truncate(time.Hour)}
+	update := bson.M{"$inc": bson.M{"views": 1}}
+
+	_, err = r.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if err != nil {
+		log.Printf("Error recording view of post %s: %v", postID, err)
+		return AppError.ErrInternalServer
+	}
+	return nil
+}
+
+func (r *mongoViewStatsRepository) CountSince(ctx context.Context, since time.Time) (map[string]int, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"hour": bson.M{"$gte": since.UTC().Truncate(time.Hour)}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$post_id", "views": bson.M{"$sum": "$views"}}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		log.Printf("Error counting recent views: %v", err)
+		return nil, AppError.ErrInternalServer
+	}
+	defer cursor.Close(ctx)
+
+	var groups []struct {
+		PostID primitive.ObjectID `bson:"_id"`
+		Views  int                `bson:"views"`
+	}
+	if err := cursor.All(ctx, &groups); err != nil {
+		return nil, AppError.ErrInternalServer
+	}
+
+	counts := make(map[string]int, len(groups))
+	for _, group := range groups {
+		counts[group.PostID.Hex()] = group.Views
+	}
+	return counts, nil
+}
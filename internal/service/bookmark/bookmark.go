@@ -0,0 +1,136 @@
+package bookmarksvc
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"anchor-blog/internal/domain/entities"
+	AppError "anchor-blog/internal/errors"
+)
+
+const maxFolderLength = 50
+
+type BookmarkService struct {
+	bookmarkRepo entities.IBookmarkRepository
+	postRepo     entities.IPostRepository
+}
+
+// NewBookmarkService creates a new bookmark service.
+func NewBookmarkService(bookmarkRepo entities.IBookmarkRepository, postRepo entities.IPostRepository) *BookmarkService {
+	return &BookmarkService{
+		bookmarkRepo: bookmarkRepo,
+		postRepo:     postRepo,
+	}
+}
+
+// SavedPost is a bookmark together with the post it points to
+type SavedPost struct {
+	Bookmark *entities.Bookmark
+	Post     *entities.Post
+}
+
+// AddBookmark saves a post to the user's reading list. Bookmarking a post again moves it to the given folder.
+func (s *BookmarkService) AddBookmark(ctx context.Context, userID, postID, folder string) (*entities.Bookmark, error) {
+	folder, err := normalizeFolder(folder)
+	if err != nil {
+		return nil, err
+	}
+
+	posts, err := s.postRepo.FindByIDs(ctx, []string{postID})
+	if err != nil {
+		return nil, err
+	}
+	if len(posts) == 0 || !visibleTo(posts[0], userID) {
+		return nil, AppError.ErrNotFound
+	}
+
+	return s.bookmarkRepo.Save(ctx, &entities.Bookmark{
+		UserID: userID,
+		PostID: postID,
+		Folder: folder,
+	})
+}
+
+// RemoveBookmark takes a post off the user's reading list
+func (s *BookmarkService) RemoveBookmark(ctx context.Context, userID, postID string) error {
+	return s.bookmarkRepo.Delete(ctx, userID, postID)
+}
+
+// ListBookmarks returns the user's bookmarked posts, newest bookmark first.
+// Posts that are no longer visible to the user are left out.
+func (s *BookmarkService) ListBookmarks(ctx context.Context, userID, folder string, page, limit int64) ([]*SavedPost, error) {
+	folder, err := normalizeFolder(folder)
+	if err != nil {
+		return nil, err
+	}
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = 20
+	}
+
+	opts := entities.PaginationOptions{
+		Page:  page,
+		Limit: limit,
+	}
+
+	bookmarks, err := s.bookmarkRepo.FindByUser(ctx, userID, folder, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(bookmarks) == 0 {
+		return []*SavedPost{}, nil
+	}
+
+	postIDs := make([]string, len(bookmarks))
+	for idx, bookmark := range bookmarks {
+		postIDs[idx] = bookmark.PostID
+	}
+	posts, err := s.postRepo.FindByIDs(ctx, postIDs)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*entities.Post, len(posts))
+	for _, post := range posts {
+		byID[post.ID] = post
+	}
+
+	saved := make([]*SavedPost, 0, len(bookmarks))
+	for _, bookmark := range bookmarks {
+		if post, ok := byID[bookmark.PostID]; ok && visibleTo(post, userID) {
+			saved = append(saved, &SavedPost{Bookmark: bookmark, Post: post})
+		}
+	}
+	return saved, nil
+}
+
+// ListFolders returns the folders of the user's reading list with their sizes
+func (s *BookmarkService) ListFolders(ctx context.Context, userID string) ([]*entities.BookmarkFolder, error) {
+	return s.bookmarkRepo.ListFolders(ctx, userID)
+}
+
+// IsBookmarked reports whether the user saved the post
+func (s *BookmarkService) IsBookmarked(ctx context.Context, userID, postID string) (bool, error) {
+	return s.bookmarkRepo.Exists(ctx, userID, postID)
+}
+
+// DeletePostBookmarks removes the bookmarks of a deleted post
+func (s *BookmarkService) DeletePostBookmarks(ctx context.Context, postID string) error {
+	return s.bookmarkRepo.DeleteByPostID(ctx, postID)
+}
+
+// visibleTo reports whether the user may keep the post on their reading list.
+// Readers with a reading list are signed in, so members-only posts count as visible.
+func visibleTo(post *entities.Post, userID string) bool {
+	return post.CanEdit(userID) || post.CanView(userID, true)
+}
+
+func normalizeFolder(folder string) (string, error) {
+	folder = strings.Join(strings.Fields(folder), " ")
+	if utf8.RuneCountInString(folder) > maxFolderLength {
+		return "", AppError.ErrValidationFailed
+	}
+	return folder, nil
+}
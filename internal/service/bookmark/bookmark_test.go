@@ -0,0 +1,136 @@
+package bookmarksvc
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"anchor-blog/internal/domain/entities"
+	AppError "anchor-blog/internal/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockBookmarkRepository struct {
+	mock.Mock
+}
+
+func (m *MockBookmarkRepository) Save(ctx context.Context, bookmark *entities.Bookmark) (*entities.Bookmark, error) {
+	args := m.Called(ctx, bookmark)
+	return args.Get(0).(*entities.Bookmark), args.Error(1)
+}
+
+func (m *MockBookmarkRepository) Delete(ctx context.Context, userID, postID string) error {
+	args := m.Called(ctx, userID, postID)
+	return args.Error(0)
+}
+
+func (m *MockBookmarkRepository) FindByUser(ctx context.Context, userID, folder string, opts entities.PaginationOptions) ([]*entities.Bookmark, error) {
+	args := m.Called(ctx, userID, folder, opts)
+	return args.Get(0).([]*entities.Bookmark), args.Error(1)
+}
+
+func (m *MockBookmarkRepository) Exists(ctx context.Context, userID, postID string) (bool, error) {
+	args := m.Called(ctx, userID, postID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockBookmarkRepository) ListFolders(ctx context.Context, userID string) ([]*entities.BookmarkFolder, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]*entities.BookmarkFolder), args.Error(1)
+}
+
+func (m *MockBookmarkRepository) DeleteByPostID(ctx context.Context, postID string) error {
+	args := m.Called(ctx, postID)
+	return args.Error(0)
+}
+
+// Mock post repository; only the bulk lookup is used by the bookmark service
+type MockPostRepository struct {
+	entities.IPostRepository
+	mock.Mock
+}
+
+func (m *MockPostRepository) FindByIDs(ctx context.Context, ids []string) ([]*entities.Post, error) {
+	args := m.Called(ctx, ids)
+	return args.Get(0).([]*entities.Post), args.Error(1)
+}
+
+func TestBookmarkService_AddBookmark_Success(t *testing.T) {
+	// Setup
+	bookmarkRepo := new(MockBookmarkRepository)
+	postRepo := new(MockPostRepository)
+	service := NewBookmarkService(bookmarkRepo, postRepo)
+
+	postRepo.On("FindByIDs", mock.Anything, []string{"post-1"}).
+		Return([]*entities.Post{{ID: "post-1", Status: entities.PostStatusPublished}}, nil)
+	bookmarkRepo.On("Save", mock.Anything, &entities.Bookmark{UserID: "user-1", PostID: "post-1", Folder: "to read"}).
+		Return(&entities.Bookmark{ID: "bm-1", UserID: "user-1", PostID: "post-1", Folder: "to read"}, nil)
+
+	// Execute
+	bookmark, err := service.AddBookmark(context.Background(), "user-1", "post-1", "  to   read ")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "bm-1", bookmark.ID)
+	bookmarkRepo.AssertExpectations(t)
+}
+
+func TestBookmarkService_AddBookmark_HiddenPost(t *testing.T) {
+	// Setup
+	bookmarkRepo := new(MockBookmarkRepository)
+	postRepo := new(MockPostRepository)
+	service := NewBookmarkService(bookmarkRepo, postRepo)
+
+	postRepo.On("FindByIDs", mock.Anything, []string{"post-1"}).
+		Return([]*entities.Post{{ID: "post-1", AuthorID: "author-1", Status: entities.PostStatusDraft}}, nil)
+
+	// Execute
+	bookmark, err := service.AddBookmark(context.Background(), "user-1", "post-1", "")
+
+	// Assert
+	assert.Equal(t, AppError.ErrNotFound, err)
+	assert.Nil(t, bookmark)
+	bookmarkRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestBookmarkService_AddBookmark_FolderTooLong(t *testing.T) {
+	// Setup
+	service := NewBookmarkService(new(MockBookmarkRepository), new(MockPostRepository))
+
+	// Execute
+	bookmark, err := service.AddBookmark(context.Background(), "user-1", "post-1", strings.Repeat("x", 51))
+
+	// Assert
+	assert.Equal(t, AppError.ErrValidationFailed, err)
+	assert.Nil(t, bookmark)
+}
+
+func TestBookmarkService_ListBookmarks_KeepsBookmarkOrder(t *testing.T) {
+	// Setup
+	bookmarkRepo := new(MockBookmarkRepository)
+	postRepo := new(MockPostRepository)
+	service := NewBookmarkService(bookmarkRepo, postRepo)
+
+	bookmarks := []*entities.Bookmark{
+		{ID: "bm-3", PostID: "post-3"},
+		{ID: "bm-2", PostID: "post-2"},
+		{ID: "bm-1", PostID: "post-1"},
+	}
+	bookmarkRepo.On("FindByUser", mock.Anything, "user-1", "", entities.PaginationOptions{Page: 1, Limit: 20}).Return(bookmarks, nil)
+	postRepo.On("FindByIDs", mock.Anything, []string{"post-3", "post-2", "post-1"}).Return([]*entities.Post{
+		{ID: "post-1", Status: entities.PostStatusPublished},
+		{ID: "post-2", Status: entities.PostStatusArchived, AuthorID: "someone-else"},
+		{ID: "post-3", Status: entities.PostStatusPublished},
+	}, nil)
+
+	// Execute
+	saved, err := service.ListBookmarks(context.Background(), "user-1", "", 0, 0)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, saved, 2)
+	assert.Equal(t, "post-3", saved[0].Post.ID)
+	assert.Equal(t, "post-1", saved[1].Post.ID)
+}
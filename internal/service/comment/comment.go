@@ -0,0 +1,170 @@
+package commentsvc
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"anchor-blog/internal/domain/entities"
+	AppError "anchor-blog/internal/errors"
+)
+
+const maxCommentLength = 5000
+
+type CommentService struct {
+	commentRepo entities.ICommentRepository
+	postRepo    entities.IPostRepository
+	access      PostAccess
+}
+
+// PostAccess decides who may read a post; the post service implements it
+type PostAccess interface {
+	CheckAccess(ctx context.Context, post *entities.Post, viewerID string) error
+}
+
+// NewCommentService creates a new comment service.
+// A post's comments can only be read and written by readers access lets open the post.
+func NewCommentService(commentRepo entities.ICommentRepository, postRepo entities.IPostRepository, access PostAccess) *CommentService {
+	return &CommentService{
+		commentRepo: commentRepo,
+		postRepo:    postRepo,
+		access:      access,
+	}
+}
+
+// AddComment creates a top-level comment, or a reply when parentID is set
+func (s *CommentService) AddComment(ctx context.Context, postID, authorID, parentID, content string) (*entities.Comment, error) {
+	content = strings.TrimSpace(content)
+	if content == "" || len(content) > maxCommentLength {
+		return nil, AppError.ErrValidationFailed
+	}
+
+	if err := s.checkAccess(ctx, postID, authorID); err != nil {
+		return nil, err
+	}
+
+	if parentID != "" {
+		parent, err := s.commentRepo.FindByID(ctx, parentID)
+		if err != nil {
+			return nil, err
+		}
+		// Replies must stay inside the thread of the same post
+		if parent.PostID != postID || parent.Deleted {
+			return nil, AppError.ErrValidationFailed
+		}
+	}
+
+	comment, err := s.commentRepo.Create(ctx, &entities.Comment{
+		PostID:   postID,
+		AuthorID: authorID,
+		ParentID: parentID,
+		Content:  content,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.postRepo.IncrementCommentCount(ctx, postID, 1); err != nil {
+		log.Printf("Error incrementing comment count for post %s: %v", postID, err)
+	}
+	if parentID != "" {
+		if err := s.commentRepo.IncrementReplyCount(ctx, parentID, 1); err != nil {
+			log.Printf("Error incrementing reply count for comment %s: %v", parentID, err)
+		}
+	}
+
+	return comment, nil
+}
+
+// ListComments returns the top-level comments of a post; viewerID is empty for anonymous readers
+func (s *CommentService) ListComments(ctx context.Context, postID, viewerID string, page, limit int64) ([]*entities.Comment, error) {
+	if err := s.checkAccess(ctx, postID, viewerID); err != nil {
+		return nil, err
+	}
+	return s.commentRepo.FindByPost(ctx, postID, paginationOptions(page, limit))
+}
+
+// ListReplies returns the direct replies to a comment; viewerID is empty for anonymous readers
+func (s *CommentService) ListReplies(ctx context.Context, commentID, viewerID string, page, limit int64) ([]*entities.Comment, error) {
+	parent, err := s.commentRepo.FindByID(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkAccess(ctx, parent.PostID, viewerID); err != nil {
+		return nil, err
+	}
+	return s.commentRepo.FindReplies(ctx, commentID, paginationOptions(page, limit))
+}
+
+// checkAccess loads a post and makes sure the reader may open it
+func (s *CommentService) checkAccess(ctx context.Context, postID, viewerID string) error {
+	post, err := s.postRepo.FindByID(ctx, postID)
+	if err != nil {
+		return err
+	}
+	return s.access.CheckAccess(ctx, post, viewerID)
+}
+
+// EditComment updates the content of a comment owned by userID
+func (s *CommentService) EditComment(ctx context.Context, commentID, userID, content string) (*entities.Comment, error) {
+	content = strings.TrimSpace(content)
+	if content == "" || len(content) > maxCommentLength {
+		return nil, AppError.ErrValidationFailed
+	}
+
+	comment, err := s.commentRepo.FindByID(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.Deleted {
+		return nil, AppError.ErrNotFound
+	}
+	if comment.AuthorID != userID {
+		return nil, AppError.ErrForbidden
+	}
+
+	return s.commentRepo.UpdateContent(ctx, commentID, content)
+}
+
+// DeleteComment tombstones a comment owned by userID so its replies remain readable
+func (s *CommentService) DeleteComment(ctx context.Context, commentID, userID string) error {
+	comment, err := s.commentRepo.FindByID(ctx, commentID)
+	if err != nil {
+		return err
+	}
+	if comment.Deleted {
+		return AppError.ErrNotFound
+	}
+	if comment.AuthorID != userID {
+		return AppError.ErrForbidden
+	}
+
+	if err := s.commentRepo.Tombstone(ctx, commentID); err != nil {
+		return err
+	}
+
+	if err := s.postRepo.IncrementCommentCount(ctx, comment.PostID, -1); err != nil {
+		log.Printf("Error decrementing comment count for post %s: %v", comment.PostID, err)
+	}
+
+	return nil
+}
+
+// DeletePostComments removes the whole thread of a deleted post
+func (s *CommentService) DeletePostComments(ctx context.Context, postID string) error {
+	return s.commentRepo.DeleteByPostID(ctx, postID)
+}
+
+func paginationOptions(page, limit int64) entities.PaginationOptions {
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = 20 // Default limit
+	}
+
+	return entities.PaginationOptions{
+		Page:  page,
+		Limit: limit,
+	}
+}
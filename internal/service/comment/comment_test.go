@@ -0,0 +1,235 @@
+package commentsvc
+
+import (
+	"context"
+	"testing"
+
+	"anchor-blog/internal/domain/entities"
+	AppError "anchor-blog/internal/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock CommentRepository for testing
+type MockCommentRepository struct {
+	mock.Mock
+}
+
+func (m *MockCommentRepository) Create(ctx context.Context, comment *entities.Comment) (*entities.Comment, error) {
+	args := m.Called(ctx, comment)
+	return args.Get(0).(*entities.Comment), args.Error(1)
+}
+
+func (m *MockCommentRepository) FindByID(ctx context.Context, id string) (*entities.Comment, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(*entities.Comment), args.Error(1)
+}
+
+func (m *MockCommentRepository) FindByPost(ctx context.Context, postID string, opts entities.PaginationOptions) ([]*entities.Comment, error) {
+	args := m.Called(ctx, postID, opts)
+	return args.Get(0).([]*entities.Comment), args.Error(1)
+}
+
+func (m *MockCommentRepository) FindReplies(ctx context.Context, parentID string, opts entities.PaginationOptions) ([]*entities.Comment, error) {
+	args := m.Called(ctx, parentID, opts)
+	return args.Get(0).([]*entities.Comment), args.Error(1)
+}
+
+func (m *MockCommentRepository) UpdateContent(ctx context.Context, id, content string) (*entities.Comment, error) {
+	args := m.Called(ctx, id, content)
+	return args.Get(0).(*entities.Comment), args.Error(1)
+}
+
+func (m *MockCommentRepository) Tombstone(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockCommentRepository) IncrementReplyCount(ctx context.Context, id string, delta int) error {
+	args := m.Called(ctx, id, delta)
+	return args.Error(0)
+}
+
+func (m *MockCommentRepository) DeleteByPostID(ctx context.Context, postID string) error {
+	args := m.Called(ctx, postID)
+	return args.Error(0)
+}
+
+// Mock PostRepository; only the methods used by the comment service are stubbed
+type MockPostRepository struct {
+	entities.IPostRepository
+	mock.Mock
+}
+
+func (m *MockPostRepository) FindByID(ctx context.Context, id string) (*entities.Post, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(*entities.Post), args.Error(1)
+}
+
+func (m *MockPostRepository) IncrementCommentCount(ctx context.Context, postID string, delta int) error {
+	args := m.Called(ctx, postID, delta)
+	return args.Error(0)
+}
+
+// postAccess lets a reader open the posts Post.CanView allows, like the post service does
+type postAccess struct{}
+
+func (postAccess) CheckAccess(ctx context.Context, post *entities.Post, viewerID string) error {
+	if !post.CanView(viewerID, false) {
+		return AppError.ErrNotFound
+	}
+	return nil
+}
+
+func TestCommentService_AddComment_Reply(t *testing.T) {
+	// Setup
+	commentRepo := new(MockCommentRepository)
+	postRepo := new(MockPostRepository)
+	service := NewCommentService(commentRepo, postRepo, postAccess{})
+
+	postID := "post-123"
+	parent := &entities.Comment{ID: "comment-1", PostID: postID, AuthorID: "user-1"}
+	created := &entities.Comment{ID: "comment-2", PostID: postID, AuthorID: "user-2", ParentID: parent.ID, Content: "Nice"}
+
+	// Mock expectations
+	postRepo.On("FindByID", mock.Anything, postID).Return(&entities.Post{ID: postID}, nil)
+	commentRepo.On("FindByID", mock.Anything, parent.ID).Return(parent, nil)
+	commentRepo.On("Create", mock.Anything, mock.MatchedBy(func(c *entities.Comment) bool {
+		return c.ParentID == parent.ID && c.Content == "Nice"
+	})).Return(created, nil)
+	postRepo.On("IncrementCommentCount", mock.Anything, postID, 1).Return(nil)
+	commentRepo.On("IncrementReplyCount", mock.Anything, parent.ID, 1).Return(nil)
+
+	// Execute
+	result, err := service.AddComment(context.Background(), postID, "user-2", parent.ID, "  Nice  ")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, created.ID, result.ID)
+
+	commentRepo.AssertExpectations(t)
+	postRepo.AssertExpectations(t)
+}
+
+func TestCommentService_AddComment_ParentFromOtherPost(t *testing.T) {
+	// Setup
+	commentRepo := new(MockCommentRepository)
+	postRepo := new(MockPostRepository)
+	service := NewCommentService(commentRepo, postRepo, postAccess{})
+
+	postRepo.On("FindByID", mock.Anything, "post-1").Return(&entities.Post{ID: "post-1"}, nil)
+	commentRepo.On("FindByID", mock.Anything, "comment-1").Return(&entities.Comment{ID: "comment-1", PostID: "post-2"}, nil)
+
+	// Execute
+	result, err := service.AddComment(context.Background(), "post-1", "user-1", "comment-1", "hello")
+
+	// Assert
+	assert.ErrorIs(t, err, AppError.ErrValidationFailed)
+	assert.Nil(t, result)
+	commentRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestCommentService_AddComment_EmptyContent(t *testing.T) {
+	service := NewCommentService(new(MockCommentRepository), new(MockPostRepository), postAccess{})
+
+	result, err := service.AddComment(context.Background(), "post-1", "user-1", "", "   ")
+
+	assert.ErrorIs(t, err, AppError.ErrValidationFailed)
+	assert.Nil(t, result)
+}
+
+func TestCommentService_EditComment_NotOwner(t *testing.T) {
+	// Setup
+	commentRepo := new(MockCommentRepository)
+	service := NewCommentService(commentRepo, new(MockPostRepository), postAccess{})
+
+	commentRepo.On("FindByID", mock.Anything, "comment-1").Return(&entities.Comment{ID: "comment-1", AuthorID: "user-1"}, nil)
+
+	// Execute
+	result, err := service.EditComment(context.Background(), "comment-1", "user-2", "edited")
+
+	// Assert
+	assert.ErrorIs(t, err, AppError.ErrForbidden)
+	assert.Nil(t, result)
+	commentRepo.AssertNotCalled(t, "UpdateContent", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCommentService_DeleteComment_Success(t *testing.T) {
+	// Setup
+	commentRepo := new(MockCommentRepository)
+	postRepo := new(MockPostRepository)
+	service := NewCommentService(commentRepo, postRepo, postAccess{})
+
+	comment := &entities.Comment{ID: "comment-1", PostID: "post-1", AuthorID: "user-1"}
+
+	commentRepo.On("FindByID", mock.Anything, comment.ID).Return(comment, nil)
+	commentRepo.On("Tombstone", mock.Anything, comment.ID).Return(nil)
+	postRepo.On("IncrementCommentCount", mock.Anything, comment.PostID, -1).Return(nil)
+
+	// Execute
+	err := service.DeleteComment(context.Background(), comment.ID, "user-1")
+
+	// Assert
+	assert.NoError(t, err)
+	commentRepo.AssertExpectations(t)
+	postRepo.AssertExpectations(t)
+}
+
+func TestCommentService_ListComments_DefaultPagination(t *testing.T) {
+	// Setup
+	commentRepo := new(MockCommentRepository)
+	postRepo := new(MockPostRepository)
+	service := NewCommentService(commentRepo, postRepo, postAccess{})
+
+	postRepo.On("FindByID", mock.Anything, "post-1").Return(&entities.Post{ID: "post-1"}, nil)
+	commentRepo.On("FindByPost", mock.Anything, "post-1", entities.PaginationOptions{
+		Page:  1,
+		Limit: 20,
+	}).Return([]*entities.Comment{{ID: "comment-1"}}, nil)
+
+	// Execute
+	result, err := service.ListComments(context.Background(), "post-1", "", 0, 0)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	commentRepo.AssertExpectations(t)
+}
+
+func TestCommentService_HiddenPosts(t *testing.T) {
+	tests := []struct {
+		name string
+		post *entities.Post
+	}{
+		{"private post", &entities.Post{ID: "post-1", AuthorID: "author", Visibility: entities.PostVisibilityPrivate}},
+		{"draft post", &entities.Post{ID: "post-1", AuthorID: "author", Status: entities.PostStatusDraft}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			commentRepo := new(MockCommentRepository)
+			postRepo := new(MockPostRepository)
+			service := NewCommentService(commentRepo, postRepo, postAccess{})
+
+			postRepo.On("FindByID", mock.Anything, "post-1").Return(tt.post, nil)
+			commentRepo.On("FindByID", mock.Anything, "comment-1").Return(&entities.Comment{ID: "comment-1", PostID: "post-1"}, nil)
+
+			// Execute
+			comments, listErr := service.ListComments(context.Background(), "post-1", "reader", 1, 20)
+			replies, repliesErr := service.ListReplies(context.Background(), "comment-1", "", 1, 20)
+			created, addErr := service.AddComment(context.Background(), "post-1", "reader", "", "hello")
+
+			// Assert
+			assert.ErrorIs(t, listErr, AppError.ErrNotFound)
+			assert.Nil(t, comments)
+			assert.ErrorIs(t, repliesErr, AppError.ErrNotFound)
+			assert.Nil(t, replies)
+			assert.ErrorIs(t, addErr, AppError.ErrNotFound)
+			assert.Nil(t, created)
+			commentRepo.AssertNotCalled(t, "FindByPost", mock.Anything, mock.Anything, mock.Anything)
+			commentRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
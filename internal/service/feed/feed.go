@@ -0,0 +1,266 @@
+package feedsvc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"anchor-blog/internal/domain/entities"
+	AppError "anchor-blog/internal/errors"
+	postsvc "anchor-blog/internal/service/post"
+	"anchor-blog/pkg/feed"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Feed formats
+const (
+	FormatRSS  = "rss"
+	FormatAtom = "atom"
+	FormatJSON = "json"
+)
+
+// Content modes: the whole rendered post, or only its excerpt
+const (
+	ContentFull    = "full"
+	ContentExcerpt = "excerpt"
+)
+
+const (
+	defaultTitle   = "Anchor Blog"
+	defaultLimit   = 20
+	maxLimit       = 100
+	defaultPostURL = "/api/v1/posts/{id}"
+)
+
+// emptyFeedUpdated is the update time of a feed without items
+var emptyFeedUpdated = time.Unix(0, 0).UTC()
+
+// Settings describe the site the feeds are published for
+type Settings struct {
+	Title       string
+	Description string
+	SiteURL     string // defaults to the origin of the request
+	PostURL     string // pattern with {id} and {slug} placeholders; a path is resolved against the origin
+	Limit       int    // posts per feed
+	Content     string // default content mode
+}
+
+// Request is a feed as asked for by a reader. At most one of Tag and Author is set.
+type Request struct {
+	Format  string
+	Content string // empty for the configured default
+	Tag     string
+	Author  string // username or user id
+	Origin  string // scheme and host the request came in on
+	SelfURL string
+}
+
+// Document is an encoded feed along with what a client needs to cache it
+type Document struct {
+	Body         []byte
+	ContentType  string
+	ETag         string
+	LastModified time.Time // zero when the feed is empty
+}
+
+type FeedService struct {
+	postService *postsvc.PostService
+	userRepo    entities.IUserReaderRepository
+	settings    Settings
+}
+
+// NewFeedService creates a new feed service.
+// Feeds reuse the post listing, tag filter and author search of the post service.
+func NewFeedService(postService *postsvc.PostService, userRepo entities.IUserReaderRepository, settings Settings) *FeedService {
+	if settings.Title == "" {
+		settings.Title = defaultTitle
+	}
+	if settings.PostURL == "" {
+		settings.PostURL = defaultPostURL
+	}
+	if settings.Limit <= 0 || settings.Limit > maxLimit {
+		settings.Limit = defaultLimit
+	}
+	if settings.Content != ContentExcerpt {
+		settings.Content = ContentFull
+	}
+	return &FeedService{
+		postService: postService,
+		userRepo:    userRepo,
+		settings:    settings,
+	}
+}
+
+// Generate builds and encodes the requested feed
+func (s *FeedService) Generate(ctx context.Context, req Request) (*Document, error) {
+	encode, contentType, ok := encoder(req.Format)
+	if !ok {
+		return nil, AppError.ErrValidationFailed
+	}
+	content := req.Content
+	if content == "" {
+		content = s.settings.Content
+	}
+	if content != ContentFull && content != ContentExcerpt {
+		return nil, AppError.ErrValidationFailed
+	}
+
+	siteURL := s.settings.SiteURL
+	if siteURL == "" {
+		siteURL = req.Origin
+	}
+	f := &feed.Feed{
+		Title:       s.settings.Title,
+		Description: s.settings.Description,
+		SiteURL:     siteURL,
+		FeedURL:     req.SelfURL,
+	}
+
+	pageReq := postsvc.PageRequest{Limit: int64(s.settings.Limit)}
+	var page *postsvc.PostPage
+	var err error
+	switch {
+	case req.Tag != "":
+		f.Title += " – #" + req.Tag
+		page, err = s.postService.QueryPosts(ctx, postsvc.SearchCriteria{Tags: []string{req.Tag}}, pageReq)
+	case req.Author != "":
+		author, findErr := s.findAuthor(ctx, req.Author)
+		if findErr != nil {
+			return nil, findErr
+		}
+		f.Title += " – " + displayName(author)
+		page, err = s.postService.QueryPosts(ctx, postsvc.SearchCriteria{Author: author.ID}, pageReq)
+	default:
+		page, err = s.postService.ListPosts(ctx, pageReq)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var lastModified time.Time
+	authors := make(map[string]string)
+	for _, post := range page.Posts {
+		item := s.item(ctx, post, req.Origin, content, authors)
+		f.Items = append(f.Items, item)
+		if item.Updated.After(lastModified) {
+			lastModified = item.Updated
+		}
+	}
+	f.Updated = lastModified
+	if f.Updated.IsZero() {
+		// a fixed time keeps the body of an empty feed, and so its ETag, the same
+		f.Updated = emptyFeedUpdated
+	}
+
+	body, err := encode(f)
+	if err != nil {
+		return nil, AppError.ErrInternalServer
+	}
+
+	sum := sha256.Sum256(body)
+	return &Document{
+		Body:         body,
+		ContentType:  contentType,
+		ETag:         `"` + hex.EncodeToString(sum[:16]) + `"`,
+		LastModified: lastModified,
+	}, nil
+}
+
+func (s *FeedService) item(ctx context.Context, post *entities.Post, origin, content string, authors map[string]string) feed.Item {
+	post = s.postService.EnsureRendered(ctx, post)
+
+	published := post.PublishAt
+	if published.IsZero() {
+		published = post.CreatedAt
+	}
+	updated := post.UpdatedAt
+	if updated.Before(published) {
+		updated = published
+	}
+
+	url := s.postURL(post, origin)
+	item := feed.Item{
+		ID:        url,
+		URL:       url,
+		Title:     post.Title,
+		Author:    s.authorName(ctx, post.AuthorID, authors),
+		Tags:      post.Tags,
+		Published: published,
+		Updated:   updated,
+		Summary:   post.Rendered.Excerpt,
+	}
+	if content == ContentFull {
+		item.HTML = post.Rendered.HTML
+	}
+	return item
+}
+
+func (s *FeedService) postURL(post *entities.Post, origin string) string {
+	slug := post.Slug
+	if slug == "" {
+		slug = post.ID
+	}
+	url := strings.NewReplacer("{id}", post.ID, "{slug}", slug).Replace(s.settings.PostURL)
+	if strings.HasPrefix(url, "/") {
+		url = origin + url
+	}
+	return url
+}
+
+// authorName looks each author up once per feed; unknown authors stay anonymous
+func (s *FeedService) authorName(ctx context.Context, authorID string, names map[string]string) string {
+	if name, ok := names[authorID]; ok {
+		return name
+	}
+	name := ""
+	if user, err := s.userRepo.GetUserByID(ctx, authorID); err == nil {
+		name = displayName(user)
+	}
+	names[authorID] = name
+	return name
+}
+
+// findAuthor resolves a username, or a user id for authors linked by id
+func (s *FeedService) findAuthor(ctx context.Context, author string) (*entities.User, error) {
+	user, err := s.userRepo.GetUserByUsername(ctx, author)
+	if err == nil {
+		return user, nil
+	}
+	if !errors.Is(err, AppError.ErrNotFound) {
+		return nil, err
+	}
+	if _, hexErr := primitive.ObjectIDFromHex(author); hexErr != nil {
+		return nil, AppError.ErrUserNotFound
+	}
+
+	// the user repository reports a missing id as an internal error
+	user, err = s.userRepo.GetUserByID(ctx, author)
+	if err != nil {
+		return nil, AppError.ErrUserNotFound
+	}
+	return user, nil
+}
+
+func displayName(user *entities.User) string {
+	name := strings.TrimSpace(user.FirstName + " " + user.LastName)
+	if name == "" {
+		name = user.Username
+	}
+	return name
+}
+
+func encoder(format string) (func(*feed.Feed) ([]byte, error), string, bool) {
+	switch format {
+	case FormatRSS:
+		return feed.RSS, feed.RSSContentType, true
+	case FormatAtom:
+		return feed.Atom, feed.AtomContentType, true
+	case FormatJSON:
+		return feed.JSON, feed.JSONContentType, true
+	}
+	return nil, "", false
+}
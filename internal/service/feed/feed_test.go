@@ -0,0 +1,178 @@
+package feedsvc
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"anchor-blog/internal/domain/entities"
+	AppError "anchor-blog/internal/errors"
+	postsvc "anchor-blog/internal/service/post"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock post repository; only the listings and the render cache are used through the post service
+type MockPostRepository struct {
+	entities.IPostRepository
+	mock.Mock
+}
+
+func (m *MockPostRepository) FindAll(ctx context.Context, opts entities.PaginationOptions) ([]*entities.Post, error) {
+	args := m.Called(ctx, opts)
+	return args.Get(0).([]*entities.Post), args.Error(1)
+}
+
+func (m *MockPostRepository) Query(ctx context.Context, query entities.PostQuery, opts entities.PaginationOptions) ([]*entities.Post, error) {
+	args := m.Called(ctx, query, opts)
+	return args.Get(0).([]*entities.Post), args.Error(1)
+}
+
+func (m *MockPostRepository) SaveRendered(ctx context.Context, id string, rendered *entities.RenderedContent) error {
+	return nil
+}
+
+// Mock user repository; only the lookups of authors are used
+type MockUserRepository struct {
+	entities.IUserReaderRepository
+	mock.Mock
+}
+
+func (m *MockUserRepository) GetUserByID(ctx context.Context, id string) (*entities.User, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(*entities.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetUserByUsername(ctx context.Context, username string) (*entities.User, error) {
+	args := m.Called(ctx, username)
+	return args.Get(0).(*entities.User), args.Error(1)
+}
+
+var (
+	older = &entities.Post{ID: "post-1", Slug: "hello", Title: "Hello", Content: "First **post**.", AuthorID: "author-1",
+		PublishAt: time.Date(2025, 8, 1, 9, 0, 0, 0, time.UTC), UpdatedAt: time.Date(2025, 8, 3, 9, 0, 0, 0, time.UTC)}
+	newer = &entities.Post{ID: "post-2", Slug: "again", Title: "Again", Content: "Second post.", AuthorID: "author-1",
+		PublishAt: time.Date(2025, 8, 2, 9, 0, 0, 0, time.UTC), UpdatedAt: time.Date(2025, 8, 2, 9, 0, 0, 0, time.UTC)}
+	alice = &entities.User{ID: "64b7f0c2a1b2c3d4e5f60718", Username: "alice", FirstName: "Alice", LastName: "Doe"}
+)
+
+func newTestService(postRepo *MockPostRepository, userRepo *MockUserRepository, settings Settings) *FeedService {
+	return NewFeedService(postsvc.NewPostService(postRepo, userRepo), userRepo, settings)
+}
+
+func TestFeedService_Generate_SiteRSS(t *testing.T) {
+	// Setup
+	postRepo := new(MockPostRepository)
+	userRepo := new(MockUserRepository)
+	service := newTestService(postRepo, userRepo, Settings{PostURL: "https://blog.example.com/p/{slug}"})
+
+	postRepo.On("FindAll", mock.Anything, mock.Anything).Return([]*entities.Post{newer, older}, nil)
+	userRepo.On("GetUserByID", mock.Anything, "author-1").Return(alice, nil).Once()
+
+	// Execute
+	doc, err := service.Generate(context.Background(), Request{Format: FormatRSS, Origin: "http://localhost:8080", SelfURL: "http://localhost:8080/api/v1/feed/rss"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "application/rss+xml; charset=utf-8", doc.ContentType)
+	assert.True(t, strings.HasPrefix(doc.ETag, `"`) && strings.HasSuffix(doc.ETag, `"`))
+	assert.Equal(t, older.UpdatedAt, doc.LastModified)
+	body := string(doc.Body)
+	assert.Contains(t, body, "<link>https://blog.example.com/p/hello</link>")
+	assert.Contains(t, body, "<dc:creator>Alice Doe</dc:creator>")
+	assert.Contains(t, body, "<content:encoded><![CDATA[<p>First <strong>post</strong>.</p>")
+	userRepo.AssertExpectations(t)
+}
+
+func TestFeedService_Generate_ExcerptOnly(t *testing.T) {
+	// Setup
+	postRepo := new(MockPostRepository)
+	userRepo := new(MockUserRepository)
+	service := newTestService(postRepo, userRepo, Settings{Content: ContentExcerpt})
+
+	postRepo.On("Query", mock.Anything, entities.PostQuery{Tags: []string{"go"}}, mock.Anything).Return([]*entities.Post{older}, nil)
+	userRepo.On("GetUserByID", mock.Anything, "author-1").Return(alice, nil)
+
+	// Execute
+	doc, err := service.Generate(context.Background(), Request{Format: FormatJSON, Tag: "go", Origin: "http://localhost:8080"})
+
+	// Assert
+	assert.NoError(t, err)
+	body := string(doc.Body)
+	assert.Contains(t, body, `"title": "Anchor Blog – #go"`)
+	assert.Contains(t, body, `"url": "http://localhost:8080/api/v1/posts/post-1"`)
+	assert.Contains(t, body, `"content_text": "First post."`)
+	assert.NotContains(t, body, "content_html")
+}
+
+func TestFeedService_Generate_EmptyFeedKeepsETag(t *testing.T) {
+	// Setup
+	postRepo := new(MockPostRepository)
+	service := newTestService(postRepo, new(MockUserRepository), Settings{})
+
+	postRepo.On("FindAll", mock.Anything, mock.Anything).Return([]*entities.Post{}, nil)
+	req := Request{Format: FormatAtom, Origin: "http://localhost:8080", SelfURL: "http://localhost:8080/api/v1/feed/atom"}
+
+	// Execute
+	first, err := service.Generate(context.Background(), req)
+	assert.NoError(t, err)
+	time.Sleep(time.Second)
+	second, err := service.Generate(context.Background(), req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, first.ETag, second.ETag)
+	assert.True(t, second.LastModified.IsZero())
+	assert.Contains(t, string(second.Body), "<updated>1970-01-01T00:00:00Z</updated>")
+}
+
+func TestFeedService_Generate_SameContentSameETag(t *testing.T) {
+	// Setup
+	postRepo := new(MockPostRepository)
+	userRepo := new(MockUserRepository)
+	service := newTestService(postRepo, userRepo, Settings{})
+
+	postRepo.On("FindAll", mock.Anything, mock.Anything).Return([]*entities.Post{older}, nil)
+	userRepo.On("GetUserByID", mock.Anything, "author-1").Return(alice, nil)
+	req := Request{Format: FormatAtom, Origin: "http://localhost:8080"}
+
+	// Execute
+	first, err := service.Generate(context.Background(), req)
+	assert.NoError(t, err)
+	second, err := service.Generate(context.Background(), req)
+	assert.NoError(t, err)
+	req.Content = ContentExcerpt
+	excerpt, err := service.Generate(context.Background(), req)
+	assert.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, first.ETag, second.ETag)
+	assert.NotEqual(t, first.ETag, excerpt.ETag)
+}
+
+func TestFeedService_Generate_UnknownAuthor(t *testing.T) {
+	// Setup
+	userRepo := new(MockUserRepository)
+	service := newTestService(new(MockPostRepository), userRepo, Settings{})
+
+	userRepo.On("GetUserByUsername", mock.Anything, "nobody").Return(&entities.User{}, AppError.ErrNotFound)
+
+	// Execute
+	doc, err := service.Generate(context.Background(), Request{Format: FormatRSS, Author: "nobody"})
+
+	// Assert
+	assert.Equal(t, AppError.ErrUserNotFound, err)
+	assert.Nil(t, doc)
+}
+
+func TestFeedService_Generate_InvalidOptions(t *testing.T) {
+	service := newTestService(new(MockPostRepository), new(MockUserRepository), Settings{})
+
+	_, err := service.Generate(context.Background(), Request{Format: "xml"})
+	assert.Equal(t, AppError.ErrValidationFailed, err)
+
+	_, err = service.Generate(context.Background(), Request{Format: FormatRSS, Content: "summary"})
+	assert.Equal(t, AppError.ErrValidationFailed, err)
+}
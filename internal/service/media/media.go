@@ -0,0 +1,298 @@
+package mediasvc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"io"
+	"io/fs"
+	"log"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+
+	"anchor-blog/internal/domain/entities"
+	AppError "anchor-blog/internal/errors"
+	"anchor-blog/pkg/imageutil"
+	"anchor-blog/pkg/storage"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// used when no upload limit is configured
+const defaultMaxUploadMB = 10
+
+// images with more pixels are refused before they are decoded, so a small file
+// that expands to gigabytes of pixels can't exhaust the memory
+const maxPixels = 50_000_000
+
+// ThumbnailSize is a thumbnail variant: the image is scaled to fit a Box×Box square
+type ThumbnailSize struct {
+	Name string
+	Box  int
+}
+
+// ThumbnailSizes are generated for every image larger than them
+var ThumbnailSizes = []ThumbnailSize{
+	{Name: "small", Box: 160},
+	{Name: "medium", Box: 480},
+	{Name: "large", Box: 1200},
+}
+
+// accepted content types, as sniffed from the data, and the extension of their files.
+// WebP is stored as is: the standard library can't decode it, so it gets no thumbnails.
+var allowedTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// mediaKey matches the storage keys of uploads wherever they appear in post content,
+// in local API URLs as well as in object store URLs: <media id>/<variant>.<ext>
+var mediaKey = regexp.MustCompile(`\b([0-9a-f]{24})/(?:original|small|medium|large)\.(?:jpg|png|gif|webp)\b`)
+
+type MediaService struct {
+	mediaRepo entities.IMediaRepository
+	postRepo  entities.IPostRepository
+	store     storage.Storage
+	maxSize   int64
+}
+
+// NewMediaService creates the media service; maxUploadMB limits uploaded files (0 uses 10 MB)
+func NewMediaService(mediaRepo entities.IMediaRepository, postRepo entities.IPostRepository, store storage.Storage, maxUploadMB int) *MediaService {
+	if maxUploadMB <= 0 {
+		maxUploadMB = defaultMaxUploadMB
+	}
+	return &MediaService{
+		mediaRepo: mediaRepo,
+		postRepo:  postRepo,
+		store:     store,
+		maxSize:   int64(maxUploadMB) << 20,
+	}
+}
+
+// MaxUploadSize is the largest accepted file, in bytes
+func (s *MediaService) MaxUploadSize() int64 {
+	return s.maxSize
+}
+
+// Upload stores an image in the user's library along with its thumbnails.
+// The type is sniffed from the content; the file name and declared type are not trusted.
+func (s *MediaService) Upload(ctx context.Context, ownerID, filename string, r io.Reader) (*entities.Media, error) {
+	data, err := io.ReadAll(io.LimitReader(r, s.maxSize+1))
+	if err != nil {
+		return nil, AppError.ErrValidationFailed
+	}
+	if int64(len(data)) > s.maxSize {
+		return nil, AppError.ErrMediaTooLarge
+	}
+	if len(data) == 0 {
+		return nil, AppError.ErrValidationFailed
+	}
+
+	contentType := http.DetectContentType(data)
+	ext, ok := allowedTypes[contentType]
+	if !ok {
+		return nil, AppError.ErrUnsupportedMediaType
+	}
+
+	id := primitive.NewObjectID().Hex()
+	media := &entities.Media{
+		ID:          id,
+		OwnerID:     ownerID,
+		Filename:    cleanFilename(filename),
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		Key:         id + "/original" + ext,
+	}
+
+	var thumbnails []imageutil.Scaled
+	if contentType != "image/webp" {
+		config, _, err := image.DecodeConfig(bytes.NewReader(data))
+		if err != nil {
+			return nil, AppError.ErrUnsupportedMediaType
+		}
+		if config.Width*config.Height > maxPixels {
+			return nil, AppError.ErrMediaTooLarge
+		}
+		media.Width, media.Height = config.Width, config.Height
+
+		boxes := make([]int, len(ThumbnailSizes))
+		for i, size := range ThumbnailSizes {
+			boxes[i] = size.Box
+		}
+		if thumbnails, err = imageutil.Thumbnails(data, boxes); err != nil {
+			return nil, AppError.ErrUnsupportedMediaType
+		}
+	}
+
+	// files that no library entry points to are removed again
+	stored, err := s.storeFiles(ctx, media, data, thumbnails)
+	if err != nil {
+		s.deleteFiles(ctx, stored)
+		return nil, err
+	}
+	created, err := s.mediaRepo.Create(ctx, media)
+	if err != nil {
+		s.deleteFiles(ctx, stored)
+		return nil, err
+	}
+	return created, nil
+}
+
+// storeFiles writes the original and its thumbnails, adding the thumbnails to the media.
+// It returns the keys written so far, also when it fails halfway.
+func (s *MediaService) storeFiles(ctx context.Context, media *entities.Media, data []byte, thumbnails []imageutil.Scaled) ([]string, error) {
+	stored := []string{}
+	if err := s.store.Put(ctx, media.Key, data, media.ContentType); err != nil {
+		log.Printf("Error storing media file %s: %v", media.Key, err)
+		return stored, AppError.ErrInternalServer
+	}
+	stored = append(stored, media.Key)
+
+	for _, thumb := range thumbnails {
+		thumbnail := entities.Thumbnail{Name: sizeName(thumb.Box), Width: thumb.Width, Height: thumb.Height}
+		contentType, ext := "image/png", ".png"
+		if thumb.JPEG {
+			contentType, ext = "image/jpeg", ".jpg"
+		}
+		thumbnail.Key = media.ID + "/" + thumbnail.Name + ext
+
+		if err := s.store.Put(ctx, thumbnail.Key, thumb.Data, contentType); err != nil {
+			log.Printf("Error storing media file %s: %v", thumbnail.Key, err)
+			return stored, AppError.ErrInternalServer
+		}
+		stored = append(stored, thumbnail.Key)
+		media.Thumbnails = append(media.Thumbnails, thumbnail)
+	}
+	return stored, nil
+}
+
+// ListMedia lists a user's uploads, newest first
+func (s *MediaService) ListMedia(ctx context.Context, ownerID string, page, limit int64) ([]*entities.Media, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 20
+	}
+	return s.mediaRepo.FindByOwner(ctx, ownerID, entities.PaginationOptions{Page: page, Limit: limit})
+}
+
+// GetMedia returns one upload of the user's library
+func (s *MediaService) GetMedia(ctx context.Context, id, ownerID string) (*entities.Media, error) {
+	media, err := s.mediaRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if media.OwnerID != ownerID {
+		return nil, AppError.ErrForbidden
+	}
+	return media, nil
+}
+
+// DeleteMedia removes an upload and its files. Media still used by a post can't be deleted.
+func (s *MediaService) DeleteMedia(ctx context.Context, id, ownerID string) error {
+	media, err := s.GetMedia(ctx, id, ownerID)
+	if err != nil {
+		return err
+	}
+	if err := s.mediaRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	keys := []string{media.Key}
+	for _, thumbnail := range media.Thumbnails {
+		keys = append(keys, thumbnail.Key)
+	}
+	s.deleteFiles(ctx, keys)
+	return nil
+}
+
+// Open reads a stored file, for backends whose files are served by the API
+func (s *MediaService) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	if !storage.ValidKey(key) {
+		return nil, AppError.ErrNotFound
+	}
+	file, err := s.store.Open(ctx, key)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, AppError.ErrNotFound
+		}
+		log.Printf("Error reading media file %s: %v", key, err)
+		return nil, AppError.ErrInternalServer
+	}
+	return file, nil
+}
+
+// URL is where clients download a stored file
+func (s *MediaService) URL(key string) string {
+	return s.store.URL(key)
+}
+
+// PostChanged records which media the post's content uses; it implements postsvc.ChangeListener.
+// Posts in the trash keep their references so restoring them doesn't break their images;
+// they are dropped by DeletePostReferences once the post is deleted for good.
+func (s *MediaService) PostChanged(postID string) {
+	ctx := context.Background()
+	post, err := s.postRepo.FindByID(ctx, postID)
+	if err != nil {
+		if err != AppError.ErrNotFound {
+			log.Printf("Error reading post %s to track its media: %v", postID, err)
+		}
+		return
+	}
+
+	if err := s.mediaRepo.SetPostReferences(ctx, postID, ReferencedMedia(post.Content)); err != nil {
+		log.Printf("Error tracking media of post %s: %v", postID, err)
+	}
+}
+
+// DeletePostReferences forgets the media references of a post deleted for good
+func (s *MediaService) DeletePostReferences(ctx context.Context, postID string) error {
+	return s.mediaRepo.RemovePostReferences(ctx, postID)
+}
+
+// ReferencedMedia returns the ids of the uploads linked from Markdown content, in order of appearance
+func ReferencedMedia(content string) []string {
+	ids := []string{}
+	seen := map[string]bool{}
+	for _, match := range mediaKey.FindAllStringSubmatch(content, -1) {
+		if id := match[1]; !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// deleteFiles removes stored files; failures are logged, the files are only orphaned
+func (s *MediaService) deleteFiles(ctx context.Context, keys []string) {
+	for _, key := range keys {
+		if err := s.store.Delete(ctx, key); err != nil {
+			log.Printf("Error deleting media file %s: %v", key, err)
+		}
+	}
+}
+
+// sizeName names the thumbnail variant of a box size
+func sizeName(box int) string {
+	for _, size := range ThumbnailSizes {
+		if size.Box == box {
+			return size.Name
+		}
+	}
+	return ""
+}
+
+// cleanFilename keeps the base name of an uploaded file, without any client-side path
+func cleanFilename(filename string) string {
+	filename = path.Base(strings.ReplaceAll(filename, "\\", "/"))
+	if filename == "." || filename == "/" {
+		return ""
+	}
+	return filename
+}
@@ -0,0 +1,234 @@
+package mediasvc
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"io"
+	"io/fs"
+	"strings"
+	"testing"
+
+	"anchor-blog/internal/domain/entities"
+	AppError "anchor-blog/internal/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockMediaRepository struct {
+	mock.Mock
+}
+
+func (m *MockMediaRepository) Create(ctx context.Context, media *entities.Media) (*entities.Media, error) {
+	args := m.Called(ctx, media)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	if fn, ok := args.Get(0).(func(*entities.Media) *entities.Media); ok {
+		return fn(media), args.Error(1)
+	}
+	return args.Get(0).(*entities.Media), args.Error(1)
+}
+
+func (m *MockMediaRepository) FindByID(ctx context.Context, id string) (*entities.Media, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Media), args.Error(1)
+}
+
+func (m *MockMediaRepository) FindByOwner(ctx context.Context, ownerID string, opts entities.PaginationOptions) ([]*entities.Media, error) {
+	args := m.Called(ctx, ownerID, opts)
+	return args.Get(0).([]*entities.Media), args.Error(1)
+}
+
+func (m *MockMediaRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockMediaRepository) SetPostReferences(ctx context.Context, postID string, mediaIDs []string) error {
+	args := m.Called(ctx, postID, mediaIDs)
+	return args.Error(0)
+}
+
+func (m *MockMediaRepository) RemovePostReferences(ctx context.Context, postID string) error {
+	args := m.Called(ctx, postID)
+	return args.Error(0)
+}
+
+// Mock post repository; only the lookup is used by the media service
+type MockPostRepository struct {
+	entities.IPostRepository
+	mock.Mock
+}
+
+func (m *MockPostRepository) FindByID(ctx context.Context, id string) (*entities.Post, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Post), args.Error(1)
+}
+
+// memoryStorage keeps files in a map
+type memoryStorage struct {
+	files map[string][]byte
+	types map[string]string
+}
+
+func newMemoryStorage() *memoryStorage {
+	return &memoryStorage{files: map[string][]byte{}, types: map[string]string{}}
+}
+
+func (s *memoryStorage) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	s.files[key] = data
+	s.types[key] = contentType
+	return nil
+}
+
+func (s *memoryStorage) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	data, ok := s.files[key]
+	if !ok {
+		return nil, fs.ErrNotExist
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *memoryStorage) Delete(ctx context.Context, key string) error {
+	delete(s.files, key)
+	return nil
+}
+
+func (s *memoryStorage) URL(key string) string {
+	return "https://cdn.example.com/" + key
+}
+
+func pngImage(t *testing.T, width, height int) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height))))
+	return buf.Bytes()
+}
+
+func TestMediaService_Upload_StoresThumbnails(t *testing.T) {
+	// Setup
+	mediaRepo := new(MockMediaRepository)
+	store := newMemoryStorage()
+	service := NewMediaService(mediaRepo, nil, store, 0)
+
+	mediaRepo.On("Create", mock.Anything, mock.Anything).Return(func(media *entities.Media) *entities.Media {
+		return media
+	}, nil)
+
+	// Execute: the name claims a text file, the content is a PNG
+	media, err := service.Upload(context.Background(), "user-1", `C:\photos\notes.txt`, bytes.NewReader(pngImage(t, 1600, 800)))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", media.ContentType)
+	assert.Equal(t, "notes.txt", media.Filename)
+	assert.Equal(t, 1600, media.Width)
+	assert.Equal(t, media.ID+"/original.png", media.Key)
+	require.Len(t, media.Thumbnails, 3)
+	assert.Equal(t, entities.Thumbnail{Name: "small", Key: media.ID + "/small.png", Width: 160, Height: 80}, media.Thumbnails[0])
+	assert.Equal(t, 1200, media.Thumbnails[2].Width)
+	assert.Len(t, store.files, 4)
+	assert.Equal(t, "image/png", store.types[media.Thumbnails[1].Key])
+}
+
+func TestMediaService_Upload_RejectsBadFiles(t *testing.T) {
+	// Setup
+	mediaRepo := new(MockMediaRepository)
+	store := newMemoryStorage()
+	service := NewMediaService(mediaRepo, nil, store, 1)
+
+	// Execute
+	_, textErr := service.Upload(context.Background(), "user-1", "image.png", strings.NewReader("just some text"))
+	_, svgErr := service.Upload(context.Background(), "user-1", "image.svg", strings.NewReader(`<svg xmlns="http://www.w3.org/2000/svg"><script>alert(1)</script></svg>`))
+	_, bigErr := service.Upload(context.Background(), "user-1", "big.png", bytes.NewReader(make([]byte, 1<<20+1)))
+	_, emptyErr := service.Upload(context.Background(), "user-1", "empty.png", strings.NewReader(""))
+
+	// Assert
+	assert.Equal(t, AppError.ErrUnsupportedMediaType, textErr)
+	assert.Equal(t, AppError.ErrUnsupportedMediaType, svgErr)
+	assert.Equal(t, AppError.ErrMediaTooLarge, bigErr)
+	assert.Equal(t, AppError.ErrValidationFailed, emptyErr)
+	assert.Empty(t, store.files)
+	mediaRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestMediaService_Upload_CleansUpWhenSavingFails(t *testing.T) {
+	// Setup
+	mediaRepo := new(MockMediaRepository)
+	store := newMemoryStorage()
+	service := NewMediaService(mediaRepo, nil, store, 0)
+
+	mediaRepo.On("Create", mock.Anything, mock.Anything).Return(nil, AppError.ErrInternalServer)
+
+	// Execute
+	_, err := service.Upload(context.Background(), "user-1", "photo.png", bytes.NewReader(pngImage(t, 400, 300)))
+
+	// Assert
+	assert.Equal(t, AppError.ErrInternalServer, err)
+	assert.Empty(t, store.files)
+}
+
+func TestMediaService_DeleteMedia(t *testing.T) {
+	// Setup
+	mediaRepo := new(MockMediaRepository)
+	store := newMemoryStorage()
+	service := NewMediaService(mediaRepo, nil, store, 0)
+
+	store.files["m1/original.png"] = []byte("png")
+	store.files["m1/small.png"] = []byte("png")
+	store.files["m2/original.png"] = []byte("png")
+	mediaRepo.On("FindByID", mock.Anything, "m1").Return(&entities.Media{ID: "m1", OwnerID: "user-1", Key: "m1/original.png", Thumbnails: []entities.Thumbnail{{Name: "small", Key: "m1/small.png"}}}, nil)
+	mediaRepo.On("FindByID", mock.Anything, "m2").Return(&entities.Media{ID: "m2", OwnerID: "user-1", Key: "m2/original.png", PostIDs: []string{"post-1"}}, nil)
+	mediaRepo.On("Delete", mock.Anything, "m1").Return(nil)
+	mediaRepo.On("Delete", mock.Anything, "m2").Return(AppError.ErrMediaInUse)
+
+	// Execute
+	forbiddenErr := service.DeleteMedia(context.Background(), "m1", "user-2")
+	inUseErr := service.DeleteMedia(context.Background(), "m2", "user-1")
+	err := service.DeleteMedia(context.Background(), "m1", "user-1")
+
+	// Assert
+	assert.Equal(t, AppError.ErrForbidden, forbiddenErr)
+	assert.Equal(t, AppError.ErrMediaInUse, inUseErr)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"m2/original.png": []byte("png")}, store.files)
+	mediaRepo.AssertNumberOfCalls(t, "Delete", 2)
+}
+
+func TestReferencedMedia(t *testing.T) {
+	content := "![cover](/api/v1/media/files/65a1b2c3d4e5f60718293a4b/original.jpg)\n\n" +
+		"![thumb](https://cdn.example.com/blog/65a1b2c3d4e5f60718293a4b/small.jpg) " +
+		"![other](https://cdn.example.com/blog/65a1b2c3d4e5f60718293a4c/large.png)\n" +
+		"not ours: https://example.com/65a1b2c3d4e5f60718293a4d/photo.jpg"
+
+	assert.Equal(t, []string{"65a1b2c3d4e5f60718293a4b", "65a1b2c3d4e5f60718293a4c"}, ReferencedMedia(content))
+	assert.Empty(t, ReferencedMedia("no images here"))
+}
+
+func TestMediaService_PostChanged_TracksReferences(t *testing.T) {
+	// Setup
+	mediaRepo := new(MockMediaRepository)
+	postRepo := new(MockPostRepository)
+	service := NewMediaService(mediaRepo, postRepo, newMemoryStorage(), 0)
+
+	postRepo.On("FindByID", mock.Anything, "post-1").Return(&entities.Post{ID: "post-1", Content: "![a](/api/v1/media/files/65a1b2c3d4e5f60718293a4b/medium.jpg)"}, nil)
+	postRepo.On("FindByID", mock.Anything, "post-2").Return(nil, AppError.ErrNotFound)
+	mediaRepo.On("SetPostReferences", mock.Anything, "post-1", []string{"65a1b2c3d4e5f60718293a4b"}).Return(nil)
+
+	// Execute
+	service.PostChanged("post-1")
+	service.PostChanged("post-2")
+
+	// Assert: a post in the trash keeps its references
+	mediaRepo.AssertExpectations(t)
+	mediaRepo.AssertNumberOfCalls(t, "SetPostReferences", 1)
+}
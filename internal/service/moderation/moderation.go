@@ -0,0 +1,200 @@
+package moderationsvc
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"anchor-blog/internal/domain/entities"
+	AppError "anchor-blog/internal/errors"
+	postsvc "anchor-blog/internal/service/post"
+)
+
+const (
+	// DefaultAutoHideThreshold is the number of open reports that hides a post until a moderator looks at it
+	DefaultAutoHideThreshold = 5
+	maxDetailsLength         = 1000
+	maxNoteLength            = 1000
+)
+
+var reasons = map[string]bool{
+	entities.ReportReasonSpam:       true,
+	entities.ReportReasonHarassment: true,
+	entities.ReportReasonHate:       true,
+	entities.ReportReasonIllegal:    true,
+	entities.ReportReasonOther:      true,
+}
+
+type ModerationService struct {
+	reportRepo        entities.IReportRepository
+	actionRepo        entities.IModerationActionRepository
+	postService       *postsvc.PostService
+	autoHideThreshold int64
+}
+
+// NewModerationService creates a new moderation service.
+// A threshold of 0 uses DefaultAutoHideThreshold, a negative one turns auto-hiding off.
+// Posts are hidden and deleted through the post service so its listeners and cascades run.
+func NewModerationService(reportRepo entities.IReportRepository, actionRepo entities.IModerationActionRepository, postService *postsvc.PostService, autoHideThreshold int) *ModerationService {
+	if autoHideThreshold == 0 {
+		autoHideThreshold = DefaultAutoHideThreshold
+	}
+	return &ModerationService{
+		reportRepo:        reportRepo,
+		actionRepo:        actionRepo,
+		postService:       postService,
+		autoHideThreshold: int64(autoHideThreshold),
+	}
+}
+
+// ReportPost files a report on a published post. Once the post collects enough open reports
+// it is hidden until a moderator dismisses them.
+func (s *ModerationService) ReportPost(ctx context.Context, postID, reporterID, reason, details string) (*entities.Report, error) {
+	reason = strings.ToLower(strings.TrimSpace(reason))
+	details = strings.TrimSpace(details)
+	if !reasons[reason] || utf8.RuneCountInString(details) > maxDetailsLength {
+		return nil, AppError.ErrValidationFailed
+	}
+	if reason == entities.ReportReasonOther && details == "" {
+		return nil, AppError.ErrValidationFailed
+	}
+
+	post, err := s.postService.GetPostByID(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if !post.IsPublished() {
+		return nil, AppError.ErrNotFound
+	}
+
+	report, err := s.reportRepo.Create(ctx, &entities.Report{
+		PostID:     postID,
+		ReporterID: reporterID,
+		Reason:     reason,
+		Details:    details,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if s.autoHideThreshold > 0 {
+		s.autoHide(ctx, post)
+	}
+	return report, nil
+}
+
+// autoHide hides the post once its open reports reach the threshold. The report itself
+// is already stored, so failures are only logged.
+func (s *ModerationService) autoHide(ctx context.Context, post *entities.Post) {
+	count, err := s.reportRepo.CountOpenByPost(ctx, post.ID)
+	if err != nil || count < s.autoHideThreshold {
+		return
+	}
+
+	if _, err := s.postService.SetHidden(ctx, post.ID, true); err != nil {
+		log.Printf("Error hiding reported post %s: %v", post.ID, err)
+		return
+	}
+	if _, err := s.actionRepo.Create(ctx, &entities.ModerationAction{
+		PostID:   post.ID,
+		AuthorID: post.AuthorID,
+		Action:   entities.ModerationAutoHide,
+	}); err != nil {
+		log.Printf("Error recording auto-hide of post %s: %v", post.ID, err)
+	}
+}
+
+// ListReports lists reports oldest first, optionally narrowed to one status
+func (s *ModerationService) ListReports(ctx context.Context, status string, page, limit int64) ([]*entities.Report, error) {
+	switch status {
+	case "", entities.ReportStatusOpen, entities.ReportStatusDismissed, entities.ReportStatusActioned:
+	default:
+		return nil, AppError.ErrValidationFailed
+	}
+	return s.reportRepo.FindAll(ctx, status, paginate(page, limit))
+}
+
+// GetReport returns a single report
+func (s *ModerationService) GetReport(ctx context.Context, id string) (*entities.Report, error) {
+	return s.reportRepo.FindByID(ctx, id)
+}
+
+// Resolve takes a moderation action on the post of an open report and closes every open
+// report of that post:
+//   - dismiss: the reports were unfounded; a hidden post is shown again
+//   - hide: the post is hidden from the public but kept for its authors
+//   - delete: the post is deleted for good with its comments, revisions and other data;
+//     unlike a delete by its owner it doesn't go to the trash
+//   - warn: the author is warned; the post stays as it is
+//
+// The action is recorded in the moderation log.
+func (s *ModerationService) Resolve(ctx context.Context, reportID, moderatorID, action, note string) (*entities.ModerationAction, error) {
+	note = strings.TrimSpace(note)
+	if utf8.RuneCountInString(note) > maxNoteLength {
+		return nil, AppError.ErrValidationFailed
+	}
+
+	report, err := s.reportRepo.FindByID(ctx, reportID)
+	if err != nil {
+		return nil, err
+	}
+	if report.Status != entities.ReportStatusOpen {
+		return nil, AppError.ErrReportClosed
+	}
+
+	post, err := s.postService.GetPostByID(ctx, report.PostID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := entities.ReportStatusActioned
+	switch action {
+	case entities.ModerationDismiss:
+		status = entities.ReportStatusDismissed
+		if post.Hidden {
+			_, err = s.postService.SetHidden(ctx, post.ID, false)
+		}
+	case entities.ModerationHide:
+		if !post.Hidden {
+			_, err = s.postService.SetHidden(ctx, post.ID, true)
+		}
+	case entities.ModerationDelete:
+		err = s.postService.DeletePermanently(ctx, post.ID)
+	case entities.ModerationWarn:
+	default:
+		return nil, AppError.ErrValidationFailed
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.reportRepo.ResolveByPost(ctx, post.ID, status, moderatorID, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return s.actionRepo.Create(ctx, &entities.ModerationAction{
+		ReportID:    report.ID,
+		PostID:      post.ID,
+		AuthorID:    post.AuthorID,
+		ModeratorID: moderatorID,
+		Action:      action,
+		Note:        note,
+	})
+}
+
+// ListActions lists the moderation log newest first, optionally narrowed to a post or an author
+func (s *ModerationService) ListActions(ctx context.Context, postID, authorID string, page, limit int64) ([]*entities.ModerationAction, error) {
+	return s.actionRepo.FindAll(ctx, postID, authorID, paginate(page, limit))
+}
+
+func paginate(page, limit int64) entities.PaginationOptions {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 20
+	}
+	return entities.PaginationOptions{Page: page, Limit: limit}
+}
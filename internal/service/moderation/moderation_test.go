@@ -0,0 +1,235 @@
+package moderationsvc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"anchor-blog/internal/domain/entities"
+	AppError "anchor-blog/internal/errors"
+	postsvc "anchor-blog/internal/service/post"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockReportRepository struct {
+	mock.Mock
+}
+
+func (m *MockReportRepository) Create(ctx context.Context, report *entities.Report) (*entities.Report, error) {
+	args := m.Called(ctx, report)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Report), args.Error(1)
+}
+
+func (m *MockReportRepository) FindByID(ctx context.Context, id string) (*entities.Report, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Report), args.Error(1)
+}
+
+func (m *MockReportRepository) FindAll(ctx context.Context, status string, opts entities.PaginationOptions) ([]*entities.Report, error) {
+	args := m.Called(ctx, status, opts)
+	return args.Get(0).([]*entities.Report), args.Error(1)
+}
+
+func (m *MockReportRepository) CountOpenByPost(ctx context.Context, postID string) (int64, error) {
+	args := m.Called(ctx, postID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockReportRepository) ResolveByPost(ctx context.Context, postID, status, moderatorID string, at time.Time) (int64, error) {
+	args := m.Called(ctx, postID, status, moderatorID, at)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+type MockModerationActionRepository struct {
+	mock.Mock
+}
+
+func (m *MockModerationActionRepository) Create(ctx context.Context, action *entities.ModerationAction) (*entities.ModerationAction, error) {
+	args := m.Called(ctx, action)
+	return action, args.Error(0)
+}
+
+func (m *MockModerationActionRepository) FindAll(ctx context.Context, postID, authorID string, opts entities.PaginationOptions) ([]*entities.ModerationAction, error) {
+	args := m.Called(ctx, postID, authorID, opts)
+	return args.Get(0).([]*entities.ModerationAction), args.Error(1)
+}
+
+// Mock post repository; only the lookup, the moderation flag and deletes are used through the post service
+type MockPostRepository struct {
+	entities.IPostRepository
+	mock.Mock
+}
+
+func (m *MockPostRepository) FindByID(ctx context.Context, id string) (*entities.Post, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Post), args.Error(1)
+}
+
+func (m *MockPostRepository) SetHidden(ctx context.Context, id string, hidden bool) (*entities.Post, error) {
+	args := m.Called(ctx, id, hidden)
+	return &entities.Post{ID: id, Hidden: hidden}, args.Error(0)
+}
+
+func (m *MockPostRepository) DeletePermanently(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func newTestService(threshold int) (*ModerationService, *MockReportRepository, *MockModerationActionRepository, *MockPostRepository) {
+	reportRepo := new(MockReportRepository)
+	actionRepo := new(MockModerationActionRepository)
+	postRepo := new(MockPostRepository)
+	service := NewModerationService(reportRepo, actionRepo, postsvc.NewPostService(postRepo, nil), threshold)
+	return service, reportRepo, actionRepo, postRepo
+}
+
+func TestModerationService_ReportPost_Validation(t *testing.T) {
+	service, _, _, _ := newTestService(0)
+
+	_, badReason := service.ReportPost(context.Background(), "post-1", "user-1", "boring", "")
+	_, missingDetails := service.ReportPost(context.Background(), "post-1", "user-1", "other", "  ")
+
+	assert.Equal(t, AppError.ErrValidationFailed, badReason)
+	assert.Equal(t, AppError.ErrValidationFailed, missingDetails)
+}
+
+func TestModerationService_ReportPost_OnlyVisiblePosts(t *testing.T) {
+	// Setup
+	service, reportRepo, _, postRepo := newTestService(0)
+	postRepo.On("FindByID", mock.Anything, "post-1").Return(&entities.Post{ID: "post-1", Status: entities.PostStatusDraft}, nil)
+	postRepo.On("FindByID", mock.Anything, "post-2").Return(&entities.Post{ID: "post-2", Hidden: true}, nil)
+
+	// Execute
+	_, draftErr := service.ReportPost(context.Background(), "post-1", "user-1", "spam", "")
+	_, hiddenErr := service.ReportPost(context.Background(), "post-2", "user-1", "spam", "")
+
+	// Assert
+	assert.Equal(t, AppError.ErrNotFound, draftErr)
+	assert.Equal(t, AppError.ErrNotFound, hiddenErr)
+	reportRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestModerationService_ReportPost_AutoHidesAtThreshold(t *testing.T) {
+	// Setup
+	service, reportRepo, actionRepo, postRepo := newTestService(2)
+	post := &entities.Post{ID: "post-1", AuthorID: "author-1", Status: entities.PostStatusPublished}
+	postRepo.On("FindByID", mock.Anything, "post-1").Return(post, nil)
+	reportRepo.On("Create", mock.Anything, &entities.Report{PostID: "post-1", ReporterID: "user-1", Reason: "spam"}).
+		Return(&entities.Report{ID: "report-1", Status: entities.ReportStatusOpen}, nil)
+	reportRepo.On("CountOpenByPost", mock.Anything, "post-1").Return(int64(2), nil)
+	postRepo.On("SetHidden", mock.Anything, "post-1", true).Return(nil)
+	actionRepo.On("Create", mock.Anything, &entities.ModerationAction{PostID: "post-1", AuthorID: "author-1", Action: entities.ModerationAutoHide}).Return(nil)
+
+	// Execute
+	report, err := service.ReportPost(context.Background(), "post-1", "user-1", " Spam ", "")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "report-1", report.ID)
+	reportRepo.AssertExpectations(t)
+	postRepo.AssertExpectations(t)
+	actionRepo.AssertExpectations(t)
+}
+
+func TestModerationService_ReportPost_BelowThresholdOrDisabled(t *testing.T) {
+	for _, threshold := range []int{3, -1} {
+		// Setup
+		service, reportRepo, _, postRepo := newTestService(threshold)
+		postRepo.On("FindByID", mock.Anything, "post-1").Return(&entities.Post{ID: "post-1"}, nil)
+		reportRepo.On("Create", mock.Anything, mock.Anything).Return(&entities.Report{ID: "report-1"}, nil)
+		reportRepo.On("CountOpenByPost", mock.Anything, "post-1").Return(int64(2), nil)
+
+		// Execute
+		_, err := service.ReportPost(context.Background(), "post-1", "user-1", "hate", "")
+
+		// Assert
+		assert.NoError(t, err)
+		postRepo.AssertNotCalled(t, "SetHidden", mock.Anything, mock.Anything, mock.Anything)
+	}
+}
+
+func TestModerationService_Resolve_DismissShowsPostAgain(t *testing.T) {
+	// Setup
+	service, reportRepo, actionRepo, postRepo := newTestService(0)
+	reportRepo.On("FindByID", mock.Anything, "report-1").Return(&entities.Report{ID: "report-1", PostID: "post-1", Status: entities.ReportStatusOpen}, nil)
+	postRepo.On("FindByID", mock.Anything, "post-1").Return(&entities.Post{ID: "post-1", AuthorID: "author-1", Hidden: true}, nil)
+	postRepo.On("SetHidden", mock.Anything, "post-1", false).Return(nil)
+	reportRepo.On("ResolveByPost", mock.Anything, "post-1", entities.ReportStatusDismissed, "admin-1", mock.Anything).Return(int64(3), nil)
+	actionRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	// Execute
+	action, err := service.Resolve(context.Background(), "report-1", "admin-1", entities.ModerationDismiss, " false alarm ")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, &entities.ModerationAction{
+		ReportID:    "report-1",
+		PostID:      "post-1",
+		AuthorID:    "author-1",
+		ModeratorID: "admin-1",
+		Action:      entities.ModerationDismiss,
+		Note:        "false alarm",
+	}, action)
+	reportRepo.AssertExpectations(t)
+	postRepo.AssertExpectations(t)
+}
+
+func TestModerationService_Resolve_DeleteAndWarn(t *testing.T) {
+	// Setup
+	service, reportRepo, actionRepo, postRepo := newTestService(0)
+	reportRepo.On("FindByID", mock.Anything, "report-1").Return(&entities.Report{ID: "report-1", PostID: "post-1", Status: entities.ReportStatusOpen}, nil)
+	reportRepo.On("FindByID", mock.Anything, "report-2").Return(&entities.Report{ID: "report-2", PostID: "post-2", Status: entities.ReportStatusOpen}, nil)
+	postRepo.On("FindByID", mock.Anything, "post-1").Return(&entities.Post{ID: "post-1", AuthorID: "author-1"}, nil)
+	postRepo.On("FindByID", mock.Anything, "post-2").Return(&entities.Post{ID: "post-2", AuthorID: "author-2"}, nil)
+	postRepo.On("DeletePermanently", mock.Anything, "post-1").Return(nil)
+	reportRepo.On("ResolveByPost", mock.Anything, mock.Anything, entities.ReportStatusActioned, "admin-1", mock.Anything).Return(int64(1), nil)
+	actionRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	// Execute
+	deleted, deleteErr := service.Resolve(context.Background(), "report-1", "admin-1", entities.ModerationDelete, "")
+	warned, warnErr := service.Resolve(context.Background(), "report-2", "admin-1", entities.ModerationWarn, "first warning")
+
+	// Assert
+	assert.NoError(t, deleteErr)
+	assert.NoError(t, warnErr)
+	assert.Equal(t, entities.ModerationDelete, deleted.Action)
+	assert.Equal(t, "author-2", warned.AuthorID)
+	postRepo.AssertNumberOfCalls(t, "DeletePermanently", 1)
+	postRepo.AssertNotCalled(t, "SetHidden", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestModerationService_Resolve_Rejections(t *testing.T) {
+	// Setup
+	service, reportRepo, _, postRepo := newTestService(0)
+	reportRepo.On("FindByID", mock.Anything, "report-1").Return(&entities.Report{ID: "report-1", PostID: "post-1", Status: entities.ReportStatusDismissed}, nil)
+	reportRepo.On("FindByID", mock.Anything, "report-2").Return(&entities.Report{ID: "report-2", PostID: "post-2", Status: entities.ReportStatusOpen}, nil)
+	postRepo.On("FindByID", mock.Anything, "post-2").Return(&entities.Post{ID: "post-2"}, nil)
+
+	// Execute
+	_, closedErr := service.Resolve(context.Background(), "report-1", "admin-1", entities.ModerationHide, "")
+	_, unknownErr := service.Resolve(context.Background(), "report-2", "admin-1", "ban", "")
+
+	// Assert
+	assert.Equal(t, AppError.ErrReportClosed, closedErr)
+	assert.Equal(t, AppError.ErrValidationFailed, unknownErr)
+	reportRepo.AssertNotCalled(t, "ResolveByPost", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestModerationService_ListReports_InvalidStatus(t *testing.T) {
+	service, _, _, _ := newTestService(0)
+
+	_, err := service.ListReports(context.Background(), "closed", 1, 20)
+
+	assert.Equal(t, AppError.ErrValidationFailed, err)
+}
@@ -0,0 +1,145 @@
+package postsvc
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"anchor-blog/internal/domain/entities"
+	AppError "anchor-blog/internal/errors"
+)
+
+// MaxPageSize is the most posts a listing returns per page
+const MaxPageSize = 100
+
+// PageRequest selects a page of a listing, either by number or by a cursor
+// returned with a previous page. The cursor wins when both are given.
+type PageRequest struct {
+	Page   int64
+	Limit  int64
+	Cursor string
+}
+
+// PostPage is one page of a listing along with the cursors of its neighbours.
+// A cursor is empty when there is nothing in that direction.
+type PostPage struct {
+	Posts      []*entities.Post
+	NextCursor string
+	PrevCursor string
+}
+
+// cursorPayload is the JSON behind an opaque cursor
+type cursorPayload struct {
+	Sort      string    `json:"s"`
+	ID        string    `json:"id"`
+	CreatedAt time.Time `json:"c"`
+	ViewCount int       `json:"v,omitempty"`
+	LikeCount int       `json:"l,omitempty"`
+	Backward  bool      `json:"b,omitempty"`
+}
+
+// sortSignature identifies the ordering a cursor belongs to
+func sortSignature(keys []string) string {
+	if len(keys) == 0 {
+		return entities.SortNewest
+	}
+	return strings.Join(keys, ",")
+}
+
+// encodeCursor builds the opaque cursor pointing at post
+func encodeCursor(post *entities.Post, sort string, backward bool) string {
+	raw, _ := json.Marshal(cursorPayload{
+		Sort:      sort,
+		ID:        post.ID,
+		CreatedAt: post.CreatedAt,
+		ViewCount: post.ViewCount,
+		LikeCount: post.ReactionCount(entities.ReactionLike),
+		Backward:  backward,
+	})
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// decodeCursor parses a cursor and checks it was issued for the same ordering
+func decodeCursor(cursor, sort string) (*entities.PageCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, AppError.ErrInvalidCursor
+	}
+	var payload cursorPayload
+	if err := json.Unmarshal(raw, &payload); err != nil || payload.ID == "" {
+		return nil, AppError.ErrInvalidCursor
+	}
+	if payload.Sort != sort {
+		return nil, AppError.ErrInvalidCursor
+	}
+
+	return &entities.PageCursor{
+		Sort:      payload.Sort,
+		ID:        payload.ID,
+		CreatedAt: payload.CreatedAt,
+		ViewCount: payload.ViewCount,
+		LikeCount: payload.LikeCount,
+		Backward:  payload.Backward,
+	}, nil
+}
+
+// paginationOptions applies defaults and resolves the cursor for the given ordering
+func (p PageRequest) paginationOptions(sort string) (entities.PaginationOptions, error) {
+	opts := entities.PaginationOptions{
+		Page:  p.Page,
+		Limit: p.Limit,
+	}
+	if opts.Page <= 0 {
+		opts.Page = 1
+	}
+	if opts.Limit <= 0 {
+		opts.Limit = 10 // Default limit
+	}
+	opts.Limit = min(opts.Limit, MaxPageSize)
+
+	if p.Cursor != "" {
+		cursor, err := decodeCursor(p.Cursor, sort)
+		if err != nil {
+			return entities.PaginationOptions{}, err
+		}
+		opts.Page = 1
+		opts.Cursor = cursor
+	}
+	return opts, nil
+}
+
+// FirstPage wraps the first page of a listing read from elsewhere in the given ordering,
+// so that it can be continued by cursor
+func FirstPage(posts []*entities.Post, sort []string, limit int64) *PostPage {
+	opts := entities.PaginationOptions{Page: 1, Limit: limit}
+	return newPostPage(posts, opts, sortSignature(sort))
+}
+
+// newPostPage wraps listing results with the cursors of the neighbouring pages.
+// A full page may have a next one; a page reached by cursor or page number always has a previous one.
+func newPostPage(posts []*entities.Post, opts entities.PaginationOptions, sort string) *PostPage {
+	page := &PostPage{Posts: posts}
+	if len(posts) == 0 {
+		return page
+	}
+
+	first, last := posts[0], posts[len(posts)-1]
+	full := int64(len(posts)) >= opts.Limit
+
+	if opts.Cursor != nil && opts.Cursor.Backward {
+		page.NextCursor = encodeCursor(last, sort, false)
+		if full {
+			page.PrevCursor = encodeCursor(first, sort, true)
+		}
+		return page
+	}
+
+	if full {
+		page.NextCursor = encodeCursor(last, sort, false)
+	}
+	if opts.Cursor != nil || opts.Page > 1 {
+		page.PrevCursor = encodeCursor(first, sort, true)
+	}
+	return page
+}
@@ -0,0 +1,134 @@
+package postsvc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"anchor-blog/internal/domain/entities"
+	AppError "anchor-blog/internal/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCursor_RoundTrip(t *testing.T) {
+	// Test data
+	post := &entities.Post{
+		ID:        "507f1f77bcf86cd799439012",
+		CreatedAt: time.Date(2025, 8, 7, 10, 30, 0, 123000000, time.UTC),
+		ViewCount: 42,
+		Reactions: map[string]int{entities.ReactionLike: 2},
+	}
+
+	// Execute
+	cursor, err := decodeCursor(encodeCursor(post, "views,newest", true), "views,newest")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, post.ID, cursor.ID)
+	assert.True(t, post.CreatedAt.Equal(cursor.CreatedAt))
+	assert.Equal(t, 42, cursor.ViewCount)
+	assert.Equal(t, 2, cursor.LikeCount)
+	assert.True(t, cursor.Backward)
+}
+
+func TestCursor_RejectsOtherSortAndGarbage(t *testing.T) {
+	post := &entities.Post{ID: "507f1f77bcf86cd799439012", CreatedAt: time.Now()}
+
+	_, err := decodeCursor(encodeCursor(post, "newest", false), "likes")
+	assert.ErrorIs(t, err, AppError.ErrInvalidCursor)
+
+	_, err = decodeCursor("not a cursor!", "newest")
+	assert.ErrorIs(t, err, AppError.ErrInvalidCursor)
+}
+
+func TestPageRequest_CapsLimit(t *testing.T) {
+	tests := []struct {
+		limit int64
+		want  int64
+	}{
+		{0, 10},
+		{-5, 10},
+		{25, 25},
+		{MaxPageSize, MaxPageSize},
+		{10000, MaxPageSize},
+	}
+
+	for _, tt := range tests {
+		opts, err := PageRequest{Limit: tt.limit}.paginationOptions(entities.SortNewest)
+
+		assert.NoError(t, err)
+		assert.Equal(t, tt.want, opts.Limit, "limit %d", tt.limit)
+	}
+}
+
+func TestFirstPage_ContinuesInSortOrder(t *testing.T) {
+	posts := []*entities.Post{
+		{ID: "507f1f77bcf86cd799439012", CreatedAt: time.Now(), ViewCount: 9},
+		{ID: "507f1f77bcf86cd799439013", CreatedAt: time.Now(), ViewCount: 4},
+	}
+
+	page := FirstPage(posts, []string{entities.SortViews}, 2)
+	short := FirstPage(posts, []string{entities.SortViews}, 3)
+
+	assert.Empty(t, page.PrevCursor)
+	cursor, err := decodeCursor(page.NextCursor, entities.SortViews)
+	assert.NoError(t, err)
+	assert.Equal(t, "507f1f77bcf86cd799439013", cursor.ID)
+	assert.Equal(t, 4, cursor.ViewCount)
+	assert.Empty(t, short.NextCursor, "a short page is the last one")
+}
+
+func TestPostService_ListPosts_FollowsCursor(t *testing.T) {
+	// Setup
+	mockRepo := new(MockPostRepository)
+	service := NewPostService(mockRepo, nil)
+
+	boundary := &entities.Post{ID: "507f1f77bcf86cd799439012", CreatedAt: time.Date(2025, 8, 7, 10, 30, 0, 0, time.UTC)}
+	expectedPosts := []*entities.Post{
+		{ID: "507f1f77bcf86cd799439013", CreatedAt: boundary.CreatedAt.Add(-time.Hour)},
+		{ID: "507f1f77bcf86cd799439014", CreatedAt: boundary.CreatedAt.Add(-2 * time.Hour)},
+	}
+
+	// Mock expectations - the page number is ignored once a cursor is given
+	mockRepo.On("FindAll", mock.Anything, mock.MatchedBy(func(opts entities.PaginationOptions) bool {
+		return opts.Page == 1 && opts.Limit == 2 && opts.Cursor != nil &&
+			opts.Cursor.ID == boundary.ID && opts.Cursor.CreatedAt.Equal(boundary.CreatedAt) && !opts.Cursor.Backward
+	})).Return(expectedPosts, nil)
+
+	// Execute
+	result, err := service.ListPosts(context.Background(), PageRequest{Page: 7, Limit: 2, Cursor: encodeCursor(boundary, "newest", false)})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, result.Posts, 2)
+
+	next, err := decodeCursor(result.NextCursor, "newest")
+	assert.NoError(t, err)
+	assert.Equal(t, expectedPosts[1].ID, next.ID)
+	assert.False(t, next.Backward)
+
+	prev, err := decodeCursor(result.PrevCursor, "newest")
+	assert.NoError(t, err)
+	assert.Equal(t, expectedPosts[0].ID, prev.ID)
+	assert.True(t, prev.Backward)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPostService_QueryPosts_CursorFromOtherSort(t *testing.T) {
+	// Setup
+	mockRepo := new(MockPostRepository)
+	service := NewPostService(mockRepo, nil)
+
+	cursor := encodeCursor(&entities.Post{ID: "507f1f77bcf86cd799439012"}, "newest", false)
+
+	// Execute
+	result, err := service.QueryPosts(context.Background(), SearchCriteria{Tags: []string{"go"}, Sort: []string{"likes"}}, PageRequest{Cursor: cursor})
+
+	// Assert
+	assert.ErrorIs(t, err, AppError.ErrInvalidCursor)
+	assert.Nil(t, result)
+	mockRepo.AssertNotCalled(t, "Query", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -2,146 +2,634 @@ package postsvc
 
 import (
 	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
 
 	"anchor-blog/internal/domain/entities"
+	AppError "anchor-blog/internal/errors"
+	"anchor-blog/pkg/tagutil"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type PostService struct {
-	postRepo entities.IPostRepository
+	postRepo  entities.IPostRepository
+	userRepo  entities.IUserReaderRepository
+	revisions RevisionRecorder
+	tags      TagResolver
+	listeners []ChangeListener
+	cascades  []cascade
+}
+
+// RevisionRecorder keeps the edit history of posts; the revision service implements it
+type RevisionRecorder interface {
+	RecordCreate(ctx context.Context, post *entities.Post) (*entities.PostRevision, error)
+	RecordUpdate(ctx context.Context, before, after *entities.Post, editorID string, restoredFrom int) (*entities.PostRevision, error)
+}
+
+// TagResolver maps tags to their registered names; the tag service implements it
+type TagResolver interface {
+	ResolveTags(ctx context.Context, raw []string) ([]string, error)
+}
+
+// ChangeListener is told about posts that were created, changed, removed or changed visibility
+// through the service, so that anything derived from them can be refreshed.
+type ChangeListener interface {
+	PostChanged(postID string)
+}
+
+// CascadeFunc removes data that belongs to a deleted post, such as its comments
+type CascadeFunc func(ctx context.Context, postID string) error
+
+// how many trashed posts are purged per query
+const purgeBatchSize = 100
+
+type cascade struct {
+	name   string
+	remove CascadeFunc
 }
 
 // NewPostService creates a new post service.
-func NewPostService(repo entities.IPostRepository) *PostService {
-	return &PostService{postRepo: repo}
+// The user repository is used to resolve author usernames in searches.
+func NewPostService(repo entities.IPostRepository, userRepo entities.IUserReaderRepository) *PostService {
+	return &PostService{postRepo: repo, userRepo: userRepo}
+}
+
+// AddChangeListener registers a listener for content changes. It is meant to be called during setup.
+func (s *PostService) AddChangeListener(listener ChangeListener) {
+	s.listeners = append(s.listeners, listener)
+}
+
+// SetRevisionRecorder makes the service record a revision of every post it creates and of
+// every change to a post's title, content or tags. It is meant to be called during setup.
+func (s *PostService) SetRevisionRecorder(recorder RevisionRecorder) {
+	s.revisions = recorder
+}
+
+// SetTagResolver makes imported posts use the registered names of their tags.
+// It is meant to be called during setup.
+func (s *PostService) SetTagResolver(resolver TagResolver) {
+	s.tags = resolver
+}
+
+// AddCascade registers cleanup that runs once a post is permanently deleted. The name describes
+// what is removed in log messages. It is meant to be called during setup.
+func (s *PostService) AddCascade(name string, remove CascadeFunc) {
+	s.cascades = append(s.cascades, cascade{name: name, remove: remove})
+}
+
+func (s *PostService) notifyChanged(postID string) {
+	for _, listener := range s.listeners {
+		listener.PostChanged(postID)
+	}
+}
+
+// changed passes through the result of a write, notifying listeners when it succeeded
+func (s *PostService) changed(post *entities.Post, err error) (*entities.Post, error) {
+	if err != nil {
+		return nil, err
+	}
+	s.notifyChanged(post.ID)
+	return post, nil
+}
+
+// created notifies listeners of a new post and keeps its original version as the first revision
+func (s *PostService) created(ctx context.Context, post *entities.Post) *entities.Post {
+	s.notifyChanged(post.ID)
+	if s.revisions != nil {
+		if _, err := s.revisions.RecordCreate(ctx, post); err != nil {
+			log.Printf("Error recording first revision of post %s: %v", post.ID, err)
+		}
+	}
+	return post
+}
+
+// recordUpdate adds an edit to the post's history; a failure is logged, the edit stands
+func (s *PostService) recordUpdate(ctx context.Context, before, after *entities.Post, editorID string, restoredFrom int) {
+	if s.revisions == nil {
+		return
+	}
+	if _, err := s.revisions.RecordUpdate(ctx, before, after, editorID, restoredFrom); err != nil {
+		log.Printf("Error recording revision of post %s: %v", after.ID, err)
+	}
 }
 
-func (s *PostService) CreatePost(ctx context.Context, title, content string, authorID string, tags []string) (*entities.Post, error) {
+// CreatePost stores a post and publishes it right away. An empty visibility makes it public.
+func (s *PostService) CreatePost(ctx context.Context, title, content string, authorID string, tags []string, visibility string) (*entities.Post, error) {
+	visibility, err := normalizeVisibility(visibility)
+	if err != nil {
+		return nil, err
+	}
 	post := &entities.Post{
-		Title:    title,
-		Content:  content,
-		AuthorID: authorID,
-		Tags:     tags,
+		Title:      title,
+		Content:    content,
+		AuthorID:   authorID,
+		Tags:       tagutil.NormalizeList(tags),
+		Status:     entities.PostStatusPublished,
+		Visibility: visibility,
+		Rendered:   renderContent(content),
 	}
 
-	return s.postRepo.Create(ctx, post)
+	created, err := s.postRepo.Create(ctx, post)
+	if err != nil {
+		return nil, err
+	}
+	return s.created(ctx, created), nil
+}
+
+// CreateDraft stores a post that is not publicly visible yet.
+// A non-zero publishAt schedules the post to go live at that time.
+func (s *PostService) CreateDraft(ctx context.Context, title, content string, authorID string, tags []string, publishAt time.Time, visibility string) (*entities.Post, error) {
+	visibility, err := normalizeVisibility(visibility)
+	if err != nil {
+		return nil, err
+	}
+	post := &entities.Post{
+		Title:      title,
+		Content:    content,
+		AuthorID:   authorID,
+		Tags:       tagutil.NormalizeList(tags),
+		Status:     entities.PostStatusDraft,
+		Visibility: visibility,
+		Rendered:   renderContent(content),
+	}
+	if !publishAt.IsZero() {
+		if !publishAt.After(time.Now()) {
+			return nil, AppError.ErrValidationFailed
+		}
+		post.Status = entities.PostStatusScheduled
+		post.PublishAt = publishAt
+	}
+
+	created, err := s.postRepo.Create(ctx, post)
+	if err != nil {
+		return nil, err
+	}
+	return s.created(ctx, created), nil
+}
+
+// ImportPost stores a post exported from another blog as it was, including its
+// status, view count and timestamps
+func (s *PostService) ImportPost(ctx context.Context, post *entities.Post) (*entities.Post, error) {
+	post.Tags = tagutil.NormalizeList(post.Tags)
+	if s.tags != nil {
+		if tags, err := s.tags.ResolveTags(ctx, post.Tags); err == nil {
+			post.Tags = tags
+		} else {
+			// imports keep every tag they bring, even more than a post may get through the API
+			log.Printf("Error resolving tags %v of imported post: %v", post.Tags, err)
+		}
+	}
+	post.Rendered = renderContent(post.Content)
+	imported, err := s.postRepo.Import(ctx, post)
+	if err != nil {
+		return nil, err
+	}
+	return s.created(ctx, imported), nil
 }
 
 func (s *PostService) GetPostByID(ctx context.Context, id string) (*entities.Post, error) {
 	return s.postRepo.FindByID(ctx, id)
 }
 
-func (s *PostService) ListPosts(ctx context.Context, page, limit int64) ([]*entities.Post, error) {
-	if page <= 0 {
-		page = 1
+// GetPostBySlug resolves a current or historical slug to its post
+func (s *PostService) GetPostBySlug(ctx context.Context, slug string) (*entities.Post, error) {
+	slug = strings.ToLower(strings.TrimSpace(slug))
+	if slug == "" {
+		return nil, AppError.ErrValidationFailed
+	}
+	return s.postRepo.FindBySlug(ctx, slug)
+}
+
+// ListPosts lists published posts, newest first
+func (s *PostService) ListPosts(ctx context.Context, req PageRequest) (*PostPage, error) {
+	sort := sortSignature(nil)
+	opts, err := req.paginationOptions(sort)
+	if err != nil {
+		return nil, err
+	}
+
+	posts, err := s.postRepo.FindAll(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return newPostPage(posts, opts, sort), nil
+}
+
+// ListAuthorPosts lists the author's own posts, optionally narrowed to one status
+func (s *PostService) ListAuthorPosts(ctx context.Context, authorID, status string, req PageRequest) (*PostPage, error) {
+	switch status {
+	case "", entities.PostStatusDraft, entities.PostStatusScheduled, entities.PostStatusPublished, entities.PostStatusArchived:
+	default:
+		return nil, AppError.ErrValidationFailed
+	}
+
+	sort := sortSignature(nil)
+	opts, err := req.paginationOptions(sort)
+	if err != nil {
+		return nil, err
+	}
+
+	posts, err := s.postRepo.FindByAuthorAndStatus(ctx, authorID, status, opts)
+	if err != nil {
+		return nil, err
+	}
+	return newPostPage(posts, opts, sort), nil
+}
+
+// PublishPost makes a draft or scheduled post public now, or schedules it when publishAt is in the future.
+// Publishing a published post changes nothing, so it keeps its place in the listings.
+// Published posts can't be scheduled and archived ones can't be published; both have to be unpublished first.
+func (s *PostService) PublishPost(ctx context.Context, id string, publishAt time.Time) (*entities.Post, error) {
+	post, err := s.postRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	switch post.Status {
+	case entities.PostStatusArchived:
+		return nil, AppError.ErrInvalidStatusTransition
+	case entities.PostStatusPublished, "":
+		if publishAt.After(now) {
+			return nil, AppError.ErrInvalidStatusTransition
+		}
+		return post, nil
+	}
+
+	if publishAt.After(now) {
+		return s.changed(s.postRepo.UpdateStatus(ctx, id, entities.PostStatusScheduled, publishAt))
+	}
+	return s.changed(s.postRepo.UpdateStatus(ctx, id, entities.PostStatusPublished, now))
+}
+
+// PublishScheduled publishes the scheduled posts whose publish time has passed and tells
+// the listeners about each of them
+func (s *PostService) PublishScheduled(ctx context.Context, now time.Time) ([]string, error) {
+	published, err := s.postRepo.PublishScheduled(ctx, now)
+	if err != nil {
+		return nil, err
 	}
-	if limit <= 0 {
-		limit = 10 // Default limit
+	for _, id := range published {
+		s.notifyChanged(id)
 	}
+	return published, nil
+}
 
-	opts := entities.PaginationOptions{
-		Page:  page,
-		Limit: limit,
+// UnpublishPost moves a post back to draft; drafts are left as they are
+func (s *PostService) UnpublishPost(ctx context.Context, id string) (*entities.Post, error) {
+	post, err := s.postRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if post.Status == entities.PostStatusDraft {
+		return post, nil
 	}
+	return s.changed(s.postRepo.UpdateStatus(ctx, id, entities.PostStatusDraft, time.Time{}))
+}
+
+// ArchivePost hides a post from the public without deleting it; archived posts are left as they are
+func (s *PostService) ArchivePost(ctx context.Context, id string) (*entities.Post, error) {
+	post, err := s.postRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if post.Status == entities.PostStatusArchived {
+		return post, nil
+	}
+	return s.changed(s.postRepo.UpdateStatus(ctx, id, entities.PostStatusArchived, post.PublishAt))
+}
+
+// UpdatePost updates an existing post on behalf of editorID and records the edit as a revision.
+// version is the version the editor started from; the update fails with ErrVersionConflict
+// when the post has changed since.
+func (s *PostService) UpdatePost(ctx context.Context, id, editorID string, title, content string, tags []string, version int) (*entities.Post, error) {
+	return s.update(ctx, id, editorID, title, content, tags, version, 0)
+}
 
-	return s.postRepo.FindAll(ctx, opts)
+// RestoreRevision makes an older revision the current version of the post. Restoring is an
+// edit like any other; tags are the revision's tags as they are to be stored now.
+func (s *PostService) RestoreRevision(ctx context.Context, id, editorID string, revision *entities.PostRevision, tags []string, version int) (*entities.Post, error) {
+	return s.update(ctx, id, editorID, revision.Title, revision.Content, tags, version, revision.Number)
 }
 
-// UpdatePost updates an existing post
-func (s *PostService) UpdatePost(ctx context.Context, id string, title, content string, tags []string) (*entities.Post, error) {
+func (s *PostService) update(ctx context.Context, id, editorID string, title, content string, tags []string, version, restoredFrom int) (*entities.Post, error) {
+	before, err := s.postRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
 	post := &entities.Post{
-		Title:   title,
-		Content: content,
-		Tags:    tags,
+		Title:    title,
+		Content:  content,
+		Tags:     tagutil.NormalizeList(tags),
+		Rendered: renderContent(content),
+		Version:  version,
+	}
+	updated, err := s.changed(s.postRepo.Update(ctx, id, post))
+	if err != nil {
+		return nil, err
 	}
 
-	return s.postRepo.Update(ctx, id, post)
+	s.recordUpdate(ctx, before, updated, editorID, restoredFrom)
+	return updated, nil
 }
 
-// DeletePost deletes a post by ID
-func (s *PostService) DeletePost(ctx context.Context, id string) error {
-	return s.postRepo.Delete(ctx, id)
+// ReplaceTags swaps the from tags for to on every post carrying any of them, trashed posts
+// included, and returns the number of posts rewritten. Every rewritten post gets a revision
+// by editorID; automatic rewrites pass an empty editor. When the swap fails partway, the
+// posts it did rewrite still get their revision, and calling it again finishes the rest.
+func (s *PostService) ReplaceTags(ctx context.Context, from []string, to, editorID string) (int64, error) {
+	before, err := s.postRepo.FindByTags(ctx, from)
+	if err != nil {
+		return 0, err
+	}
+	modified, err := s.postRepo.ReplaceTags(ctx, from, to)
+	rewritten := before
+	if err != nil {
+		rewritten = s.rewrittenBefore(ctx, before, from)
+		modified = int64(len(rewritten))
+	}
+
+	now := time.Now()
+	for _, post := range rewritten {
+		after := *post
+		after.Tags = tagutil.Replace(post.Tags, from, to)
+		after.Version++
+		after.UpdatedAt = now
+		s.notifyChanged(post.ID)
+		s.recordUpdate(ctx, post, &after, editorID, 0)
+	}
+	return modified, err
 }
 
-// SearchPosts searches posts by title or author
-func (s *PostService) SearchPosts(ctx context.Context, query, searchType string, page, limit int64) ([]*entities.Post, error) {
-	if page <= 0 {
-		page = 1
+// rewrittenBefore returns the posts of before that no longer carry any of the from tags
+// after a failed ReplaceTags
+func (s *PostService) rewrittenBefore(ctx context.Context, before []*entities.Post, from []string) []*entities.Post {
+	remaining, err := s.postRepo.FindByTags(ctx, from)
+	if err != nil {
+		log.Printf("Error finding posts left with tags %v: %v", from, err)
+		return nil
 	}
-	if limit <= 0 {
-		limit = 10
+	left := make(map[string]bool, len(remaining))
+	for _, post := range remaining {
+		left[post.ID] = true
 	}
 
-	opts := entities.PaginationOptions{
-		Page:  page,
-		Limit: limit,
+	rewritten := []*entities.Post{}
+	for _, post := range before {
+		if !left[post.ID] {
+			rewritten = append(rewritten, post)
+		}
 	}
+	return rewritten
+}
 
-	switch searchType {
-	case "title":
-		return s.postRepo.SearchByTitle(ctx, query, opts)
-	case "author":
-		return s.postRepo.SearchByAuthor(ctx, query, opts)
-	default:
-		// Default to title search
-		return s.postRepo.SearchByTitle(ctx, query, opts)
+// DeletePost moves a post to the trash unless it has changed since the given version.
+// Its comments and other data are kept so that it can be restored, until the post is purged.
+func (s *PostService) DeletePost(ctx context.Context, id string, version int) error {
+	if err := s.postRepo.Delete(ctx, id, version); err != nil {
+		return err
 	}
+	s.notifyChanged(id)
+	return nil
 }
 
-// FilterPosts filters posts by various criteria
-func (s *PostService) FilterPostsByTags(ctx context.Context, tags []string, page, limit int64) ([]*entities.Post, error) {
-	if page <= 0 {
+// DeletePermanently removes a post for good along with the data registered through AddCascade
+func (s *PostService) DeletePermanently(ctx context.Context, id string) error {
+	if err := s.postRepo.DeletePermanently(ctx, id); err != nil {
+		return err
+	}
+	s.notifyChanged(id)
+	s.cascade(ctx, id)
+	return nil
+}
+
+// ListTrash lists the deleted posts of an owner, most recently deleted first
+func (s *PostService) ListTrash(ctx context.Context, ownerID string, page, limit int64) ([]*entities.Post, error) {
+	if page < 1 {
 		page = 1
 	}
-	if limit <= 0 {
-		limit = 10
+	if limit < 1 {
+		limit = 20
 	}
+	return s.postRepo.FindTrashed(ctx, ownerID, entities.PaginationOptions{Page: page, Limit: min(limit, MaxPageSize)})
+}
 
-	opts := entities.PaginationOptions{
-		Page:  page,
-		Limit: limit,
+// RestorePost takes a post of the given owner out of the trash
+func (s *PostService) RestorePost(ctx context.Context, id, ownerID string) (*entities.Post, error) {
+	post, err := s.postRepo.FindTrashedByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !post.IsOwner(ownerID) {
+		return nil, AppError.ErrForbidden
 	}
+	return s.changed(s.postRepo.Restore(ctx, id))
+}
 
-	return s.postRepo.FilterByTags(ctx, tags, opts)
+// PurgeTrash permanently removes the posts deleted before the given time, in batches,
+// running the cascades of every purged post. It returns how many posts were purged.
+func (s *PostService) PurgeTrash(ctx context.Context, deletedBefore time.Time) (int, error) {
+	purged := 0
+	for {
+		ids, err := s.postRepo.PurgeTrashed(ctx, deletedBefore, purgeBatchSize)
+		if err != nil {
+			return purged, err
+		}
+		for _, id := range ids {
+			s.cascade(ctx, id)
+		}
+		purged += len(ids)
+		if len(ids) < purgeBatchSize {
+			return purged, nil
+		}
+	}
 }
 
-// FilterPostsByDateRange filters posts by date range
-func (s *PostService) FilterPostsByDateRange(ctx context.Context, startDate, endDate string, page, limit int64) ([]*entities.Post, error) {
-	if page <= 0 {
-		page = 1
+// cascade removes the data of a post that is gone for good.
+// A failing cleanup is logged and doesn't stop the others.
+func (s *PostService) cascade(ctx context.Context, id string) {
+	for _, c := range s.cascades {
+		if err := c.remove(ctx, id); err != nil {
+			log.Printf("Error deleting %s of post %s: %v", c.name, id, err)
+		}
 	}
-	if limit <= 0 {
-		limit = 10
+}
+
+// SetHidden hides a post from the public for moderation, or shows it again
+func (s *PostService) SetHidden(ctx context.Context, id string, hidden bool) (*entities.Post, error) {
+	return s.changed(s.postRepo.SetHidden(ctx, id, hidden))
+}
+
+// SetVisibility changes who can read a post
+func (s *PostService) SetVisibility(ctx context.Context, id, visibility string) (*entities.Post, error) {
+	visibility, err := normalizeVisibility(visibility)
+	if err != nil {
+		return nil, err
 	}
+	return s.changed(s.postRepo.SetVisibility(ctx, id, visibility))
+}
 
-	opts := entities.PaginationOptions{
-		Page:  page,
-		Limit: limit,
+// CheckAccess tells whether a reader may open a post; viewerID is empty for anonymous readers.
+// Members-only posts ask anonymous readers to sign in and readers whose account isn't
+// activated yet to activate it. Posts the reader may not see at all are reported as missing.
+func (s *PostService) CheckAccess(ctx context.Context, post *entities.Post, viewerID string) error {
+	if post.CanView(viewerID, false) {
+		return nil
+	}
+	if !post.IsPublished() || post.Visibility != entities.PostVisibilityMembers {
+		return AppError.ErrNotFound
+	}
+	if viewerID == "" {
+		return AppError.ErrUnauthorized
 	}
 
-	return s.postRepo.FilterByDateRange(ctx, startDate, endDate, opts)
+	viewer, err := s.userRepo.GetUserByID(ctx, viewerID)
+	if err != nil {
+		if errors.Is(err, AppError.ErrUserNotFound) {
+			return AppError.ErrUnauthorized
+		}
+		return err
+	}
+	if !viewer.Activated {
+		return AppError.ErrUserIsUnverified
+	}
+	return nil
 }
 
-// LikePost adds a like to a post
-func (s *PostService) LikePost(ctx context.Context, postID, userID string) error {
-	return s.postRepo.AddLike(ctx, postID, userID)
+// normalizeVisibility defaults an empty visibility to public and rejects unknown levels
+func normalizeVisibility(visibility string) (string, error) {
+	visibility = strings.ToLower(strings.TrimSpace(visibility))
+	if visibility == "" {
+		return entities.PostVisibilityPublic, nil
+	}
+	if !entities.IsValidVisibility(visibility) {
+		return "", AppError.ErrValidationFailed
+	}
+	return visibility, nil
 }
 
-// UnlikePost removes a like from a post
-func (s *PostService) UnlikePost(ctx context.Context, postID, userID string) error {
-	return s.postRepo.RemoveLike(ctx, postID, userID)
+// AddCollaborator makes a user, given by id or username, a co-author of a post.
+// Only the owner can invite collaborators.
+func (s *PostService) AddCollaborator(ctx context.Context, postID, ownerID, user string) (*entities.Post, error) {
+	post, err := s.postRepo.FindByID(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if !post.IsOwner(ownerID) {
+		return nil, AppError.ErrForbidden
+	}
+
+	collaborator, err := s.findUser(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.changed(s.postRepo.AddCollaborator(ctx, postID, entities.Collaborator{
+		UserID:  collaborator.ID,
+		Role:    entities.PostRoleCoAuthor,
+		AddedAt: time.Now(),
+	}))
 }
 
-// DislikePost adds a dislike to a post
-func (s *PostService) DislikePost(ctx context.Context, postID, userID string) error {
-	return s.postRepo.AddDislike(ctx, postID, userID)
+// RemoveCollaborator takes a user off a post. The owner can remove anyone,
+// collaborators can only remove themselves.
+func (s *PostService) RemoveCollaborator(ctx context.Context, postID, actorID, userID string) (*entities.Post, error) {
+	post, err := s.postRepo.FindByID(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if !post.IsOwner(actorID) && actorID != userID {
+		return nil, AppError.ErrForbidden
+	}
+	return s.changed(s.postRepo.RemoveCollaborator(ctx, postID, userID))
 }
 
-// UndislikePost removes a dislike from a post
-func (s *PostService) UndislikePost(ctx context.Context, postID, userID string) error {
-	return s.postRepo.RemoveDislike(ctx, postID, userID)
+// TransferOwnership hands a post over to another user, given by id or username.
+// The previous owner stays on as a co-author and can leave with RemoveCollaborator.
+func (s *PostService) TransferOwnership(ctx context.Context, postID, ownerID, user string) (*entities.Post, error) {
+	post, err := s.postRepo.FindByID(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if !post.IsOwner(ownerID) {
+		return nil, AppError.ErrForbidden
+	}
+
+	newOwner, err := s.findUser(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	if newOwner.ID == ownerID {
+		return nil, AppError.ErrValidationFailed
+	}
+
+	return s.changed(s.postRepo.TransferOwnership(ctx, postID, ownerID, newOwner.ID))
 }
 
-// GetPostLikeStatus gets the like/dislike status for a user
-func (s *PostService) GetPostLikeStatus(ctx context.Context, postID, userID string) (liked bool, disliked bool, err error) {
-	return s.postRepo.GetLikeStatus(ctx, postID, userID)
+// QueryPosts runs a combined search over published posts.
+// The author may be given as a user id or a username.
+func (s *PostService) QueryPosts(ctx context.Context, criteria SearchCriteria, req PageRequest) (*PostPage, error) {
+	query, err := criteria.toPostQuery()
+	if err != nil {
+		return nil, err
+	}
+
+	sort := sortSignature(query.Sort)
+	opts, err := req.paginationOptions(sort)
+	if err != nil {
+		return nil, err
+	}
+
+	if criteria.Author != "" {
+		authorID, err := s.resolveAuthor(ctx, criteria.Author)
+		if err != nil {
+			return nil, err
+		}
+		if authorID == "" {
+			// Unknown author: nothing can match
+			return &PostPage{Posts: []*entities.Post{}}, nil
+		}
+		query.AuthorID = authorID
+	}
+
+	posts, err := s.postRepo.Query(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	return newPostPage(posts, opts, sort), nil
+}
+
+// findUser looks up a user by id or username
+func (s *PostService) findUser(ctx context.Context, user string) (*entities.User, error) {
+	user = strings.TrimSpace(user)
+	if user == "" {
+		return nil, AppError.ErrValidationFailed
+	}
+	if _, err := primitive.ObjectIDFromHex(user); err == nil {
+		return s.userRepo.GetUserByID(ctx, user)
+	}
+	return s.userRepo.GetUserByUsername(ctx, user)
+}
+
+// resolveAuthor maps a username to its user id; values that already are ids pass through
+func (s *PostService) resolveAuthor(ctx context.Context, author string) (string, error) {
+	if _, err := primitive.ObjectIDFromHex(author); err == nil {
+		return author, nil
+	}
+	if s.userRepo == nil {
+		return "", nil
+	}
+
+	user, err := s.userRepo.GetUserByUsername(ctx, author)
+	if err != nil {
+		if errors.Is(err, AppError.ErrNotFound) {
+			return "", nil
+		}
+		return "", err
+	}
+	return user.ID, nil
 }
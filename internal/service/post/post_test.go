@@ -2,9 +2,12 @@ package postsvc
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"anchor-blog/internal/domain/entities"
+	AppError "anchor-blog/internal/errors"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -20,11 +23,26 @@ func (m *MockPostRepository) Create(ctx context.Context, post *entities.Post) (*
 	return args.Get(0).(*entities.Post), args.Error(1)
 }
 
+func (m *MockPostRepository) Import(ctx context.Context, post *entities.Post) (*entities.Post, error) {
+	args := m.Called(ctx, post)
+	return args.Get(0).(*entities.Post), args.Error(1)
+}
+
 func (m *MockPostRepository) FindByID(ctx context.Context, id string) (*entities.Post, error) {
 	args := m.Called(ctx, id)
 	return args.Get(0).(*entities.Post), args.Error(1)
 }
 
+func (m *MockPostRepository) FindByIDs(ctx context.Context, ids []string) ([]*entities.Post, error) {
+	args := m.Called(ctx, ids)
+	return args.Get(0).([]*entities.Post), args.Error(1)
+}
+
+func (m *MockPostRepository) FindBySlug(ctx context.Context, slug string) (*entities.Post, error) {
+	args := m.Called(ctx, slug)
+	return args.Get(0).(*entities.Post), args.Error(1)
+}
+
 func (m *MockPostRepository) FindAll(ctx context.Context, opts entities.PaginationOptions) ([]*entities.Post, error) {
 	args := m.Called(ctx, opts)
 	return args.Get(0).([]*entities.Post), args.Error(1)
@@ -35,56 +53,51 @@ func (m *MockPostRepository) Update(ctx context.Context, id string, post *entiti
 	return args.Get(0).(*entities.Post), args.Error(1)
 }
 
-func (m *MockPostRepository) Delete(ctx context.Context, id string) error {
-	args := m.Called(ctx, id)
+func (m *MockPostRepository) Delete(ctx context.Context, id string, version int) error {
+	args := m.Called(ctx, id, version)
 	return args.Error(0)
 }
 
-func (m *MockPostRepository) SearchByTitle(ctx context.Context, query string, opts entities.PaginationOptions) ([]*entities.Post, error) {
+func (m *MockPostRepository) Query(ctx context.Context, query entities.PostQuery, opts entities.PaginationOptions) ([]*entities.Post, error) {
 	args := m.Called(ctx, query, opts)
 	return args.Get(0).([]*entities.Post), args.Error(1)
 }
 
-func (m *MockPostRepository) SearchByAuthor(ctx context.Context, query string, opts entities.PaginationOptions) ([]*entities.Post, error) {
-	args := m.Called(ctx, query, opts)
-	return args.Get(0).([]*entities.Post), args.Error(1)
+func (m *MockPostRepository) SaveRendered(ctx context.Context, id string, rendered *entities.RenderedContent) error {
+	args := m.Called(ctx, id, rendered)
+	return args.Error(0)
 }
 
-func (m *MockPostRepository) FilterByTags(ctx context.Context, tags []string, opts entities.PaginationOptions) ([]*entities.Post, error) {
-	args := m.Called(ctx, tags, opts)
-	return args.Get(0).([]*entities.Post), args.Error(1)
+func (m *MockPostRepository) CountTags(ctx context.Context, publishedOnly bool) (map[string]int, error) {
+	args := m.Called(ctx, publishedOnly)
+	return args.Get(0).(map[string]int), args.Error(1)
 }
 
-func (m *MockPostRepository) FilterByDateRange(ctx context.Context, startDate, endDate string, opts entities.PaginationOptions) ([]*entities.Post, error) {
-	args := m.Called(ctx, startDate, endDate, opts)
+func (m *MockPostRepository) FindByTags(ctx context.Context, tags []string) ([]*entities.Post, error) {
+	args := m.Called(ctx, tags)
 	return args.Get(0).([]*entities.Post), args.Error(1)
 }
 
-func (m *MockPostRepository) AddLike(ctx context.Context, postID, userID string) error {
-	args := m.Called(ctx, postID, userID)
-	return args.Error(0)
+func (m *MockPostRepository) ReplaceTags(ctx context.Context, from []string, to string) (int64, error) {
+	args := m.Called(ctx, from, to)
+	return args.Get(0).(int64), args.Error(1)
 }
 
-func (m *MockPostRepository) RemoveLike(ctx context.Context, postID, userID string) error {
-	args := m.Called(ctx, postID, userID)
+func (m *MockPostRepository) IncrementReactionCount(ctx context.Context, postID, kind string, delta int) error {
+	args := m.Called(ctx, postID, kind, delta)
 	return args.Error(0)
 }
 
-func (m *MockPostRepository) AddDislike(ctx context.Context, postID, userID string) error {
-	args := m.Called(ctx, postID, userID)
-	return args.Error(0)
+func (m *MockPostRepository) FindLegacyReactions(ctx context.Context, limit int) ([]*entities.LegacyReactions, error) {
+	args := m.Called(ctx, limit)
+	return args.Get(0).([]*entities.LegacyReactions), args.Error(1)
 }
 
-func (m *MockPostRepository) RemoveDislike(ctx context.Context, postID, userID string) error {
-	args := m.Called(ctx, postID, userID)
+func (m *MockPostRepository) ClearLegacyReactions(ctx context.Context, postID string, counts map[string]int) error {
+	args := m.Called(ctx, postID, counts)
 	return args.Error(0)
 }
 
-func (m *MockPostRepository) GetLikeStatus(ctx context.Context, postID, userID string) (bool, bool, error) {
-	args := m.Called(ctx, postID, userID)
-	return args.Bool(0), args.Bool(1), args.Error(2)
-}
-
 func (m *MockPostRepository) IncrementViewCount(ctx context.Context, postID string) error {
 	args := m.Called(ctx, postID)
 	return args.Error(0)
@@ -110,10 +123,117 @@ func (m *MockPostRepository) ResetViewCount(ctx context.Context, postID string)
 	return args.Error(0)
 }
 
+func (m *MockPostRepository) IncrementCommentCount(ctx context.Context, postID string, delta int) error {
+	args := m.Called(ctx, postID, delta)
+	return args.Error(0)
+}
+
+func (m *MockPostRepository) FindByAuthorAndStatus(ctx context.Context, authorID, status string, opts entities.PaginationOptions) ([]*entities.Post, error) {
+	args := m.Called(ctx, authorID, status, opts)
+	return args.Get(0).([]*entities.Post), args.Error(1)
+}
+
+func (m *MockPostRepository) AddCollaborator(ctx context.Context, postID string, collaborator entities.Collaborator) (*entities.Post, error) {
+	args := m.Called(ctx, postID, collaborator)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Post), args.Error(1)
+}
+
+func (m *MockPostRepository) RemoveCollaborator(ctx context.Context, postID, userID string) (*entities.Post, error) {
+	args := m.Called(ctx, postID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Post), args.Error(1)
+}
+
+func (m *MockPostRepository) TransferOwnership(ctx context.Context, postID, fromUserID, toUserID string) (*entities.Post, error) {
+	args := m.Called(ctx, postID, fromUserID, toUserID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Post), args.Error(1)
+}
+
+func (m *MockPostRepository) SetVisibility(ctx context.Context, id, visibility string) (*entities.Post, error) {
+	args := m.Called(ctx, id, visibility)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Post), args.Error(1)
+}
+
+func (m *MockPostRepository) SetHidden(ctx context.Context, id string, hidden bool) (*entities.Post, error) {
+	args := m.Called(ctx, id, hidden)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Post), args.Error(1)
+}
+
+func (m *MockPostRepository) DeletePermanently(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockPostRepository) FindTrashed(ctx context.Context, authorID string, opts entities.PaginationOptions) ([]*entities.Post, error) {
+	args := m.Called(ctx, authorID, opts)
+	return args.Get(0).([]*entities.Post), args.Error(1)
+}
+
+func (m *MockPostRepository) FindTrashedByID(ctx context.Context, id string) (*entities.Post, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Post), args.Error(1)
+}
+
+func (m *MockPostRepository) Restore(ctx context.Context, id string) (*entities.Post, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Post), args.Error(1)
+}
+
+func (m *MockPostRepository) PurgeTrashed(ctx context.Context, deletedBefore time.Time, limit int) ([]string, error) {
+	args := m.Called(ctx, deletedBefore, limit)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockPostRepository) UpdateStatus(ctx context.Context, id, status string, publishAt time.Time) (*entities.Post, error) {
+	args := m.Called(ctx, id, status, publishAt)
+	return args.Get(0).(*entities.Post), args.Error(1)
+}
+
+func (m *MockPostRepository) PublishScheduled(ctx context.Context, now time.Time) ([]string, error) {
+	args := m.Called(ctx, now)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+// Mock user reader; only the id and username lookups are used by the post service
+type MockUserReader struct {
+	entities.IUserReaderRepository
+	mock.Mock
+}
+
+func (m *MockUserReader) GetUserByID(ctx context.Context, id string) (*entities.User, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(*entities.User), args.Error(1)
+}
+
+func (m *MockUserReader) GetUserByUsername(ctx context.Context, username string) (*entities.User, error) {
+	args := m.Called(ctx, username)
+	return args.Get(0).(*entities.User), args.Error(1)
+}
+
 func TestPostService_CreatePost_Success(t *testing.T) {
 	// Setup
 	mockRepo := new(MockPostRepository)
-	service := NewPostService(mockRepo)
+	service := NewPostService(mockRepo, nil)
 
 	// Test data
 	title := "Test Post"
@@ -135,7 +255,7 @@ func TestPostService_CreatePost_Success(t *testing.T) {
 	})).Return(expectedPost, nil)
 
 	// Execute
-	result, err := service.CreatePost(context.Background(), title, content, authorID, tags)
+	result, err := service.CreatePost(context.Background(), title, content, authorID, tags, "")
 
 	// Assert
 	assert.NoError(t, err)
@@ -152,7 +272,7 @@ func TestPostService_CreatePost_Success(t *testing.T) {
 func TestPostService_GetPostByID_Success(t *testing.T) {
 	// Setup
 	mockRepo := new(MockPostRepository)
-	service := NewPostService(mockRepo)
+	service := NewPostService(mockRepo, nil)
 
 	// Test data
 	postID := "post-123"
@@ -181,7 +301,7 @@ func TestPostService_GetPostByID_Success(t *testing.T) {
 func TestPostService_GetPostByID_NotFound(t *testing.T) {
 	// Setup
 	mockRepo := new(MockPostRepository)
-	service := NewPostService(mockRepo)
+	service := NewPostService(mockRepo, nil)
 
 	// Test data
 	postID := "nonexistent-post"
@@ -202,7 +322,7 @@ func TestPostService_GetPostByID_NotFound(t *testing.T) {
 func TestPostService_ListPosts_Success(t *testing.T) {
 	// Setup
 	mockRepo := new(MockPostRepository)
-	service := NewPostService(mockRepo)
+	service := NewPostService(mockRepo, nil)
 
 	// Test data
 	page := int64(1)
@@ -219,14 +339,16 @@ func TestPostService_ListPosts_Success(t *testing.T) {
 	}).Return(expectedPosts, nil)
 
 	// Execute
-	result, err := service.ListPosts(context.Background(), page, limit)
+	result, err := service.ListPosts(context.Background(), PageRequest{Page: page, Limit: limit})
 
 	// Assert
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
-	assert.Len(t, result, 2)
-	assert.Equal(t, "post-1", result[0].ID)
-	assert.Equal(t, "post-2", result[1].ID)
+	assert.Len(t, result.Posts, 2)
+	assert.Equal(t, "post-1", result.Posts[0].ID)
+	assert.Equal(t, "post-2", result.Posts[1].ID)
+	assert.Empty(t, result.NextCursor) // short page
+	assert.Empty(t, result.PrevCursor) // first page
 
 	mockRepo.AssertExpectations(t)
 }
@@ -234,7 +356,7 @@ func TestPostService_ListPosts_Success(t *testing.T) {
 func TestPostService_ListPosts_DefaultPagination(t *testing.T) {
 	// Setup
 	mockRepo := new(MockPostRepository)
-	service := NewPostService(mockRepo)
+	service := NewPostService(mockRepo, nil)
 
 	// Test data - invalid pagination values
 	page := int64(0)  // Should default to 1
@@ -251,7 +373,7 @@ func TestPostService_ListPosts_DefaultPagination(t *testing.T) {
 	}).Return(expectedPosts, nil)
 
 	// Execute
-	result, err := service.ListPosts(context.Background(), page, limit)
+	result, err := service.ListPosts(context.Background(), PageRequest{Page: page, Limit: limit})
 
 	// Assert
 	assert.NoError(t, err)
@@ -263,7 +385,7 @@ func TestPostService_ListPosts_DefaultPagination(t *testing.T) {
 func TestPostService_UpdatePost_Success(t *testing.T) {
 	// Setup
 	mockRepo := new(MockPostRepository)
-	service := NewPostService(mockRepo)
+	service := NewPostService(mockRepo, nil)
 
 	// Test data
 	postID := "post-123"
@@ -279,12 +401,13 @@ func TestPostService_UpdatePost_Success(t *testing.T) {
 	}
 
 	// Mock expectations
+	mockRepo.On("FindByID", mock.Anything, postID).Return(&entities.Post{ID: postID, Title: "Old Title", Version: 3}, nil)
 	mockRepo.On("Update", mock.Anything, postID, mock.MatchedBy(func(post *entities.Post) bool {
-		return post.Title == title && post.Content == content
+		return post.Title == title && post.Content == content && post.Version == 3
 	})).Return(updatedPost, nil)
 
 	// Execute
-	result, err := service.UpdatePost(context.Background(), postID, title, content, tags)
+	result, err := service.UpdatePost(context.Background(), postID, "editor-1", title, content, tags, 3)
 
 	// Assert
 	assert.NoError(t, err)
@@ -297,34 +420,314 @@ func TestPostService_UpdatePost_Success(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+type recordingListener struct {
+	changed []string
+}
+
+func (l *recordingListener) PostChanged(postID string) {
+	l.changed = append(l.changed, postID)
+}
+
+func TestPostService_UpdatePost_NotifiesListeners(t *testing.T) {
+	// Setup
+	mockRepo := new(MockPostRepository)
+	service := NewPostService(mockRepo, nil)
+	listener := &recordingListener{}
+	service.AddChangeListener(listener)
+
+	mockRepo.On("FindByID", mock.Anything, "post-1").Return(&entities.Post{ID: "post-1"}, nil)
+	mockRepo.On("FindByID", mock.Anything, "post-2").Return((*entities.Post)(nil), AppError.ErrNotFound)
+	mockRepo.On("Update", mock.Anything, "post-1", mock.Anything).Return(&entities.Post{ID: "post-1"}, nil).Once()
+
+	// Execute
+	_, err := service.UpdatePost(context.Background(), "post-1", "editor-1", "Title", "Content", nil, 1)
+	_, failErr := service.UpdatePost(context.Background(), "post-2", "editor-1", "Title", "Content", nil, 1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, AppError.ErrNotFound, failErr)
+	assert.Equal(t, []string{"post-1"}, listener.changed)
+}
+
+func TestPostService_PublishScheduled_NotifiesListeners(t *testing.T) {
+	// Setup
+	mockRepo := new(MockPostRepository)
+	service := NewPostService(mockRepo, nil)
+	listener := &recordingListener{}
+	service.AddChangeListener(listener)
+	now := time.Now()
+
+	mockRepo.On("PublishScheduled", mock.Anything, now).Return([]string{"post-1", "post-2"}, nil).Once()
+	mockRepo.On("PublishScheduled", mock.Anything, now).Return([]string(nil), AppError.ErrInternalServer).Once()
+
+	// Execute
+	published, err := service.PublishScheduled(context.Background(), now)
+	_, failErr := service.PublishScheduled(context.Background(), now)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"post-1", "post-2"}, published)
+	assert.Equal(t, AppError.ErrInternalServer, failErr)
+	assert.Equal(t, []string{"post-1", "post-2"}, listener.changed)
+}
+
+// recordingRevisions keeps the revisions the service asks for
+type recordingRevisions struct {
+	created  []*entities.Post
+	updates  []string // editor:post:restoredFrom
+	previous []*entities.Post
+}
+
+func (r *recordingRevisions) RecordCreate(ctx context.Context, post *entities.Post) (*entities.PostRevision, error) {
+	r.created = append(r.created, post)
+	return &entities.PostRevision{Number: 1}, nil
+}
+
+func (r *recordingRevisions) RecordUpdate(ctx context.Context, before, after *entities.Post, editorID string, restoredFrom int) (*entities.PostRevision, error) {
+	r.updates = append(r.updates, fmt.Sprintf("%s:%s:%d:%v", editorID, after.ID, restoredFrom, after.Tags))
+	r.previous = append(r.previous, before)
+	return &entities.PostRevision{}, nil
+}
+
+func TestPostService_RecordsRevisionsOfEveryWritePath(t *testing.T) {
+	// Setup
+	mockRepo := new(MockPostRepository)
+	service := NewPostService(mockRepo, nil)
+	revisions := &recordingRevisions{}
+	service.SetRevisionRecorder(revisions)
+	listener := &recordingListener{}
+	service.AddChangeListener(listener)
+
+	before := &entities.Post{ID: "post-1", Title: "Old", Tags: []string{"golang", "web"}, Version: 2}
+	mockRepo.On("Import", mock.Anything, mock.Anything).Return(&entities.Post{ID: "post-9"}, nil)
+	mockRepo.On("FindByID", mock.Anything, "post-1").Return(before, nil)
+	mockRepo.On("Update", mock.Anything, "post-1", mock.Anything).Return(&entities.Post{ID: "post-1", Title: "Old", Tags: []string{"web"}, Version: 3}, nil)
+	mockRepo.On("FindByTags", mock.Anything, []string{"golang"}).Return([]*entities.Post{before}, nil)
+	mockRepo.On("ReplaceTags", mock.Anything, []string{"golang"}, "go").Return(int64(1), nil)
+
+	// Execute
+	_, importErr := service.ImportPost(context.Background(), &entities.Post{Title: "Imported"})
+	_, updateErr := service.UpdatePost(context.Background(), "post-1", "editor-1", "Old", "Content", []string{"web"}, 2)
+	_, restoreErr := service.RestoreRevision(context.Background(), "post-1", "editor-2", &entities.PostRevision{Number: 1, Title: "Old"}, []string{"web"}, 3)
+	modified, retagErr := service.ReplaceTags(context.Background(), []string{"golang"}, "go", "admin-1")
+
+	// Assert
+	assert.NoError(t, importErr)
+	assert.NoError(t, updateErr)
+	assert.NoError(t, restoreErr)
+	assert.NoError(t, retagErr)
+	assert.Equal(t, int64(1), modified)
+	assert.Len(t, revisions.created, 1)
+	assert.Equal(t, []string{"editor-1:post-1:0:[web]", "editor-2:post-1:1:[web]", "admin-1:post-1:0:[go web]"}, revisions.updates)
+	assert.Equal(t, before, revisions.previous[2])
+	assert.Equal(t, []string{"post-9", "post-1", "post-1", "post-1"}, listener.changed)
+}
+
+func TestPostService_ReplaceTags_PartialFailure(t *testing.T) {
+	// Setup
+	mockRepo := new(MockPostRepository)
+	service := NewPostService(mockRepo, nil)
+	revisions := &recordingRevisions{}
+	service.SetRevisionRecorder(revisions)
+	listener := &recordingListener{}
+	service.AddChangeListener(listener)
+
+	first := &entities.Post{ID: "post-1", Tags: []string{"golang"}, Version: 1}
+	second := &entities.Post{ID: "post-2", Tags: []string{"golang", "web"}, Version: 4}
+	mockRepo.On("FindByTags", mock.Anything, []string{"golang"}).Return([]*entities.Post{first, second}, nil).Once()
+	mockRepo.On("ReplaceTags", mock.Anything, []string{"golang"}, "go").Return(int64(0), AppError.ErrInternalServer).Once()
+	mockRepo.On("FindByTags", mock.Anything, []string{"golang"}).Return([]*entities.Post{second}, nil).Once()
+
+	// Execute: the update stops after the first post
+	modified, err := service.ReplaceTags(context.Background(), []string{"golang"}, "go", "admin-1")
+
+	// Assert: the rewritten post has its revision, the other one is left for the retry
+	assert.Equal(t, AppError.ErrInternalServer, err)
+	assert.Equal(t, int64(1), modified)
+	assert.Equal(t, []string{"admin-1:post-1:0:[go]"}, revisions.updates)
+	assert.Equal(t, []string{"post-1"}, listener.changed)
+
+	// Execute: the retry only finds the post left behind
+	mockRepo.On("FindByTags", mock.Anything, []string{"golang"}).Return([]*entities.Post{second}, nil).Once()
+	mockRepo.On("ReplaceTags", mock.Anything, []string{"golang"}, "go").Return(int64(1), nil).Once()
+	modified, err = service.ReplaceTags(context.Background(), []string{"golang"}, "go", "admin-1")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), modified)
+	assert.Equal(t, []string{"admin-1:post-1:0:[go]", "admin-1:post-2:0:[go web]"}, revisions.updates)
+	assert.Equal(t, []string{"post-1", "post-2"}, listener.changed)
+}
+
+// aliasResolver maps former tag names to current ones
+type aliasResolver map[string]string
+
+func (r aliasResolver) ResolveTags(ctx context.Context, raw []string) ([]string, error) {
+	if len(raw) > 2 {
+		return nil, AppError.ErrValidationFailed
+	}
+	tags := make([]string, len(raw))
+	for i, tag := range raw {
+		tags[i] = tag
+		if current, ok := r[tag]; ok {
+			tags[i] = current
+		}
+	}
+	return tags, nil
+}
+
+func TestPostService_ImportPost_ResolvesTags(t *testing.T) {
+	// Setup
+	mockRepo := new(MockPostRepository)
+	service := NewPostService(mockRepo, nil)
+	service.SetTagResolver(aliasResolver{"golang": "go"})
+
+	var stored [][]string
+	mockRepo.On("Import", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		stored = append(stored, args.Get(1).(*entities.Post).Tags)
+	}).Return(&entities.Post{ID: "post-9"}, nil)
+
+	// Execute
+	_, err := service.ImportPost(context.Background(), &entities.Post{Tags: []string{"Golang", "web"}})
+	_, manyErr := service.ImportPost(context.Background(), &entities.Post{Tags: []string{"a", "b", "c"}})
+
+	// Assert: a failing resolver doesn't cost the import its tags
+	assert.NoError(t, err)
+	assert.NoError(t, manyErr)
+	assert.Equal(t, [][]string{{"go", "web"}, {"a", "b", "c"}}, stored)
+}
+
 func TestPostService_DeletePost_Success(t *testing.T) {
 	// Setup
 	mockRepo := new(MockPostRepository)
-	service := NewPostService(mockRepo)
+	service := NewPostService(mockRepo, nil)
 
 	// Test data
 	postID := "post-123"
 
 	// Mock expectations
-	mockRepo.On("Delete", mock.Anything, postID).Return(nil)
+	mockRepo.On("Delete", mock.Anything, postID, 4).Return(nil)
+
+	// Execute
+	err := service.DeletePost(context.Background(), postID, 4)
+
+	// Assert
+	assert.NoError(t, err)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPostService_VersionConflict(t *testing.T) {
+	// Setup
+	mockRepo := new(MockPostRepository)
+	service := NewPostService(mockRepo, nil)
+	listener := &recordingListener{}
+	service.AddChangeListener(listener)
+
+	mockRepo.On("FindByID", mock.Anything, "post-1").Return(&entities.Post{ID: "post-1", Version: 3}, nil)
+	mockRepo.On("Update", mock.Anything, "post-1", mock.Anything).Return((*entities.Post)(nil), AppError.ErrVersionConflict).Once()
+	mockRepo.On("Delete", mock.Anything, "post-1", 2).Return(AppError.ErrVersionConflict).Once()
+
+	// Execute: another editor saved version 3 in the meantime
+	_, updateErr := service.UpdatePost(context.Background(), "post-1", "editor-1", "Title", "Content", nil, 2)
+	deleteErr := service.DeletePost(context.Background(), "post-1", 2)
+
+	// Assert
+	assert.Equal(t, AppError.ErrVersionConflict, updateErr)
+	assert.Equal(t, AppError.ErrVersionConflict, deleteErr)
+	assert.Empty(t, listener.changed)
+}
+
+func TestPostService_DeletePermanently_RunsCascades(t *testing.T) {
+	// Setup
+	mockRepo := new(MockPostRepository)
+	service := NewPostService(mockRepo, nil)
+
+	var removed []string
+	service.AddCascade("comments", func(ctx context.Context, postID string) error {
+		removed = append(removed, "comments:"+postID)
+		return AppError.ErrInternalServer
+	})
+	service.AddCascade("bookmarks", func(ctx context.Context, postID string) error {
+		removed = append(removed, "bookmarks:"+postID)
+		return nil
+	})
+	mockRepo.On("DeletePermanently", mock.Anything, "post-1").Return(nil).Once()
+	mockRepo.On("DeletePermanently", mock.Anything, "post-2").Return(AppError.ErrNotFound).Once()
+	mockRepo.On("Delete", mock.Anything, "post-3", 1).Return(nil).Once()
+
+	// Execute
+	err := service.DeletePermanently(context.Background(), "post-1")
+	missingErr := service.DeletePermanently(context.Background(), "post-2")
+	trashErr := service.DeletePost(context.Background(), "post-3", 1)
+
+	// Assert: a failing cleanup doesn't stop the others; a failed delete or a move to the trash runs none
+	assert.NoError(t, err)
+	assert.Equal(t, AppError.ErrNotFound, missingErr)
+	assert.NoError(t, trashErr)
+	assert.Equal(t, []string{"comments:post-1", "bookmarks:post-1"}, removed)
+}
+
+func TestPostService_RestorePost_OnlyOwner(t *testing.T) {
+	// Setup
+	mockRepo := new(MockPostRepository)
+	service := NewPostService(mockRepo, nil)
+	listener := &recordingListener{}
+	service.AddChangeListener(listener)
+
+	trashed := &entities.Post{ID: "post-1", AuthorID: "owner-1", DeletedAt: time.Now(), Collaborators: []entities.Collaborator{{UserID: "user-2", Role: entities.PostRoleCoAuthor}}}
+	mockRepo.On("FindTrashedByID", mock.Anything, "post-1").Return(trashed, nil)
+	mockRepo.On("Restore", mock.Anything, "post-1").Return(&entities.Post{ID: "post-1", AuthorID: "owner-1"}, nil)
 
 	// Execute
-	err := service.DeletePost(context.Background(), postID)
+	_, coAuthorErr := service.RestorePost(context.Background(), "post-1", "user-2")
+	restored, err := service.RestorePost(context.Background(), "post-1", "owner-1")
 
 	// Assert
+	assert.Equal(t, AppError.ErrForbidden, coAuthorErr)
 	assert.NoError(t, err)
+	assert.True(t, restored.DeletedAt.IsZero())
+	assert.Equal(t, []string{"post-1"}, listener.changed)
+	mockRepo.AssertNumberOfCalls(t, "Restore", 1)
+}
+
+func TestPostService_PurgeTrash_Batches(t *testing.T) {
+	// Setup
+	mockRepo := new(MockPostRepository)
+	service := NewPostService(mockRepo, nil)
+
+	var removed []string
+	service.AddCascade("comments", func(ctx context.Context, postID string) error {
+		removed = append(removed, postID)
+		return nil
+	})
+
+	cutoff := time.Now().Add(-30 * 24 * time.Hour)
+	fullBatch := make([]string, purgeBatchSize)
+	for i := range fullBatch {
+		fullBatch[i] = fmt.Sprintf("post-%d", i)
+	}
+	mockRepo.On("PurgeTrashed", mock.Anything, cutoff, purgeBatchSize).Return(fullBatch, nil).Once()
+	mockRepo.On("PurgeTrashed", mock.Anything, cutoff, purgeBatchSize).Return([]string{"post-last"}, nil).Once()
+
+	// Execute
+	purged, err := service.PurgeTrash(context.Background(), cutoff)
 
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, purgeBatchSize+1, purged)
+	assert.Len(t, removed, purgeBatchSize+1)
+	assert.Equal(t, "post-last", removed[purgeBatchSize])
 	mockRepo.AssertExpectations(t)
 }
 
-func TestPostService_SearchPosts_ByTitle(t *testing.T) {
+func TestPostService_QueryPosts_ByTitle(t *testing.T) {
 	// Setup
 	mockRepo := new(MockPostRepository)
-	service := NewPostService(mockRepo)
+	service := NewPostService(mockRepo, nil)
 
 	// Test data
-	query := "golang"
-	searchType := "title"
+	criteria := SearchCriteria{Text: "golang"}
 	page := int64(1)
 	limit := int64(10)
 
@@ -333,95 +736,369 @@ func TestPostService_SearchPosts_ByTitle(t *testing.T) {
 	}
 
 	// Mock expectations
-	mockRepo.On("SearchByTitle", mock.Anything, query, entities.PaginationOptions{
+	mockRepo.On("Query", mock.Anything, entities.PostQuery{Text: "golang"}, entities.PaginationOptions{
 		Page:  page,
 		Limit: limit,
 	}).Return(expectedPosts, nil)
 
 	// Execute
-	result, err := service.SearchPosts(context.Background(), query, searchType, page, limit)
+	result, err := service.QueryPosts(context.Background(), criteria, PageRequest{Page: page, Limit: limit})
 
 	// Assert
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
-	assert.Len(t, result, 1)
-	assert.Equal(t, "post-1", result[0].ID)
+	assert.Len(t, result.Posts, 1)
+	assert.Equal(t, "post-1", result.Posts[0].ID)
 
 	mockRepo.AssertExpectations(t)
 }
 
-func TestPostService_SearchPosts_ByAuthor(t *testing.T) {
+func TestPostService_QueryPosts_ByAuthorUsername(t *testing.T) {
 	// Setup
 	mockRepo := new(MockPostRepository)
-	service := NewPostService(mockRepo)
+	mockUsers := new(MockUserReader)
+	service := NewPostService(mockRepo, mockUsers)
 
 	// Test data
-	query := "john"
-	searchType := "author"
-	page := int64(1)
-	limit := int64(10)
+	authorID := "507f1f77bcf86cd799439011"
+	criteria := SearchCriteria{Author: "john", Tags: []string{"go"}, MatchAllTags: true, Sort: []string{"likes"}}
 
 	expectedPosts := []*entities.Post{
 		{ID: "post-1", Title: "Post by John"},
 	}
 
 	// Mock expectations
-	mockRepo.On("SearchByAuthor", mock.Anything, query, entities.PaginationOptions{
-		Page:  page,
-		Limit: limit,
-	}).Return(expectedPosts, nil)
+	mockUsers.On("GetUserByUsername", mock.Anything, "john").Return(&entities.User{ID: authorID}, nil)
+	mockRepo.On("Query", mock.Anything, entities.PostQuery{
+		Tags:         []string{"go"},
+		MatchAllTags: true,
+		AuthorID:     authorID,
+		Sort:         []string{entities.SortLikes},
+	}, entities.PaginationOptions{Page: 1, Limit: 10}).Return(expectedPosts, nil)
 
 	// Execute
-	result, err := service.SearchPosts(context.Background(), query, searchType, page, limit)
+	result, err := service.QueryPosts(context.Background(), criteria, PageRequest{})
 
 	// Assert
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
-	assert.Len(t, result, 1)
+	assert.Len(t, result.Posts, 1)
 
 	mockRepo.AssertExpectations(t)
+	mockUsers.AssertExpectations(t)
 }
 
-func TestPostService_LikePost_Success(t *testing.T) {
+func TestPostService_QueryPosts_UnknownAuthor(t *testing.T) {
 	// Setup
 	mockRepo := new(MockPostRepository)
-	service := NewPostService(mockRepo)
+	mockUsers := new(MockUserReader)
+	service := NewPostService(mockRepo, mockUsers)
+
+	// Mock expectations
+	mockUsers.On("GetUserByUsername", mock.Anything, "nobody").Return((*entities.User)(nil), AppError.ErrNotFound)
+
+	// Execute
+	result, err := service.QueryPosts(context.Background(), SearchCriteria{Author: "nobody"}, PageRequest{Page: 1, Limit: 10})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, result.Posts)
+	mockRepo.AssertNotCalled(t, "Query", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPostService_CreateDraft_Scheduled(t *testing.T) {
+	// Setup
+	mockRepo := new(MockPostRepository)
+	service := NewPostService(mockRepo, nil)
+
+	publishAt := time.Now().Add(24 * time.Hour)
+	expectedPost := &entities.Post{ID: "post-123", Status: entities.PostStatusScheduled, PublishAt: publishAt}
+
+	// Mock expectations
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(post *entities.Post) bool {
+		return post.Status == entities.PostStatusScheduled && post.PublishAt.Equal(publishAt)
+	})).Return(expectedPost, nil)
+
+	// Execute
+	result, err := service.CreateDraft(context.Background(), "Title", "Content", "author-123", nil, publishAt, "")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, entities.PostStatusScheduled, result.Status)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPostService_CreateDraft_PastPublishAt(t *testing.T) {
+	// Setup
+	mockRepo := new(MockPostRepository)
+	service := NewPostService(mockRepo, nil)
+
+	// Execute
+	result, err := service.CreateDraft(context.Background(), "Title", "Content", "author-123", nil, time.Now().Add(-time.Hour), "")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestPostService_PublishPost_FutureDateSchedules(t *testing.T) {
+	// Setup
+	mockRepo := new(MockPostRepository)
+	service := NewPostService(mockRepo, nil)
 
-	// Test data
 	postID := "post-123"
-	userID := "user-123"
+	publishAt := time.Now().Add(time.Hour)
 
 	// Mock expectations
-	mockRepo.On("AddLike", mock.Anything, postID, userID).Return(nil)
+	mockRepo.On("FindByID", mock.Anything, postID).Return(&entities.Post{ID: postID, Status: entities.PostStatusDraft}, nil)
+	mockRepo.On("UpdateStatus", mock.Anything, postID, entities.PostStatusScheduled, publishAt).
+		Return(&entities.Post{ID: postID, Status: entities.PostStatusScheduled}, nil)
 
 	// Execute
-	err := service.LikePost(context.Background(), postID, userID)
+	result, err := service.PublishPost(context.Background(), postID, publishAt)
 
 	// Assert
 	assert.NoError(t, err)
+	assert.Equal(t, entities.PostStatusScheduled, result.Status)
 
 	mockRepo.AssertExpectations(t)
 }
 
-func TestPostService_GetPostLikeStatus_Success(t *testing.T) {
+func TestPostService_PublishPost_Now(t *testing.T) {
 	// Setup
 	mockRepo := new(MockPostRepository)
-	service := NewPostService(mockRepo)
+	service := NewPostService(mockRepo, nil)
 
-	// Test data
 	postID := "post-123"
-	userID := "user-123"
 
 	// Mock expectations
-	mockRepo.On("GetLikeStatus", mock.Anything, postID, userID).Return(true, false, nil)
+	mockRepo.On("FindByID", mock.Anything, postID).Return(&entities.Post{ID: postID, Status: entities.PostStatusScheduled}, nil)
+	mockRepo.On("UpdateStatus", mock.Anything, postID, entities.PostStatusPublished, mock.AnythingOfType("time.Time")).
+		Return(&entities.Post{ID: postID, Status: entities.PostStatusPublished}, nil)
+
+	// Execute
+	result, err := service.PublishPost(context.Background(), postID, time.Time{})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, result.IsPublished())
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPostService_StatusTransitions(t *testing.T) {
+	// Setup
+	mockRepo := new(MockPostRepository)
+	service := NewPostService(mockRepo, nil)
+
+	publishedAt := time.Now().Add(-24 * time.Hour)
+	mockRepo.On("FindByID", mock.Anything, "published").Return(&entities.Post{ID: "published", Status: entities.PostStatusPublished, PublishAt: publishedAt}, nil)
+	mockRepo.On("FindByID", mock.Anything, "archived").Return(&entities.Post{ID: "archived", Status: entities.PostStatusArchived}, nil)
+	mockRepo.On("FindByID", mock.Anything, "draft").Return(&entities.Post{ID: "draft", Status: entities.PostStatusDraft}, nil)
+
+	// Execute
+	republished, republishErr := service.PublishPost(context.Background(), "published", time.Time{})
+	_, rescheduleErr := service.PublishPost(context.Background(), "published", time.Now().Add(time.Hour))
+	_, archivedErr := service.PublishPost(context.Background(), "archived", time.Time{})
+	draft, unpublishErr := service.UnpublishPost(context.Background(), "draft")
+	archived, archiveErr := service.ArchivePost(context.Background(), "archived")
+
+	// Assert: no-ops keep the post as it is
+	assert.NoError(t, republishErr)
+	assert.Equal(t, publishedAt, republished.PublishAt)
+	assert.Equal(t, AppError.ErrInvalidStatusTransition, rescheduleErr)
+	assert.Equal(t, AppError.ErrInvalidStatusTransition, archivedErr)
+	assert.NoError(t, unpublishErr)
+	assert.Equal(t, entities.PostStatusDraft, draft.Status)
+	assert.NoError(t, archiveErr)
+	assert.Equal(t, entities.PostStatusArchived, archived.Status)
+	mockRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPostService_ListAuthorPosts_InvalidStatus(t *testing.T) {
+	// Setup
+	mockRepo := new(MockPostRepository)
+	service := NewPostService(mockRepo, nil)
+
+	// Execute
+	result, err := service.ListAuthorPosts(context.Background(), "author-123", "deleted", PageRequest{Page: 1, Limit: 10})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestPostService_GetPostBySlug_NormalizesSlug(t *testing.T) {
+	// Setup
+	mockRepo := new(MockPostRepository)
+	service := NewPostService(mockRepo, nil)
+
+	expectedPost := &entities.Post{ID: "post-123", Slug: "getting-started-with-go"}
+
+	// Mock expectations
+	mockRepo.On("FindBySlug", mock.Anything, "getting-started-with-go").Return(expectedPost, nil)
 
 	// Execute
-	liked, disliked, err := service.GetPostLikeStatus(context.Background(), postID, userID)
+	result, err := service.GetPostBySlug(context.Background(), " Getting-Started-With-Go ")
 
 	// Assert
 	assert.NoError(t, err)
-	assert.True(t, liked)
-	assert.False(t, disliked)
+	assert.Equal(t, expectedPost.ID, result.ID)
 
 	mockRepo.AssertExpectations(t)
-}
\ No newline at end of file
+}
+
+func TestPostService_AddCollaborator_OwnerInvitesByUsername(t *testing.T) {
+	// Setup
+	mockRepo := new(MockPostRepository)
+	mockUsers := new(MockUserReader)
+	service := NewPostService(mockRepo, mockUsers)
+	listener := &recordingListener{}
+	service.AddChangeListener(listener)
+
+	post := &entities.Post{ID: "post-1", AuthorID: "owner-1"}
+	mockRepo.On("FindByID", mock.Anything, "post-1").Return(post, nil)
+	mockUsers.On("GetUserByUsername", mock.Anything, "bob").Return(&entities.User{ID: "user-2", Username: "bob"}, nil)
+	mockRepo.On("AddCollaborator", mock.Anything, "post-1", mock.MatchedBy(func(c entities.Collaborator) bool {
+		return c.UserID == "user-2" && c.Role == entities.PostRoleCoAuthor && !c.AddedAt.IsZero()
+	})).Return(&entities.Post{ID: "post-1", AuthorID: "owner-1", Collaborators: []entities.Collaborator{{UserID: "user-2", Role: entities.PostRoleCoAuthor}}}, nil)
+
+	// Execute
+	result, err := service.AddCollaborator(context.Background(), "post-1", "owner-1", " bob ")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, result.CanEdit("user-2"))
+	assert.False(t, result.IsOwner("user-2"))
+	assert.Equal(t, []string{"post-1"}, listener.changed)
+	mockRepo.AssertExpectations(t)
+	mockUsers.AssertExpectations(t)
+}
+
+func TestPostService_AddCollaborator_OnlyOwner(t *testing.T) {
+	// Setup
+	mockRepo := new(MockPostRepository)
+	service := NewPostService(mockRepo, new(MockUserReader))
+
+	post := &entities.Post{ID: "post-1", AuthorID: "owner-1", Collaborators: []entities.Collaborator{{UserID: "user-2", Role: entities.PostRoleCoAuthor}}}
+	mockRepo.On("FindByID", mock.Anything, "post-1").Return(post, nil)
+
+	// Execute
+	_, err := service.AddCollaborator(context.Background(), "post-1", "user-2", "carol")
+
+	// Assert
+	assert.Equal(t, AppError.ErrForbidden, err)
+	mockRepo.AssertNotCalled(t, "AddCollaborator", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPostService_RemoveCollaborator_Permissions(t *testing.T) {
+	// Setup
+	mockRepo := new(MockPostRepository)
+	service := NewPostService(mockRepo, nil)
+
+	post := &entities.Post{ID: "post-1", AuthorID: "owner-1", Collaborators: []entities.Collaborator{
+		{UserID: "user-2", Role: entities.PostRoleCoAuthor},
+		{UserID: "user-3", Role: entities.PostRoleCoAuthor},
+	}}
+	mockRepo.On("FindByID", mock.Anything, "post-1").Return(post, nil)
+	mockRepo.On("RemoveCollaborator", mock.Anything, "post-1", "user-2").Return(post, nil)
+	mockRepo.On("RemoveCollaborator", mock.Anything, "post-1", "user-3").Return(post, nil)
+
+	// Execute
+	_, leaveErr := service.RemoveCollaborator(context.Background(), "post-1", "user-2", "user-2")
+	_, kickErr := service.RemoveCollaborator(context.Background(), "post-1", "user-2", "user-3")
+	_, ownerErr := service.RemoveCollaborator(context.Background(), "post-1", "owner-1", "user-3")
+
+	// Assert
+	assert.NoError(t, leaveErr)
+	assert.Equal(t, AppError.ErrForbidden, kickErr)
+	assert.NoError(t, ownerErr)
+	mockRepo.AssertNumberOfCalls(t, "RemoveCollaborator", 2)
+}
+
+func TestPostService_TransferOwnership(t *testing.T) {
+	// Setup
+	mockRepo := new(MockPostRepository)
+	mockUsers := new(MockUserReader)
+	service := NewPostService(mockRepo, mockUsers)
+
+	ownerID := "64b7f0c2a1b2c3d4e5f60001"
+	newOwnerID := "64b7f0c2a1b2c3d4e5f60002"
+	post := &entities.Post{ID: "post-1", AuthorID: ownerID}
+	transferred := &entities.Post{ID: "post-1", AuthorID: newOwnerID, Collaborators: []entities.Collaborator{{UserID: ownerID, Role: entities.PostRoleCoAuthor}}}
+	mockRepo.On("FindByID", mock.Anything, "post-1").Return(post, nil)
+	mockUsers.On("GetUserByID", mock.Anything, newOwnerID).Return(&entities.User{ID: newOwnerID}, nil)
+	mockUsers.On("GetUserByID", mock.Anything, ownerID).Return(&entities.User{ID: ownerID}, nil)
+	mockRepo.On("TransferOwnership", mock.Anything, "post-1", ownerID, newOwnerID).Return(transferred, nil)
+
+	// Execute
+	_, selfErr := service.TransferOwnership(context.Background(), "post-1", ownerID, ownerID)
+	result, err := service.TransferOwnership(context.Background(), "post-1", ownerID, newOwnerID)
+
+	// Assert
+	assert.Equal(t, AppError.ErrValidationFailed, selfErr)
+	assert.NoError(t, err)
+	assert.True(t, result.IsOwner(newOwnerID))
+	assert.Equal(t, entities.PostRoleCoAuthor, result.RoleOf(ownerID))
+	mockRepo.AssertNumberOfCalls(t, "TransferOwnership", 1)
+}
+
+func TestPostService_CheckAccess(t *testing.T) {
+	// Setup
+	mockUsers := new(MockUserReader)
+	service := NewPostService(new(MockPostRepository), mockUsers)
+
+	mockUsers.On("GetUserByID", mock.Anything, "member-1").Return(&entities.User{ID: "member-1", Activated: true}, nil)
+	mockUsers.On("GetUserByID", mock.Anything, "newbie-1").Return(&entities.User{ID: "newbie-1"}, nil)
+
+	post := func(visibility string) *entities.Post {
+		return &entities.Post{ID: "post-1", AuthorID: "owner-1", Status: entities.PostStatusPublished, Visibility: visibility}
+	}
+	ctx := context.Background()
+
+	// Execute & Assert
+	assert.NoError(t, service.CheckAccess(ctx, post(""), ""))
+	assert.NoError(t, service.CheckAccess(ctx, post(entities.PostVisibilityUnlisted), ""))
+
+	assert.NoError(t, service.CheckAccess(ctx, post(entities.PostVisibilityPrivate), "owner-1"))
+	assert.Equal(t, AppError.ErrNotFound, service.CheckAccess(ctx, post(entities.PostVisibilityPrivate), "member-1"))
+	assert.Equal(t, AppError.ErrNotFound, service.CheckAccess(ctx, post(entities.PostVisibilityPrivate), ""))
+
+	assert.NoError(t, service.CheckAccess(ctx, post(entities.PostVisibilityMembers), "member-1"))
+	assert.NoError(t, service.CheckAccess(ctx, post(entities.PostVisibilityMembers), "owner-1"))
+	assert.Equal(t, AppError.ErrUnauthorized, service.CheckAccess(ctx, post(entities.PostVisibilityMembers), ""))
+	assert.Equal(t, AppError.ErrUserIsUnverified, service.CheckAccess(ctx, post(entities.PostVisibilityMembers), "newbie-1"))
+
+	draft := post(entities.PostVisibilityMembers)
+	draft.Status = entities.PostStatusDraft
+	assert.Equal(t, AppError.ErrNotFound, service.CheckAccess(ctx, draft, "member-1"))
+}
+
+func TestPostService_Visibility_Validation(t *testing.T) {
+	// Setup
+	mockRepo := new(MockPostRepository)
+	service := NewPostService(mockRepo, nil)
+
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(post *entities.Post) bool {
+		return post.Visibility == entities.PostVisibilityMembers
+	})).Return(&entities.Post{ID: "post-1", Visibility: entities.PostVisibilityMembers}, nil)
+	mockRepo.On("SetVisibility", mock.Anything, "post-1", entities.PostVisibilityUnlisted).Return(&entities.Post{ID: "post-1", Visibility: entities.PostVisibilityUnlisted}, nil)
+
+	// Execute
+	created, err := service.CreatePost(context.Background(), "Title", "Content", "author-1", nil, " Members-Only ")
+	_, invalidErr := service.CreatePost(context.Background(), "Title", "Content", "author-1", nil, "friends")
+	updated, updateErr := service.SetVisibility(context.Background(), "post-1", entities.PostVisibilityUnlisted)
+	_, invalidUpdateErr := service.SetVisibility(context.Background(), "post-1", "secret")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, entities.PostVisibilityMembers, created.Visibility)
+	assert.Equal(t, AppError.ErrValidationFailed, invalidErr)
+	assert.NoError(t, updateErr)
+	assert.Equal(t, entities.PostVisibilityUnlisted, updated.Visibility)
+	assert.Equal(t, AppError.ErrValidationFailed, invalidUpdateErr)
+	mockRepo.AssertNumberOfCalls(t, "Create", 1)
+	mockRepo.AssertNumberOfCalls(t, "SetVisibility", 1)
+}
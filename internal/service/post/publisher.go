@@ -0,0 +1,53 @@
+package postsvc
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// ScheduledPublisher periodically flips scheduled posts live once their publish time has passed.
+// Posts are published through the post service, so its listeners see them go live.
+type ScheduledPublisher struct {
+	postService *PostService
+	interval    time.Duration
+}
+
+func NewScheduledPublisher(postService *PostService, intervalSeconds int) *ScheduledPublisher {
+	if intervalSeconds <= 0 {
+		intervalSeconds = 60 // Default interval
+	}
+	return &ScheduledPublisher{
+		postService: postService,
+		interval:    time.Duration(intervalSeconds) * time.Second,
+	}
+}
+
+// Start runs the publisher in the background until ctx is cancelled
+func (p *ScheduledPublisher) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+
+		p.publishDue(ctx)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				p.publishDue(ctx)
+			}
+		}
+	}()
+}
+
+func (p *ScheduledPublisher) publishDue(ctx context.Context) {
+	published, err := p.postService.PublishScheduled(ctx, time.Now())
+	if err != nil {
+		log.Printf("Error publishing scheduled posts: %v", err)
+		return
+	}
+	if len(published) > 0 {
+		log.Printf("Published %d scheduled post(s)", len(published))
+	}
+}
@@ -0,0 +1,58 @@
+package postsvc
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// TrashPurger periodically removes posts that have been in the trash longer than the retention period.
+type TrashPurger struct {
+	postService *PostService
+	retention   time.Duration
+	interval    time.Duration
+}
+
+// NewTrashPurger creates a purger that keeps deleted posts for retentionDays (30 by default)
+// and checks for expired ones every intervalSeconds (hourly by default).
+func NewTrashPurger(postService *PostService, retentionDays, intervalSeconds int) *TrashPurger {
+	if retentionDays <= 0 {
+		retentionDays = 30 // Default retention
+	}
+	if intervalSeconds <= 0 {
+		intervalSeconds = 3600 // Default interval
+	}
+	return &TrashPurger{
+		postService: postService,
+		retention:   time.Duration(retentionDays) * 24 * time.Hour,
+		interval:    time.Duration(intervalSeconds) * time.Second,
+	}
+}
+
+// Start runs the purger in the background until ctx is cancelled
+func (p *TrashPurger) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+
+		p.purgeExpired(ctx)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				p.purgeExpired(ctx)
+			}
+		}
+	}()
+}
+
+func (p *TrashPurger) purgeExpired(ctx context.Context) {
+	count, err := p.postService.PurgeTrash(ctx, time.Now().Add(-p.retention))
+	if err != nil {
+		log.Printf("Error purging deleted posts: %v", err)
+	}
+	if count > 0 {
+		log.Printf("Purged %d deleted post(s)", count)
+	}
+}
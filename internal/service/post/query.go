@@ -0,0 +1,160 @@
+package postsvc
+
+import (
+	"strings"
+	"time"
+
+	"anchor-blog/internal/domain/entities"
+	AppError "anchor-blog/internal/errors"
+	"anchor-blog/pkg/tagutil"
+)
+
+const dateLayout = "2006-01-02"
+
+// SearchCriteria is a post search as requested by a client, before the author is resolved.
+// Dates use the YYYY-MM-DD layout; Before is inclusive of the whole day.
+type SearchCriteria struct {
+	Text         string
+	Tags         []string
+	MatchAllTags bool
+	Author       string // username or user id
+	After        string
+	Before       string
+	Sort         []string
+}
+
+// IsEmpty reports whether no filter at all was requested (sorting alone doesn't count)
+func (c SearchCriteria) IsEmpty() bool {
+	return c.Text == "" && len(c.Tags) == 0 && c.Author == "" && c.After == "" && c.Before == ""
+}
+
+// ParseSearchQuery parses the compact query syntax, for example
+//
+//	tag:go tag:web author:alice after:2025-03-01 before:2025-03-31 sort:likes,newest match:all gin "router groups"
+//
+// Recognised keys are tag (or tags, comma-separated), author, after, before, sort and match (any or all).
+// Everything else, including quoted phrases, becomes the free-text part.
+func ParseSearchQuery(raw string) (SearchCriteria, error) {
+	var criteria SearchCriteria
+	var text []string
+
+	for _, token := range tokenize(raw) {
+		key, value, found := strings.Cut(token, ":")
+		if !found || value == "" {
+			text = append(text, token)
+			continue
+		}
+
+		switch strings.ToLower(key) {
+		case "tag", "tags":
+			criteria.Tags = append(criteria.Tags, splitList(value)...)
+		case "author":
+			criteria.Author = value
+		case "after":
+			criteria.After = value
+		case "before":
+			criteria.Before = value
+		case "sort":
+			criteria.Sort = append(criteria.Sort, splitList(strings.ToLower(value))...)
+		case "match":
+			switch strings.ToLower(value) {
+			case "all":
+				criteria.MatchAllTags = true
+			case "any":
+				criteria.MatchAllTags = false
+			default:
+				return SearchCriteria{}, AppError.ErrValidationFailed
+			}
+		default:
+			text = append(text, token)
+		}
+	}
+
+	criteria.Text = strings.Join(text, " ")
+	if _, err := criteria.toPostQuery(); err != nil {
+		return SearchCriteria{}, err
+	}
+	return criteria, nil
+}
+
+// toPostQuery validates the criteria and converts everything except the author
+func (c SearchCriteria) toPostQuery() (entities.PostQuery, error) {
+	query := entities.PostQuery{
+		Text:         strings.TrimSpace(c.Text),
+		MatchAllTags: c.MatchAllTags,
+	}
+
+	query.Tags = tagutil.NormalizeList(c.Tags)
+	if len(query.Tags) == 0 {
+		query.Tags = nil
+	}
+
+	if c.After != "" {
+		after, err := time.Parse(dateLayout, c.After)
+		if err != nil {
+			return entities.PostQuery{}, AppError.ErrValidationFailed
+		}
+		query.After = after
+	}
+	if c.Before != "" {
+		before, err := time.Parse(dateLayout, c.Before)
+		if err != nil {
+			return entities.PostQuery{}, AppError.ErrValidationFailed
+		}
+		// Set before date to end of day
+		query.Before = before.Add(24*time.Hour - time.Nanosecond)
+	}
+	if !query.After.IsZero() && !query.Before.IsZero() && query.After.After(query.Before) {
+		return entities.PostQuery{}, AppError.ErrValidationFailed
+	}
+
+	for _, key := range c.Sort {
+		switch key {
+		case entities.SortNewest, entities.SortOldest, entities.SortViews, entities.SortLikes:
+			query.Sort = append(query.Sort, key)
+		case "":
+		default:
+			return entities.PostQuery{}, AppError.ErrValidationFailed
+		}
+	}
+
+	return query, nil
+}
+
+// tokenize splits on whitespace while keeping double-quoted phrases together
+func tokenize(raw string) []string {
+	var tokens []string
+	var current strings.Builder
+	inQuotes := false
+
+	flush := func() {
+		if current.Len() > 0 {
+			tokens = append(tokens, current.String())
+			current.Reset()
+		}
+	}
+
+	for _, r := range raw {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+		case !inQuotes && (r == ' ' || r == '\t' || r == '\n'):
+			flush()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	flush()
+
+	return tokens
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,60 @@
+package postsvc
+
+import (
+	"testing"
+	"time"
+
+	"anchor-blog/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSearchQuery_CompactSyntax(t *testing.T) {
+	// Execute
+	criteria, err := ParseSearchQuery(`tag:go tags:web,api author:alice after:2025-03-01 before:2025-03-31 sort:likes,newest match:all "router groups" gin`)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"go", "web", "api"}, criteria.Tags)
+	assert.True(t, criteria.MatchAllTags)
+	assert.Equal(t, "alice", criteria.Author)
+	assert.Equal(t, "2025-03-01", criteria.After)
+	assert.Equal(t, "2025-03-31", criteria.Before)
+	assert.Equal(t, []string{"likes", "newest"}, criteria.Sort)
+	assert.Equal(t, "router groups gin", criteria.Text)
+}
+
+func TestParseSearchQuery_PlainText(t *testing.T) {
+	// Execute
+	criteria, err := ParseSearchQuery("getting started")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "getting started", criteria.Text)
+	assert.Empty(t, criteria.Tags)
+	assert.False(t, criteria.IsEmpty())
+}
+
+func TestParseSearchQuery_Invalid(t *testing.T) {
+	invalid := []string{
+		"after:yesterday",
+		"sort:random",
+		"match:some",
+		"after:2025-04-01 before:2025-03-01",
+	}
+
+	for _, raw := range invalid {
+		_, err := ParseSearchQuery(raw)
+		assert.Error(t, err, "query: %q", raw)
+	}
+}
+
+func TestSearchCriteria_ToPostQuery_BeforeCoversWholeDay(t *testing.T) {
+	// Execute
+	query, err := SearchCriteria{Before: "2025-03-31"}.toPostQuery()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 3, 31, 23, 59, 59, 999999999, time.UTC), query.Before)
+	assert.Equal(t, entities.PostQuery{Before: query.Before}, query)
+}
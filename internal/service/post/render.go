@@ -0,0 +1,42 @@
+package postsvc
+
+import (
+	"context"
+	"log"
+
+	"anchor-blog/internal/domain/entities"
+	"anchor-blog/pkg/markdown"
+)
+
+// renderContent converts Markdown content into the form cached on the post
+func renderContent(content string) *entities.RenderedContent {
+	doc := markdown.Render(content)
+
+	toc := make([]entities.TOCEntry, len(doc.TOC))
+	for i, heading := range doc.TOC {
+		toc[i] = entities.TOCEntry{Level: heading.Level, Text: heading.Text, ID: heading.ID}
+	}
+
+	return &entities.RenderedContent{
+		HTML:        doc.HTML,
+		TOC:         toc,
+		Excerpt:     doc.Excerpt,
+		ReadingTime: doc.ReadingTime,
+		Version:     markdown.Version,
+	}
+}
+
+// EnsureRendered fills in the rendered content of a post whose cache is missing
+// or was produced by an older renderer, and stores it for the next reads.
+func (s *PostService) EnsureRendered(ctx context.Context, post *entities.Post) *entities.Post {
+	if post.Rendered != nil && post.Rendered.Version == markdown.Version {
+		return post
+	}
+
+	post.Rendered = renderContent(post.Content)
+	if err := s.postRepo.SaveRendered(ctx, post.ID, post.Rendered); err != nil {
+		// Serving the fresh render matters more than caching it
+		log.Printf("Error caching rendered content of post %s: %v", post.ID, err)
+	}
+	return post
+}
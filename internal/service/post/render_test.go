@@ -0,0 +1,75 @@
+package postsvc
+
+import (
+	"context"
+	"testing"
+
+	"anchor-blog/internal/domain/entities"
+	AppError "anchor-blog/internal/errors"
+	"anchor-blog/pkg/markdown"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPostService_CreatePost_RendersContent(t *testing.T) {
+	// Setup
+	mockRepo := new(MockPostRepository)
+	service := NewPostService(mockRepo, nil)
+
+	// Mock expectations
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(post *entities.Post) bool {
+		return post.Rendered != nil &&
+			post.Rendered.HTML == "<h2 id=\"intro\">Intro</h2>\n<p>Hello <strong>world</strong></p>\n" &&
+			post.Rendered.Excerpt == "Hello world" &&
+			post.Rendered.ReadingTime == 1 &&
+			len(post.Rendered.TOC) == 1 &&
+			post.Rendered.Version == markdown.Version
+	})).Return(&entities.Post{ID: "post-123"}, nil)
+
+	// Execute
+	_, err := service.CreatePost(context.Background(), "Title", "## Intro\n\nHello **world**", "author-123", nil, "")
+
+	// Assert
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPostService_EnsureRendered_UsesCache(t *testing.T) {
+	// Setup
+	mockRepo := new(MockPostRepository)
+	service := NewPostService(mockRepo, nil)
+
+	cached := &entities.RenderedContent{HTML: "<p>cached</p>", Version: markdown.Version}
+	post := &entities.Post{ID: "post-123", Content: "fresh", Rendered: cached}
+
+	// Execute
+	result := service.EnsureRendered(context.Background(), post)
+
+	// Assert
+	assert.Same(t, cached, result.Rendered)
+	mockRepo.AssertNotCalled(t, "SaveRendered", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPostService_EnsureRendered_RefreshesStaleRender(t *testing.T) {
+	// Setup
+	mockRepo := new(MockPostRepository)
+	service := NewPostService(mockRepo, nil)
+
+	post := &entities.Post{
+		ID:       "post-123",
+		Content:  "fresh *content*",
+		Rendered: &entities.RenderedContent{HTML: "<p>old</p>", Version: markdown.Version - 1},
+	}
+
+	// Mock expectations - a failing cache write still returns the render
+	mockRepo.On("SaveRendered", mock.Anything, "post-123", mock.Anything).Return(AppError.ErrInternalServer)
+
+	// Execute
+	result := service.EnsureRendered(context.Background(), post)
+
+	// Assert
+	assert.Equal(t, "<p>fresh <em>content</em></p>\n", result.Rendered.HTML)
+	assert.Equal(t, markdown.Version, result.Rendered.Version)
+	mockRepo.AssertExpectations(t)
+}
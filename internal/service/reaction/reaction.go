@@ -0,0 +1,211 @@
+package reactionsvc
+
+import (
+	"context"
+	"log"
+	"regexp"
+	"strings"
+
+	"anchor-blog/internal/domain/entities"
+	AppError "anchor-blog/internal/errors"
+)
+
+// migrationBatchSize is the number of posts converted per round of the legacy migration
+const migrationBatchSize = 100
+
+// DefaultEmoji are the emoji reactions offered when none are configured
+var DefaultEmoji = []string{"heart", "laugh", "wow", "sad", "celebrate"}
+
+// kinds end up in field names of the post's counters, so they are kept simple
+var kindRe = regexp.MustCompile(`^[a-z0-9_]{1,32}$`)
+
+type ReactionService struct {
+	reactionRepo entities.IReactionRepository
+	postRepo     entities.IPostRepository
+	access       PostAccess
+	kinds        []string
+}
+
+// PostAccess decides who may read a post; the post service implements it
+type PostAccess interface {
+	CheckAccess(ctx context.Context, post *entities.Post, viewerID string) error
+}
+
+// NewReactionService creates a new reaction service offering like, dislike and the given emoji.
+// Users can only react to posts access lets them open.
+// A nil emoji list falls back to DefaultEmoji; invalid names are skipped.
+func NewReactionService(reactionRepo entities.IReactionRepository, postRepo entities.IPostRepository, access PostAccess, emoji []string) *ReactionService {
+	if emoji == nil {
+		emoji = DefaultEmoji
+	}
+
+	kinds := []string{entities.ReactionLike, entities.ReactionDislike}
+	for _, kind := range emoji {
+		kind = strings.ToLower(strings.TrimSpace(kind))
+		if !kindRe.MatchString(kind) || contains(kinds, kind) {
+			log.Printf("Ignoring reaction kind %q", kind)
+			continue
+		}
+		kinds = append(kinds, kind)
+	}
+
+	return &ReactionService{
+		reactionRepo: reactionRepo,
+		postRepo:     postRepo,
+		access:       access,
+		kinds:        kinds,
+	}
+}
+
+// Kinds returns the reaction kinds users can choose from
+func (s *ReactionService) Kinds() []string {
+	return append([]string(nil), s.kinds...)
+}
+
+// React adds the user's reaction to a post they can see. A like replaces a dislike and the other way round.
+func (s *ReactionService) React(ctx context.Context, postID, userID, kind string) error {
+	if !contains(s.kinds, kind) {
+		return AppError.ErrValidationFailed
+	}
+
+	posts, err := s.postRepo.FindByIDs(ctx, []string{postID})
+	if err != nil {
+		return err
+	}
+	if len(posts) == 0 {
+		return AppError.ErrNotFound
+	}
+	if err := s.access.CheckAccess(ctx, posts[0], userID); err != nil {
+		return err
+	}
+
+	switch kind {
+	case entities.ReactionLike:
+		if err := s.remove(ctx, postID, userID, entities.ReactionDislike); err != nil {
+			return err
+		}
+	case entities.ReactionDislike:
+		if err := s.remove(ctx, postID, userID, entities.ReactionLike); err != nil {
+			return err
+		}
+	}
+
+	added, err := s.reactionRepo.Add(ctx, &entities.Reaction{PostID: postID, UserID: userID, Kind: kind})
+	if err != nil || !added {
+		return err
+	}
+	return s.postRepo.IncrementReactionCount(ctx, postID, kind, 1)
+}
+
+// Unreact removes the user's reaction; removing a reaction that isn't there is not an error.
+// Reactions on posts in the trash are kept, so the counters still match once the post is restored.
+func (s *ReactionService) Unreact(ctx context.Context, postID, userID, kind string) error {
+	if !contains(s.kinds, kind) {
+		return AppError.ErrValidationFailed
+	}
+
+	posts, err := s.postRepo.FindByIDs(ctx, []string{postID})
+	if err != nil {
+		return err
+	}
+	if len(posts) == 0 {
+		return AppError.ErrNotFound
+	}
+	return s.remove(ctx, postID, userID, kind)
+}
+
+// remove takes back a reaction and its count
+func (s *ReactionService) remove(ctx context.Context, postID, userID, kind string) error {
+	removed, err := s.reactionRepo.Remove(ctx, postID, userID, kind)
+	if err != nil || !removed {
+		return err
+	}
+	return s.postRepo.IncrementReactionCount(ctx, postID, kind, -1)
+}
+
+// ReactionStatus is what a user left on a post
+type ReactionStatus struct {
+	Liked    bool
+	Disliked bool
+	Kinds    []string // every kind, like and dislike included
+}
+
+// GetLikeStatus tells whether the user likes or dislikes a post, along with their other reactions
+func (s *ReactionService) GetLikeStatus(ctx context.Context, postID, userID string) (*ReactionStatus, error) {
+	kinds, err := s.reactionRepo.FindKinds(ctx, postID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &ReactionStatus{
+		Liked:    contains(kinds, entities.ReactionLike),
+		Disliked: contains(kinds, entities.ReactionDislike),
+		Kinds:    kinds,
+	}, nil
+}
+
+// DeletePostReactions removes the reactions of a deleted post
+func (s *ReactionService) DeletePostReactions(ctx context.Context, postID string) error {
+	return s.reactionRepo.DeleteByPostID(ctx, postID)
+}
+
+// MigrateLegacyReactions moves the like and dislike arrays of older posts into the reaction
+// store and replaces them with counters. Each post is converted on its own and the steps are
+// idempotent, so an interrupted run is completed by the next one. It returns the number of posts migrated.
+func (s *ReactionService) MigrateLegacyReactions(ctx context.Context) (int, error) {
+	migrated := 0
+	for {
+		batch, err := s.postRepo.FindLegacyReactions(ctx, migrationBatchSize)
+		if err != nil {
+			return migrated, err
+		}
+		if len(batch) == 0 {
+			return migrated, nil
+		}
+
+		for _, legacy := range batch {
+			if err := s.migratePost(ctx, legacy); err != nil {
+				return migrated, err
+			}
+			migrated++
+		}
+	}
+}
+
+func (s *ReactionService) migratePost(ctx context.Context, legacy *entities.LegacyReactions) error {
+	disliked := make(map[string]bool, len(legacy.Dislikes))
+	for _, userID := range legacy.Dislikes {
+		disliked[userID] = true
+	}
+
+	reactions := make([]*entities.Reaction, 0, len(legacy.Likes)+len(legacy.Dislikes))
+	for _, userID := range legacy.Likes {
+		// the arrays were meant to be exclusive; a user found in both keeps the like
+		delete(disliked, userID)
+		reactions = append(reactions, &entities.Reaction{PostID: legacy.PostID, UserID: userID, Kind: entities.ReactionLike})
+	}
+	for _, userID := range legacy.Dislikes {
+		if disliked[userID] {
+			reactions = append(reactions, &entities.Reaction{PostID: legacy.PostID, UserID: userID, Kind: entities.ReactionDislike})
+		}
+	}
+
+	if err := s.reactionRepo.AddMany(ctx, reactions); err != nil {
+		return err
+	}
+
+	// counting the store also covers reactions added since the arrays stopped being written
+	counts, err := s.reactionRepo.CountByPost(ctx, legacy.PostID)
+	if err != nil {
+		return err
+	}
+	return s.postRepo.ClearLegacyReactions(ctx, legacy.PostID, counts)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
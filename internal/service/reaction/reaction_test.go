@@ -0,0 +1,215 @@
+package reactionsvc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"anchor-blog/internal/domain/entities"
+	AppError "anchor-blog/internal/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockReactionRepository struct {
+	mock.Mock
+}
+
+func (m *MockReactionRepository) Add(ctx context.Context, reaction *entities.Reaction) (bool, error) {
+	args := m.Called(ctx, reaction)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockReactionRepository) AddMany(ctx context.Context, reactions []*entities.Reaction) error {
+	args := m.Called(ctx, reactions)
+	return args.Error(0)
+}
+
+func (m *MockReactionRepository) Remove(ctx context.Context, postID, userID, kind string) (bool, error) {
+	args := m.Called(ctx, postID, userID, kind)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockReactionRepository) FindKinds(ctx context.Context, postID, userID string) ([]string, error) {
+	args := m.Called(ctx, postID, userID)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockReactionRepository) CountByPost(ctx context.Context, postID string) (map[string]int, error) {
+	args := m.Called(ctx, postID)
+	return args.Get(0).(map[string]int), args.Error(1)
+}
+
+func (m *MockReactionRepository) CountSince(ctx context.Context, kind string, since time.Time) (map[string]int, error) {
+	args := m.Called(ctx, kind, since)
+	return args.Get(0).(map[string]int), args.Error(1)
+}
+
+func (m *MockReactionRepository) DeleteByPostID(ctx context.Context, postID string) error {
+	args := m.Called(ctx, postID)
+	return args.Error(0)
+}
+
+// Mock post repository; only the lookup and the reaction counters are used by the reaction service
+type MockPostRepository struct {
+	entities.IPostRepository
+	mock.Mock
+}
+
+func (m *MockPostRepository) FindByIDs(ctx context.Context, ids []string) ([]*entities.Post, error) {
+	args := m.Called(ctx, ids)
+	return args.Get(0).([]*entities.Post), args.Error(1)
+}
+
+func (m *MockPostRepository) IncrementReactionCount(ctx context.Context, postID, kind string, delta int) error {
+	args := m.Called(ctx, postID, kind, delta)
+	return args.Error(0)
+}
+
+func (m *MockPostRepository) FindLegacyReactions(ctx context.Context, limit int) ([]*entities.LegacyReactions, error) {
+	args := m.Called(ctx, limit)
+	return args.Get(0).([]*entities.LegacyReactions), args.Error(1)
+}
+
+func (m *MockPostRepository) ClearLegacyReactions(ctx context.Context, postID string, counts map[string]int) error {
+	args := m.Called(ctx, postID, counts)
+	return args.Error(0)
+}
+
+// postAccess lets a reader open the posts Post.CanView allows, like the post service does
+type postAccess struct{}
+
+func (postAccess) CheckAccess(ctx context.Context, post *entities.Post, viewerID string) error {
+	if !post.CanView(viewerID, false) {
+		return AppError.ErrNotFound
+	}
+	return nil
+}
+
+func publishedPost(id string) []*entities.Post {
+	return []*entities.Post{{ID: id, Status: entities.PostStatusPublished}}
+}
+
+func TestReactionService_Kinds(t *testing.T) {
+	service := NewReactionService(nil, nil, postAccess{}, []string{" Heart ", "like", "bad kind!", "rocket"})
+
+	assert.Equal(t, []string{"like", "dislike", "heart", "rocket"}, service.Kinds())
+	assert.Equal(t, append([]string{"like", "dislike"}, DefaultEmoji...), NewReactionService(nil, nil, postAccess{}, nil).Kinds())
+}
+
+func TestReactionService_LikeReplacesDislike(t *testing.T) {
+	// Setup
+	reactionRepo := new(MockReactionRepository)
+	postRepo := new(MockPostRepository)
+	service := NewReactionService(reactionRepo, postRepo, postAccess{}, nil)
+
+	postRepo.On("FindByIDs", mock.Anything, []string{"post-1"}).Return(publishedPost("post-1"), nil)
+	reactionRepo.On("Remove", mock.Anything, "post-1", "user-1", entities.ReactionDislike).Return(true, nil)
+	postRepo.On("IncrementReactionCount", mock.Anything, "post-1", entities.ReactionDislike, -1).Return(nil)
+	reactionRepo.On("Add", mock.Anything, &entities.Reaction{PostID: "post-1", UserID: "user-1", Kind: entities.ReactionLike}).Return(true, nil)
+	postRepo.On("IncrementReactionCount", mock.Anything, "post-1", entities.ReactionLike, 1).Return(nil)
+
+	// Execute
+	err := service.React(context.Background(), "post-1", "user-1", entities.ReactionLike)
+
+	// Assert
+	assert.NoError(t, err)
+	reactionRepo.AssertExpectations(t)
+	postRepo.AssertExpectations(t)
+}
+
+func TestReactionService_ReactTwiceCountsOnce(t *testing.T) {
+	// Setup
+	reactionRepo := new(MockReactionRepository)
+	postRepo := new(MockPostRepository)
+	service := NewReactionService(reactionRepo, postRepo, postAccess{}, nil)
+
+	postRepo.On("FindByIDs", mock.Anything, []string{"post-1"}).Return(publishedPost("post-1"), nil)
+	reactionRepo.On("Add", mock.Anything, mock.Anything).Return(false, nil)
+
+	// Execute
+	err := service.React(context.Background(), "post-1", "user-1", "heart")
+
+	// Assert
+	assert.NoError(t, err)
+	postRepo.AssertNotCalled(t, "IncrementReactionCount", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	reactionRepo.AssertNotCalled(t, "Remove", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestReactionService_React_UnknownKindAndMissingPost(t *testing.T) {
+	// Setup
+	postRepo := new(MockPostRepository)
+	service := NewReactionService(new(MockReactionRepository), postRepo, postAccess{}, nil)
+
+	postRepo.On("FindByIDs", mock.Anything, []string{"post-9"}).Return([]*entities.Post{}, nil)
+	postRepo.On("FindByIDs", mock.Anything, []string{"private"}).Return([]*entities.Post{{ID: "private", AuthorID: "author", Status: entities.PostStatusPublished, Visibility: entities.PostVisibilityPrivate}}, nil)
+	postRepo.On("FindByIDs", mock.Anything, []string{"draft"}).Return([]*entities.Post{{ID: "draft", AuthorID: "user-1", Status: entities.PostStatusDraft}}, nil)
+
+	// Execute & Assert
+	assert.Equal(t, AppError.ErrValidationFailed, service.React(context.Background(), "post-1", "user-1", "rocket"))
+	assert.Equal(t, AppError.ErrNotFound, service.React(context.Background(), "post-9", "user-1", entities.ReactionLike))
+	assert.Equal(t, AppError.ErrNotFound, service.React(context.Background(), "private", "user-1", entities.ReactionLike))
+	assert.Equal(t, AppError.ErrNotFound, service.React(context.Background(), "draft", "user-1", entities.ReactionLike))
+}
+
+func TestReactionService_Unreact_TrashedPost(t *testing.T) {
+	// Setup
+	reactionRepo := new(MockReactionRepository)
+	postRepo := new(MockPostRepository)
+	service := NewReactionService(reactionRepo, postRepo, postAccess{}, nil)
+
+	postRepo.On("FindByIDs", mock.Anything, []string{"trashed"}).Return([]*entities.Post{}, nil)
+
+	// Execute
+	err := service.Unreact(context.Background(), "trashed", "user-1", entities.ReactionLike)
+
+	// Assert: the reaction stays so the counter is right once the post is restored
+	assert.Equal(t, AppError.ErrNotFound, err)
+	reactionRepo.AssertNotCalled(t, "Remove", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestReactionService_GetLikeStatus(t *testing.T) {
+	// Setup
+	reactionRepo := new(MockReactionRepository)
+	service := NewReactionService(reactionRepo, new(MockPostRepository), postAccess{}, nil)
+
+	reactionRepo.On("FindKinds", mock.Anything, "post-1", "user-1").Return([]string{"heart", "like"}, nil)
+
+	// Execute
+	status, err := service.GetLikeStatus(context.Background(), "post-1", "user-1")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, status.Liked)
+	assert.False(t, status.Disliked)
+	assert.Equal(t, []string{"heart", "like"}, status.Kinds)
+}
+
+func TestReactionService_MigrateLegacyReactions(t *testing.T) {
+	// Setup
+	reactionRepo := new(MockReactionRepository)
+	postRepo := new(MockPostRepository)
+	service := NewReactionService(reactionRepo, postRepo, postAccess{}, nil)
+
+	legacy := &entities.LegacyReactions{PostID: "post-1", Likes: []string{"user-1", "user-2"}, Dislikes: []string{"user-2", "user-3"}}
+	postRepo.On("FindLegacyReactions", mock.Anything, migrationBatchSize).Return([]*entities.LegacyReactions{legacy}, nil).Once()
+	postRepo.On("FindLegacyReactions", mock.Anything, migrationBatchSize).Return([]*entities.LegacyReactions{}, nil).Once()
+	reactionRepo.On("AddMany", mock.Anything, []*entities.Reaction{
+		{PostID: "post-1", UserID: "user-1", Kind: entities.ReactionLike},
+		{PostID: "post-1", UserID: "user-2", Kind: entities.ReactionLike},
+		{PostID: "post-1", UserID: "user-3", Kind: entities.ReactionDislike},
+	}).Return(nil)
+	counts := map[string]int{entities.ReactionLike: 2, entities.ReactionDislike: 1}
+	reactionRepo.On("CountByPost", mock.Anything, "post-1").Return(counts, nil)
+	postRepo.On("ClearLegacyReactions", mock.Anything, "post-1", counts).Return(nil)
+
+	// Execute
+	migrated, err := service.MigrateLegacyReactions(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, migrated)
+	reactionRepo.AssertExpectations(t)
+	postRepo.AssertExpectations(t)
+}
@@ -0,0 +1,246 @@
+package relatedsvc
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"anchor-blog/internal/domain/entities"
+)
+
+// Weights of the three signals; each signal is between 0 and 1
+const (
+	tagWeight    = 3.0
+	authorWeight = 1.0
+	textWeight   = 2.0
+)
+
+const (
+	MaxRelated = 20 // posts kept per cached ranking and the largest limit served
+
+	tagCandidates    = 200
+	authorCandidates = 50
+	recentCandidates = 200
+	defaultCacheTTL  = 10 * time.Minute
+)
+
+type RelatedService struct {
+	postRepo entities.IPostRepository
+	access   PostAccess
+	ttl      time.Duration
+
+	mu         sync.Mutex
+	cache      map[string]cachedRanking
+	generation uint64 // bumped on every invalidation, so rankings computed before it are not stored
+}
+
+type cachedRanking struct {
+	postIDs   []string
+	expiresAt time.Time
+}
+
+// PostAccess decides who may read a post; the post service implements it
+type PostAccess interface {
+	CheckAccess(ctx context.Context, post *entities.Post, viewerID string) error
+}
+
+// NewRelatedService creates a new related posts service. Related posts are only shown
+// to readers access lets open the post. Rankings are cached in memory for ttlSeconds,
+// ten minutes when zero.
+func NewRelatedService(postRepo entities.IPostRepository, access PostAccess, ttlSeconds int) *RelatedService {
+	ttl := time.Duration(ttlSeconds) * time.Second
+	if ttl <= 0 {
+		ttl = defaultCacheTTL
+	}
+	return &RelatedService{
+		postRepo: postRepo,
+		access:   access,
+		ttl:      ttl,
+		cache:    make(map[string]cachedRanking),
+	}
+}
+
+// GetRelated returns up to limit listed posts similar to the given one, most similar first.
+// viewerID is empty for anonymous readers.
+func (s *RelatedService) GetRelated(ctx context.Context, postID, viewerID string, limit int) ([]*entities.Post, error) {
+	if limit <= 0 || limit > MaxRelated {
+		limit = 5
+	}
+
+	// checked on every call, as the ranking is cached for whoever asks next
+	post, err := s.postRepo.FindByID(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.access.CheckAccess(ctx, post, viewerID); err != nil {
+		return nil, err
+	}
+
+	postIDs, ok := s.cached(postID)
+	if !ok {
+		generation := s.currentGeneration()
+		postIDs, err = s.rank(ctx, post)
+		if err != nil {
+			return nil, err
+		}
+		s.store(postID, postIDs, generation)
+	}
+
+	if len(postIDs) > limit {
+		postIDs = postIDs[:limit]
+	}
+	posts, err := s.postRepo.FindByIDs(ctx, postIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// a cached ranking may point at posts unpublished or unlisted since
+	related := make([]*entities.Post, 0, len(posts))
+	for _, post := range posts {
+		if post.IsListed() {
+			related = append(related, post)
+		}
+	}
+	return related, nil
+}
+
+// PostChanged drops every cached ranking: a changed post moves in the rankings of the others too
+func (s *RelatedService) PostChanged(postID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.generation++
+	s.cache = make(map[string]cachedRanking)
+}
+
+// rank scores the candidates sharing a tag or the author with the post, plus the latest posts
+// so that posts only similar in wording are found too
+func (s *RelatedService) rank(ctx context.Context, post *entities.Post) ([]string, error) {
+	candidates, err := s.candidates(ctx, post)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		return []string{}, nil
+	}
+
+	docs := make([]map[string]int, 0, len(candidates)+1)
+	docs = append(docs, termFrequencies(post.Title, post.Content))
+	for _, candidate := range candidates {
+		docs = append(docs, termFrequencies(candidate.Title, candidate.Content))
+	}
+	vectors := tfidfVectors(docs)
+
+	type scored struct {
+		post  *entities.Post
+		score float64
+	}
+	ranked := make([]scored, 0, len(candidates))
+	for i, candidate := range candidates {
+		score := tagWeight*tagOverlap(post.Tags, candidate.Tags) + textWeight*cosine(vectors[0], vectors[i+1])
+		if candidate.AuthorID == post.AuthorID {
+			score += authorWeight
+		}
+		if score > 0 {
+			ranked = append(ranked, scored{candidate, score})
+		}
+	}
+
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].score != ranked[j].score {
+			return ranked[i].score > ranked[j].score
+		}
+		if !ranked[i].post.CreatedAt.Equal(ranked[j].post.CreatedAt) {
+			return ranked[i].post.CreatedAt.After(ranked[j].post.CreatedAt)
+		}
+		return ranked[i].post.ID < ranked[j].post.ID
+	})
+	if len(ranked) > MaxRelated {
+		ranked = ranked[:MaxRelated]
+	}
+
+	postIDs := make([]string, len(ranked))
+	for i, r := range ranked {
+		postIDs[i] = r.post.ID
+	}
+	return postIDs, nil
+}
+
+// candidates collects published posts other than the post itself, each once
+func (s *RelatedService) candidates(ctx context.Context, post *entities.Post) ([]*entities.Post, error) {
+	var lists [][]*entities.Post
+
+	if len(post.Tags) > 0 {
+		byTag, err := s.postRepo.Query(ctx, entities.PostQuery{Tags: post.Tags}, entities.PaginationOptions{Page: 1, Limit: tagCandidates})
+		if err != nil {
+			return nil, err
+		}
+		lists = append(lists, byTag)
+	}
+
+	byAuthor, err := s.postRepo.Query(ctx, entities.PostQuery{AuthorID: post.AuthorID}, entities.PaginationOptions{Page: 1, Limit: authorCandidates})
+	if err != nil {
+		return nil, err
+	}
+	recent, err := s.postRepo.FindAll(ctx, entities.PaginationOptions{Page: 1, Limit: recentCandidates})
+	if err != nil {
+		return nil, err
+	}
+	lists = append(lists, byAuthor, recent)
+
+	seen := map[string]bool{post.ID: true}
+	var candidates []*entities.Post
+	for _, list := range lists {
+		for _, candidate := range list {
+			if !seen[candidate.ID] {
+				seen[candidate.ID] = true
+				candidates = append(candidates, candidate)
+			}
+		}
+	}
+	return candidates, nil
+}
+
+// tagOverlap is the Jaccard index of two tag sets. Tags are normalized on save, so a post holds each once.
+func tagOverlap(a, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	set := make(map[string]bool, len(a))
+	for _, tag := range a {
+		set[tag] = true
+	}
+	shared := 0
+	for _, tag := range b {
+		if set[tag] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+func (s *RelatedService) cached(postID string) ([]string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.cache[postID]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, false
+	}
+	return entry.postIDs, true
+}
+
+func (s *RelatedService) currentGeneration() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.generation
+}
+
+func (s *RelatedService) store(postID string, postIDs []string, generation uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if generation != s.generation {
+		return
+	}
+	s.cache[postID] = cachedRanking{postIDs: postIDs, expiresAt: time.Now().Add(s.ttl)}
+}
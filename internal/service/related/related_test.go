@@ -0,0 +1,156 @@
+package relatedsvc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"anchor-blog/internal/domain/entities"
+	AppError "anchor-blog/internal/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock post repository; only the lookups used to find candidates are implemented
+type MockPostRepository struct {
+	entities.IPostRepository
+	mock.Mock
+}
+
+func (m *MockPostRepository) FindByID(ctx context.Context, id string) (*entities.Post, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(*entities.Post), args.Error(1)
+}
+
+func (m *MockPostRepository) FindByIDs(ctx context.Context, ids []string) ([]*entities.Post, error) {
+	args := m.Called(ctx, ids)
+	return args.Get(0).([]*entities.Post), args.Error(1)
+}
+
+func (m *MockPostRepository) FindAll(ctx context.Context, opts entities.PaginationOptions) ([]*entities.Post, error) {
+	args := m.Called(ctx, opts)
+	return args.Get(0).([]*entities.Post), args.Error(1)
+}
+
+func (m *MockPostRepository) Query(ctx context.Context, query entities.PostQuery, opts entities.PaginationOptions) ([]*entities.Post, error) {
+	args := m.Called(ctx, query, opts)
+	return args.Get(0).([]*entities.Post), args.Error(1)
+}
+
+var (
+	source = &entities.Post{ID: "source", AuthorID: "alice", Title: "Goroutines and channels", Content: "Concurrency in Go with goroutines, channels and select.", Tags: []string{"go", "concurrency"}}
+
+	sameTags   = &entities.Post{ID: "same-tags", AuthorID: "bob", Title: "Worker pools", Content: "Bounded parallel work.", Tags: []string{"go", "concurrency"}}
+	sameText   = &entities.Post{ID: "same-text", AuthorID: "carol", Title: "Channels explained", Content: "Buffered channels, unbuffered channels and select over goroutines."}
+	sameAuthor = &entities.Post{ID: "same-author", AuthorID: "alice", Title: "My garden", Content: "Tomatoes this summer."}
+	unrelated  = &entities.Post{ID: "unrelated", AuthorID: "dave", Title: "Baking bread", Content: "Flour, water, salt and patience."}
+)
+
+// postAccess lets a reader open the posts Post.CanView allows, like the post service does
+type postAccess struct{}
+
+func (postAccess) CheckAccess(ctx context.Context, post *entities.Post, viewerID string) error {
+	if !post.CanView(viewerID, false) {
+		return AppError.ErrNotFound
+	}
+	return nil
+}
+
+func setupCandidates(postRepo *MockPostRepository) {
+	postRepo.On("FindByID", mock.Anything, "source").Return(source, nil)
+	postRepo.On("Query", mock.Anything, entities.PostQuery{Tags: source.Tags}, mock.Anything).Return([]*entities.Post{source, sameTags}, nil)
+	postRepo.On("Query", mock.Anything, entities.PostQuery{AuthorID: "alice"}, mock.Anything).Return([]*entities.Post{source, sameAuthor}, nil)
+	postRepo.On("FindAll", mock.Anything, mock.Anything).Return([]*entities.Post{unrelated, sameText, sameTags, source}, nil)
+}
+
+func TestRelatedService_GetRelated_RanksBySignals(t *testing.T) {
+	// Setup
+	postRepo := new(MockPostRepository)
+	service := NewRelatedService(postRepo, postAccess{}, 0)
+	setupCandidates(postRepo)
+
+	var ranked []string
+	postRepo.On("FindByIDs", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		ranked = args.Get(1).([]string)
+	}).Return([]*entities.Post{sameTags, sameText, sameAuthor}, nil)
+
+	// Execute
+	posts, err := service.GetRelated(context.Background(), "source", "", 10)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, posts, 3)
+	assert.Equal(t, []string{"same-tags", "same-text", "same-author"}, ranked)
+}
+
+func TestRelatedService_GetRelated_CachedUntilPostChanged(t *testing.T) {
+	// Setup
+	postRepo := new(MockPostRepository)
+	service := NewRelatedService(postRepo, postAccess{}, 0)
+	setupCandidates(postRepo)
+	postRepo.On("FindByIDs", mock.Anything, mock.Anything).Return([]*entities.Post{sameTags}, nil)
+
+	// Execute
+	_, err := service.GetRelated(context.Background(), "source", "", 1)
+	assert.NoError(t, err)
+	_, err = service.GetRelated(context.Background(), "source", "", 1)
+	assert.NoError(t, err)
+
+	// Assert
+	postRepo.AssertNumberOfCalls(t, "FindAll", 1)
+
+	service.PostChanged("same-tags")
+	_, err = service.GetRelated(context.Background(), "source", "", 1)
+	assert.NoError(t, err)
+	postRepo.AssertNumberOfCalls(t, "FindAll", 2)
+}
+
+func TestRelatedService_GetRelated_CacheExpires(t *testing.T) {
+	service := NewRelatedService(new(MockPostRepository), postAccess{}, 60)
+	service.store("source", []string{"same-tags"}, 0)
+
+	_, ok := service.cached("source")
+	assert.True(t, ok)
+
+	service.cache["source"] = cachedRanking{postIDs: []string{"same-tags"}, expiresAt: time.Now().Add(-time.Second)}
+	_, ok = service.cached("source")
+	assert.False(t, ok)
+}
+
+func TestRelatedService_GetRelated_UnpublishedPost(t *testing.T) {
+	// Setup
+	postRepo := new(MockPostRepository)
+	service := NewRelatedService(postRepo, postAccess{}, 0)
+	postRepo.On("FindByID", mock.Anything, "draft").Return(&entities.Post{ID: "draft", Status: entities.PostStatusDraft}, nil)
+
+	// Execute
+	posts, err := service.GetRelated(context.Background(), "draft", "", 5)
+
+	// Assert
+	assert.Equal(t, AppError.ErrNotFound, err)
+	assert.Nil(t, posts)
+}
+
+func TestRelatedService_GetRelated_CachedRankingStillChecksAccess(t *testing.T) {
+	// Setup
+	postRepo := new(MockPostRepository)
+	service := NewRelatedService(postRepo, postAccess{}, 0)
+	private := &entities.Post{ID: "source", AuthorID: "alice", Visibility: entities.PostVisibilityPrivate}
+	postRepo.On("FindByID", mock.Anything, "source").Return(private, nil)
+	service.store("source", []string{"same-tags"}, service.currentGeneration())
+
+	// Execute
+	posts, err := service.GetRelated(context.Background(), "source", "bob", 5)
+
+	// Assert
+	assert.Equal(t, AppError.ErrNotFound, err)
+	assert.Nil(t, posts)
+	postRepo.AssertNotCalled(t, "FindByIDs", mock.Anything, mock.Anything)
+}
+
+func TestTokenize(t *testing.T) {
+	tokens := tokenize("## Why *Go* is great: the `select` statement, and 2025's Go 1.24!")
+
+	assert.Equal(t, []string{"why", "great", "select", "statement", "2025"}, tokens)
+}
@@ -0,0 +1,97 @@
+package relatedsvc
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// titleBoost counts title words several times, since a title says more about a post than any sentence of it
+const titleBoost = 3
+
+// stopWords are too common to tell posts apart
+var stopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "are": true, "but": true, "not": true, "you": true,
+	"all": true, "any": true, "can": true, "has": true, "had": true, "her": true, "was": true,
+	"one": true, "our": true, "out": true, "his": true, "how": true, "its": true, "who": true,
+	"did": true, "get": true, "may": true, "use": true, "she": true, "him": true, "now": true,
+	"this": true, "that": true, "with": true, "from": true, "have": true, "they": true,
+	"will": true, "your": true, "what": true, "when": true, "there": true, "their": true,
+	"which": true, "about": true, "would": true, "these": true, "into": true, "than": true,
+	"then": true, "them": true, "been": true, "were": true, "also": true, "more": true,
+	"some": true, "just": true, "like": true, "only": true, "over": true, "such": true,
+	"here": true, "very": true, "does": true, "each": true, "most": true, "other": true,
+}
+
+// termVector is a document's tf-idf weight per term
+type termVector map[string]float64
+
+// tokenize lowercases the text and splits it into words of at least three letters or digits.
+// Markdown punctuation falls away with the separators.
+func tokenize(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	tokens := words[:0]
+	for _, word := range words {
+		if len([]rune(word)) >= 3 && !stopWords[word] {
+			tokens = append(tokens, word)
+		}
+	}
+	return tokens
+}
+
+// termFrequencies counts the terms of a post, title words boosted
+func termFrequencies(title, content string) map[string]int {
+	counts := make(map[string]int)
+	for _, token := range tokenize(title) {
+		counts[token] += titleBoost
+	}
+	for _, token := range tokenize(content) {
+		counts[token]++
+	}
+	return counts
+}
+
+// tfidfVectors weighs the terms of every document against the whole corpus
+func tfidfVectors(docs []map[string]int) []termVector {
+	docFreq := make(map[string]int)
+	for _, doc := range docs {
+		for term := range doc {
+			docFreq[term]++
+		}
+	}
+
+	vectors := make([]termVector, len(docs))
+	for i, doc := range docs {
+		total := 0
+		for _, count := range doc {
+			total += count
+		}
+
+		vector := make(termVector, len(doc))
+		for term, count := range doc {
+			idf := math.Log(float64(1+len(docs)) / float64(1+docFreq[term]))
+			vector[term] = float64(count) / float64(total) * idf
+		}
+		vectors[i] = vector
+	}
+	return vectors
+}
+
+// cosine returns the cosine similarity of two vectors, between 0 and 1
+func cosine(a, b termVector) float64 {
+	var dot, normA, normB float64
+	for term, weight := range a {
+		normA += weight * weight
+		dot += weight * b[term]
+	}
+	for _, weight := range b {
+		normB += weight * weight
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
@@ -0,0 +1,132 @@
+package revisionsvc
+
+import (
+	"context"
+
+	"anchor-blog/internal/domain/entities"
+	AppError "anchor-blog/internal/errors"
+	"anchor-blog/pkg/diffutil"
+)
+
+type RevisionService struct {
+	revisionRepo entities.IRevisionRepository
+}
+
+// NewRevisionService creates a new revision service.
+func NewRevisionService(repo entities.IRevisionRepository) *RevisionService {
+	return &RevisionService{revisionRepo: repo}
+}
+
+// RevisionDiff describes what changed between two revisions of a post
+type RevisionDiff struct {
+	From         int             `json:"from"`
+	To           int             `json:"to"`
+	TitleChanged bool            `json:"title_changed"`
+	OldTitle     string          `json:"old_title"`
+	NewTitle     string          `json:"new_title"`
+	AddedTags    []string        `json:"added_tags"`
+	RemovedTags  []string        `json:"removed_tags"`
+	Content      []diffutil.Line `json:"content"`
+}
+
+// RecordCreate stores the original version of a newly created post
+func (s *RevisionService) RecordCreate(ctx context.Context, post *entities.Post) (*entities.PostRevision, error) {
+	return s.revisionRepo.Create(ctx, snapshot(post, post.AuthorID, 0))
+}
+
+// RecordUpdate stores the new state of an edited post. Posts that predate revision
+// history get their previous state recorded first so the edit can still be diffed.
+func (s *RevisionService) RecordUpdate(ctx context.Context, before, after *entities.Post, editorID string, restoredFrom int) (*entities.PostRevision, error) {
+	count, err := s.revisionRepo.CountByPost(ctx, after.ID)
+	if err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		baseline := snapshot(before, before.AuthorID, 0)
+		baseline.CreatedAt = before.UpdatedAt
+		if _, err := s.revisionRepo.Create(ctx, baseline); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.revisionRepo.Create(ctx, snapshot(after, editorID, restoredFrom))
+}
+
+// ListRevisions lists the revisions of a post, newest first
+func (s *RevisionService) ListRevisions(ctx context.Context, postID string, page, limit int64) ([]*entities.PostRevision, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = 20
+	}
+
+	opts := entities.PaginationOptions{
+		Page:  page,
+		Limit: limit,
+	}
+
+	return s.revisionRepo.FindByPost(ctx, postID, opts)
+}
+
+// GetRevision returns a single revision of a post
+func (s *RevisionService) GetRevision(ctx context.Context, postID string, number int) (*entities.PostRevision, error) {
+	if number <= 0 {
+		return nil, AppError.ErrValidationFailed
+	}
+	return s.revisionRepo.FindByNumber(ctx, postID, number)
+}
+
+// Diff computes a line-level diff of the content between two revisions, plus title and tag changes
+func (s *RevisionService) Diff(ctx context.Context, postID string, from, to int) (*RevisionDiff, error) {
+	oldRevision, err := s.GetRevision(ctx, postID, from)
+	if err != nil {
+		return nil, err
+	}
+	newRevision, err := s.GetRevision(ctx, postID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RevisionDiff{
+		From:         from,
+		To:           to,
+		TitleChanged: oldRevision.Title != newRevision.Title,
+		OldTitle:     oldRevision.Title,
+		NewTitle:     newRevision.Title,
+		AddedTags:    missingFrom(newRevision.Tags, oldRevision.Tags),
+		RemovedTags:  missingFrom(oldRevision.Tags, newRevision.Tags),
+		Content:      diffutil.Lines(oldRevision.Content, newRevision.Content),
+	}, nil
+}
+
+// DeletePostRevisions removes the history of a deleted post
+func (s *RevisionService) DeletePostRevisions(ctx context.Context, postID string) error {
+	return s.revisionRepo.DeleteByPostID(ctx, postID)
+}
+
+func snapshot(post *entities.Post, editorID string, restoredFrom int) *entities.PostRevision {
+	return &entities.PostRevision{
+		PostID:       post.ID,
+		EditorID:     editorID,
+		Title:        post.Title,
+		Content:      post.Content,
+		Tags:         post.Tags,
+		RestoredFrom: restoredFrom,
+	}
+}
+
+// missingFrom returns the tags of a that are not in b
+func missingFrom(a, b []string) []string {
+	seen := make(map[string]bool, len(b))
+	for _, tag := range b {
+		seen[tag] = true
+	}
+	result := []string{}
+	for _, tag := range a {
+		if !seen[tag] {
+			result = append(result, tag)
+		}
+	}
+	return result
+}
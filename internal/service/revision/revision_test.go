@@ -0,0 +1,124 @@
+package revisionsvc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"anchor-blog/internal/domain/entities"
+	"anchor-blog/pkg/diffutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock RevisionRepository for testing
+type MockRevisionRepository struct {
+	mock.Mock
+}
+
+func (m *MockRevisionRepository) Create(ctx context.Context, revision *entities.PostRevision) (*entities.PostRevision, error) {
+	args := m.Called(ctx, revision)
+	return args.Get(0).(*entities.PostRevision), args.Error(1)
+}
+
+func (m *MockRevisionRepository) FindByPost(ctx context.Context, postID string, opts entities.PaginationOptions) ([]*entities.PostRevision, error) {
+	args := m.Called(ctx, postID, opts)
+	return args.Get(0).([]*entities.PostRevision), args.Error(1)
+}
+
+func (m *MockRevisionRepository) FindByNumber(ctx context.Context, postID string, number int) (*entities.PostRevision, error) {
+	args := m.Called(ctx, postID, number)
+	return args.Get(0).(*entities.PostRevision), args.Error(1)
+}
+
+func (m *MockRevisionRepository) CountByPost(ctx context.Context, postID string) (int64, error) {
+	args := m.Called(ctx, postID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRevisionRepository) DeleteByPostID(ctx context.Context, postID string) error {
+	args := m.Called(ctx, postID)
+	return args.Error(0)
+}
+
+func TestRevisionService_RecordUpdate_StoresBaselineForLegacyPost(t *testing.T) {
+	// Setup
+	mockRepo := new(MockRevisionRepository)
+	service := NewRevisionService(mockRepo)
+
+	lastEdit := time.Now().Add(-48 * time.Hour)
+	before := &entities.Post{ID: "post-1", AuthorID: "author-1", Title: "Old", Content: "old text", UpdatedAt: lastEdit}
+	after := &entities.Post{ID: "post-1", AuthorID: "author-1", Title: "New", Content: "new text"}
+
+	// Mock expectations
+	mockRepo.On("CountByPost", mock.Anything, "post-1").Return(int64(0), nil)
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(r *entities.PostRevision) bool {
+		return r.Title == "Old" && r.EditorID == "author-1" && r.CreatedAt.Equal(lastEdit)
+	})).Return(&entities.PostRevision{Number: 1}, nil).Once()
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(r *entities.PostRevision) bool {
+		return r.Title == "New" && r.EditorID == "editor-1"
+	})).Return(&entities.PostRevision{Number: 2}, nil).Once()
+
+	// Execute
+	result, err := service.RecordUpdate(context.Background(), before, after, "editor-1", 0)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.Number)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRevisionService_RecordUpdate_ExistingHistory(t *testing.T) {
+	// Setup
+	mockRepo := new(MockRevisionRepository)
+	service := NewRevisionService(mockRepo)
+
+	before := &entities.Post{ID: "post-1", Title: "Old"}
+	after := &entities.Post{ID: "post-1", Title: "New"}
+
+	// Mock expectations
+	mockRepo.On("CountByPost", mock.Anything, "post-1").Return(int64(3), nil)
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(r *entities.PostRevision) bool {
+		return r.Title == "New" && r.RestoredFrom == 2
+	})).Return(&entities.PostRevision{Number: 4, RestoredFrom: 2}, nil).Once()
+
+	// Execute
+	result, err := service.RecordUpdate(context.Background(), before, after, "editor-1", 2)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 4, result.Number)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRevisionService_Diff(t *testing.T) {
+	// Setup
+	mockRepo := new(MockRevisionRepository)
+	service := NewRevisionService(mockRepo)
+
+	mockRepo.On("FindByNumber", mock.Anything, "post-1", 1).Return(&entities.PostRevision{
+		Number: 1, Title: "Title", Content: "a\nb", Tags: []string{"go", "web"},
+	}, nil)
+	mockRepo.On("FindByNumber", mock.Anything, "post-1", 2).Return(&entities.PostRevision{
+		Number: 2, Title: "Title", Content: "a\nc", Tags: []string{"go", "api"},
+	}, nil)
+
+	// Execute
+	diff, err := service.Diff(context.Background(), "post-1", 1, 2)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.False(t, diff.TitleChanged)
+	assert.Equal(t, []string{"api"}, diff.AddedTags)
+	assert.Equal(t, []string{"web"}, diff.RemovedTags)
+	assert.Equal(t, []diffutil.Line{
+		{Op: diffutil.OpEqual, Text: "a"},
+		{Op: diffutil.OpDelete, Text: "b"},
+		{Op: diffutil.OpInsert, Text: "c"},
+	}, diff.Content)
+
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,217 @@
+package seriessvc
+
+import (
+	"context"
+	"strings"
+
+	"anchor-blog/internal/domain/entities"
+	AppError "anchor-blog/internal/errors"
+)
+
+const (
+	maxTitleLength       = 200
+	maxDescriptionLength = 1000
+
+	// MaxParts is the number of posts a series can hold
+	MaxParts = 100
+)
+
+type SeriesService struct {
+	seriesRepo entities.ISeriesRepository
+	postRepo   entities.IPostRepository
+}
+
+// NewSeriesService creates a new series service.
+func NewSeriesService(seriesRepo entities.ISeriesRepository, postRepo entities.IPostRepository) *SeriesService {
+	return &SeriesService{
+		seriesRepo: seriesRepo,
+		postRepo:   postRepo,
+	}
+}
+
+// Navigation places a post within its series. Only published parts are counted.
+type Navigation struct {
+	Series   *entities.Series
+	Position int // 1-based
+	Total    int
+	Previous *entities.Post // nil on the first part
+	Next     *entities.Post // nil on the last part
+}
+
+// CreateSeries creates a series of posts the author owns or co-authors, in reading order
+func (s *SeriesService) CreateSeries(ctx context.Context, authorID, title, description string, postIDs []string) (*entities.Series, error) {
+	series := &entities.Series{
+		AuthorID:    authorID,
+		Title:       strings.TrimSpace(title),
+		Description: strings.TrimSpace(description),
+		PostIDs:     postIDs,
+	}
+	if err := s.validate(ctx, series); err != nil {
+		return nil, err
+	}
+
+	return s.seriesRepo.Create(ctx, series)
+}
+
+// GetSeries returns a single series
+func (s *SeriesService) GetSeries(ctx context.Context, id string) (*entities.Series, error) {
+	return s.seriesRepo.FindByID(ctx, id)
+}
+
+// ListAuthorSeries lists the series of an author, newest first
+func (s *SeriesService) ListAuthorSeries(ctx context.Context, authorID string, page, limit int64) ([]*entities.Series, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = 20
+	}
+
+	opts := entities.PaginationOptions{
+		Page:  page,
+		Limit: limit,
+	}
+
+	return s.seriesRepo.FindByAuthor(ctx, authorID, opts)
+}
+
+// ListParts returns the posts of a series in reading order.
+// Readers other than the author only see the published, public parts.
+func (s *SeriesService) ListParts(ctx context.Context, series *entities.Series, publishedOnly bool) ([]*entities.Post, error) {
+	posts, err := s.postRepo.FindByIDs(ctx, series.PostIDs)
+	if err != nil {
+		return nil, err
+	}
+	if !publishedOnly {
+		return posts, nil
+	}
+
+	published := make([]*entities.Post, 0, len(posts))
+	for _, post := range posts {
+		if post.IsListed() {
+			published = append(published, post)
+		}
+	}
+	return published, nil
+}
+
+// UpdateSeries replaces the title, description and parts of a series owned by userID
+func (s *SeriesService) UpdateSeries(ctx context.Context, id, userID, title, description string, postIDs []string) (*entities.Series, error) {
+	existing, err := s.seriesRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if existing.AuthorID != userID {
+		return nil, AppError.ErrForbidden
+	}
+
+	series := &entities.Series{
+		ID:          existing.ID,
+		AuthorID:    existing.AuthorID,
+		Title:       strings.TrimSpace(title),
+		Description: strings.TrimSpace(description),
+		PostIDs:     postIDs,
+	}
+	if err := s.validate(ctx, series); err != nil {
+		return nil, err
+	}
+
+	return s.seriesRepo.Update(ctx, id, series)
+}
+
+// DeleteSeries deletes a series owned by userID; its posts are left untouched
+func (s *SeriesService) DeleteSeries(ctx context.Context, id, userID string) error {
+	series, err := s.seriesRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if series.AuthorID != userID {
+		return AppError.ErrForbidden
+	}
+
+	return s.seriesRepo.Delete(ctx, id)
+}
+
+// GetNavigation returns where a published post sits in its series, or nil when it isn't part of one
+func (s *SeriesService) GetNavigation(ctx context.Context, post *entities.Post) (*Navigation, error) {
+	found, err := s.seriesRepo.FindByPostIDs(ctx, []string{post.ID})
+	if err != nil || len(found) == 0 {
+		return nil, err
+	}
+	series := found[0]
+
+	parts, err := s.ListParts(ctx, series, true)
+	if err != nil {
+		return nil, err
+	}
+
+	for idx, part := range parts {
+		if part.ID != post.ID {
+			continue
+		}
+		nav := &Navigation{Series: series, Position: idx + 1, Total: len(parts)}
+		if idx > 0 {
+			nav.Previous = parts[idx-1]
+		}
+		if idx < len(parts)-1 {
+			nav.Next = parts[idx+1]
+		}
+		return nav, nil
+	}
+	// the post itself isn't published
+	return nil, nil
+}
+
+// RemovePost takes a deleted post out of its series
+func (s *SeriesService) RemovePost(ctx context.Context, postID string) error {
+	return s.seriesRepo.RemovePost(ctx, postID)
+}
+
+// validate checks the fields of a series and that every part is a post its author can edit
+// that isn't already part of another series.
+func (s *SeriesService) validate(ctx context.Context, series *entities.Series) error {
+	if series.Title == "" || len(series.Title) > maxTitleLength || len(series.Description) > maxDescriptionLength {
+		return AppError.ErrValidationFailed
+	}
+	if len(series.PostIDs) > MaxParts {
+		return AppError.ErrValidationFailed
+	}
+	if series.PostIDs == nil {
+		series.PostIDs = []string{}
+	}
+
+	seen := make(map[string]bool, len(series.PostIDs))
+	for _, id := range series.PostIDs {
+		if id == "" || seen[id] {
+			return AppError.ErrValidationFailed
+		}
+		seen[id] = true
+	}
+	if len(series.PostIDs) == 0 {
+		return nil
+	}
+
+	posts, err := s.postRepo.FindByIDs(ctx, series.PostIDs)
+	if err != nil {
+		return err
+	}
+	if len(posts) != len(series.PostIDs) {
+		return AppError.ErrNotFound
+	}
+	for _, post := range posts {
+		if !post.CanEdit(series.AuthorID) {
+			return AppError.ErrForbidden
+		}
+	}
+
+	others, err := s.seriesRepo.FindByPostIDs(ctx, series.PostIDs)
+	if err != nil {
+		return err
+	}
+	for _, other := range others {
+		if other.ID != series.ID {
+			return AppError.ErrPostInSeries
+		}
+	}
+	return nil
+}
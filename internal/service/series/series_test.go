@@ -0,0 +1,207 @@
+package seriessvc
+
+import (
+	"context"
+	"testing"
+
+	"anchor-blog/internal/domain/entities"
+	AppError "anchor-blog/internal/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSeriesRepository struct {
+	mock.Mock
+}
+
+func (m *MockSeriesRepository) Create(ctx context.Context, series *entities.Series) (*entities.Series, error) {
+	args := m.Called(ctx, series)
+	return args.Get(0).(*entities.Series), args.Error(1)
+}
+
+func (m *MockSeriesRepository) FindByID(ctx context.Context, id string) (*entities.Series, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(*entities.Series), args.Error(1)
+}
+
+func (m *MockSeriesRepository) FindByAuthor(ctx context.Context, authorID string, opts entities.PaginationOptions) ([]*entities.Series, error) {
+	args := m.Called(ctx, authorID, opts)
+	return args.Get(0).([]*entities.Series), args.Error(1)
+}
+
+func (m *MockSeriesRepository) FindByPostIDs(ctx context.Context, postIDs []string) ([]*entities.Series, error) {
+	args := m.Called(ctx, postIDs)
+	return args.Get(0).([]*entities.Series), args.Error(1)
+}
+
+func (m *MockSeriesRepository) Update(ctx context.Context, id string, series *entities.Series) (*entities.Series, error) {
+	args := m.Called(ctx, id, series)
+	return args.Get(0).(*entities.Series), args.Error(1)
+}
+
+func (m *MockSeriesRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockSeriesRepository) RemovePost(ctx context.Context, postID string) error {
+	args := m.Called(ctx, postID)
+	return args.Error(0)
+}
+
+// Mock post repository; only the bulk lookup is used by the series service
+type MockPostRepository struct {
+	entities.IPostRepository
+	mock.Mock
+}
+
+func (m *MockPostRepository) FindByIDs(ctx context.Context, ids []string) ([]*entities.Post, error) {
+	args := m.Called(ctx, ids)
+	return args.Get(0).([]*entities.Post), args.Error(1)
+}
+
+func TestSeriesService_CreateSeries_Success(t *testing.T) {
+	// Setup
+	seriesRepo := new(MockSeriesRepository)
+	postRepo := new(MockPostRepository)
+	service := NewSeriesService(seriesRepo, postRepo)
+
+	postIDs := []string{"post-1", "post-2"}
+	postRepo.On("FindByIDs", mock.Anything, postIDs).Return([]*entities.Post{
+		{ID: "post-1", AuthorID: "author-1"},
+		{ID: "post-2", AuthorID: "author-1"},
+	}, nil)
+	seriesRepo.On("FindByPostIDs", mock.Anything, postIDs).Return([]*entities.Series{}, nil)
+	seriesRepo.On("Create", mock.Anything, mock.MatchedBy(func(series *entities.Series) bool {
+		return series.Title == "Go from scratch" && series.AuthorID == "author-1"
+	})).Return(&entities.Series{ID: "series-1", AuthorID: "author-1", Title: "Go from scratch", PostIDs: postIDs}, nil)
+
+	// Execute
+	series, err := service.CreateSeries(context.Background(), "author-1", "  Go from scratch ", "", postIDs)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "series-1", series.ID)
+	seriesRepo.AssertExpectations(t)
+	postRepo.AssertExpectations(t)
+}
+
+func TestSeriesService_CreateSeries_Validation(t *testing.T) {
+	service := NewSeriesService(new(MockSeriesRepository), new(MockPostRepository))
+
+	tests := []struct {
+		name    string
+		title   string
+		postIDs []string
+	}{
+		{"missing title", "  ", nil},
+		{"duplicate part", "Series", []string{"post-1", "post-1"}},
+		{"empty part id", "Series", []string{""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Execute
+			series, err := service.CreateSeries(context.Background(), "author-1", tt.title, "", tt.postIDs)
+
+			// Assert
+			assert.Equal(t, AppError.ErrValidationFailed, err)
+			assert.Nil(t, series)
+		})
+	}
+}
+
+func TestSeriesService_CreateSeries_ForeignPost(t *testing.T) {
+	// Setup
+	seriesRepo := new(MockSeriesRepository)
+	postRepo := new(MockPostRepository)
+	service := NewSeriesService(seriesRepo, postRepo)
+
+	postRepo.On("FindByIDs", mock.Anything, []string{"post-1"}).
+		Return([]*entities.Post{{ID: "post-1", AuthorID: "someone-else"}}, nil)
+
+	// Execute
+	series, err := service.CreateSeries(context.Background(), "author-1", "Series", "", []string{"post-1"})
+
+	// Assert
+	assert.Equal(t, AppError.ErrForbidden, err)
+	assert.Nil(t, series)
+	seriesRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestSeriesService_CreateSeries_PostInAnotherSeries(t *testing.T) {
+	// Setup
+	seriesRepo := new(MockSeriesRepository)
+	postRepo := new(MockPostRepository)
+	service := NewSeriesService(seriesRepo, postRepo)
+
+	postRepo.On("FindByIDs", mock.Anything, []string{"post-1"}).
+		Return([]*entities.Post{{ID: "post-1", AuthorID: "author-1"}}, nil)
+	seriesRepo.On("FindByPostIDs", mock.Anything, []string{"post-1"}).
+		Return([]*entities.Series{{ID: "series-9"}}, nil)
+
+	// Execute
+	series, err := service.CreateSeries(context.Background(), "author-1", "Series", "", []string{"post-1"})
+
+	// Assert
+	assert.Equal(t, AppError.ErrPostInSeries, err)
+	assert.Nil(t, series)
+}
+
+func TestSeriesService_UpdateSeries_NotOwner(t *testing.T) {
+	// Setup
+	seriesRepo := new(MockSeriesRepository)
+	service := NewSeriesService(seriesRepo, new(MockPostRepository))
+
+	seriesRepo.On("FindByID", mock.Anything, "series-1").Return(&entities.Series{ID: "series-1", AuthorID: "author-1"}, nil)
+
+	// Execute
+	series, err := service.UpdateSeries(context.Background(), "series-1", "intruder", "Title", "", nil)
+
+	// Assert
+	assert.Equal(t, AppError.ErrForbidden, err)
+	assert.Nil(t, series)
+	seriesRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSeriesService_GetNavigation_SkipsUnpublishedParts(t *testing.T) {
+	// Setup
+	seriesRepo := new(MockSeriesRepository)
+	postRepo := new(MockPostRepository)
+	service := NewSeriesService(seriesRepo, postRepo)
+
+	series := &entities.Series{ID: "series-1", PostIDs: []string{"post-1", "post-2", "post-3", "post-4"}}
+	seriesRepo.On("FindByPostIDs", mock.Anything, []string{"post-3"}).Return([]*entities.Series{series}, nil)
+	postRepo.On("FindByIDs", mock.Anything, series.PostIDs).Return([]*entities.Post{
+		{ID: "post-1", Status: entities.PostStatusPublished},
+		{ID: "post-2", Status: entities.PostStatusDraft},
+		{ID: "post-3", Status: entities.PostStatusPublished},
+		{ID: "post-4", Status: entities.PostStatusPublished},
+	}, nil)
+
+	// Execute
+	nav, err := service.GetNavigation(context.Background(), &entities.Post{ID: "post-3"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, nav.Position)
+	assert.Equal(t, 3, nav.Total)
+	assert.Equal(t, "post-1", nav.Previous.ID)
+	assert.Equal(t, "post-4", nav.Next.ID)
+}
+
+func TestSeriesService_GetNavigation_NotInSeries(t *testing.T) {
+	// Setup
+	seriesRepo := new(MockSeriesRepository)
+	service := NewSeriesService(seriesRepo, new(MockPostRepository))
+
+	seriesRepo.On("FindByPostIDs", mock.Anything, []string{"post-1"}).Return([]*entities.Series{}, nil)
+
+	// Execute
+	nav, err := service.GetNavigation(context.Background(), &entities.Post{ID: "post-1"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, nav)
+}
@@ -0,0 +1,79 @@
+package sitemapsvc
+
+import (
+	"time"
+
+	"anchor-blog/internal/domain/entities"
+)
+
+// siteIndex holds what the sitemap lists: published posts, and the tags and authors they link to
+type siteIndex struct {
+	posts   map[string]postEntry
+	tags    map[string]*pageEntry
+	authors map[string]*pageEntry
+}
+
+type postEntry struct {
+	slug     string
+	authorID string
+	tags     []string
+	lastMod  time.Time
+}
+
+// pageEntry is a listing page; it disappears with the last post on it
+type pageEntry struct {
+	posts   int
+	lastMod time.Time
+}
+
+func newSiteIndex() *siteIndex {
+	return &siteIndex{
+		posts:   make(map[string]postEntry),
+		tags:    make(map[string]*pageEntry),
+		authors: make(map[string]*pageEntry),
+	}
+}
+
+func (idx *siteIndex) add(post *entities.Post) {
+	entry := postEntry{
+		slug:     post.Slug,
+		authorID: post.AuthorID,
+		tags:     post.Tags,
+		lastMod:  post.UpdatedAt,
+	}
+	idx.posts[post.ID] = entry
+
+	for _, tag := range entry.tags {
+		touch(idx.tags, tag, entry.lastMod, 1)
+	}
+	touch(idx.authors, entry.authorID, entry.lastMod, 1)
+}
+
+// remove drops a post; the pages it was listed on changed at the given time
+func (idx *siteIndex) remove(postID string, at time.Time) {
+	entry, ok := idx.posts[postID]
+	if !ok {
+		return
+	}
+	delete(idx.posts, postID)
+
+	for _, tag := range entry.tags {
+		touch(idx.tags, tag, at, -1)
+	}
+	touch(idx.authors, entry.authorID, at, -1)
+}
+
+func touch(pages map[string]*pageEntry, key string, at time.Time, delta int) {
+	page, ok := pages[key]
+	if !ok {
+		page = &pageEntry{}
+		pages[key] = page
+	}
+	page.posts += delta
+	if at.After(page.lastMod) {
+		page.lastMod = at
+	}
+	if page.posts <= 0 {
+		delete(pages, key)
+	}
+}
@@ -0,0 +1,324 @@
+package sitemapsvc
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"anchor-blog/internal/domain/entities"
+	AppError "anchor-blog/internal/errors"
+	"anchor-blog/pkg/sitemap"
+)
+
+const (
+	defaultPostURL   = "/api/v1/posts/by-slug/{slug}"
+	defaultTagURL    = "/api/v1/posts/filter?tags={tag}"
+	defaultAuthorURL = "/api/v1/posts/search?q=author:{username}"
+
+	rebuildBatchSize = 500
+)
+
+// Settings describe where the pages listed in the sitemap live.
+// Patterns starting with a slash are resolved against SiteURL, or the request origin when that is empty.
+type Settings struct {
+	SiteURL         string
+	PostURL         string // placeholders {id} and {slug}
+	TagURL          string // placeholder {tag}
+	AuthorURL       string // placeholders {id} and {username}
+	RebuildInterval int    // seconds between full rebuilds
+}
+
+type SitemapService struct {
+	postRepo entities.IPostRepository
+	userRepo entities.IUserReaderRepository
+	settings Settings
+	interval time.Duration
+
+	mu         sync.Mutex
+	index      *siteIndex
+	usernames  map[string]string // author id to username, "" for authors that could not be found
+	rebuilding bool
+	pending    []string          // posts changed while a rebuild was running
+	documents  map[string][]byte // encoded sitemaps by origin and part, dropped on every change
+}
+
+// NewSitemapService creates a new sitemap service.
+// The sitemap is kept up to date post by post through PostChanged, and rebuilt in full
+// every RebuildInterval seconds (an hour when zero) to catch scheduled posts going live.
+func NewSitemapService(postRepo entities.IPostRepository, userRepo entities.IUserReaderRepository, settings Settings) *SitemapService {
+	if settings.PostURL == "" {
+		settings.PostURL = defaultPostURL
+	}
+	if settings.TagURL == "" {
+		settings.TagURL = defaultTagURL
+	}
+	if settings.AuthorURL == "" {
+		settings.AuthorURL = defaultAuthorURL
+	}
+	if settings.RebuildInterval <= 0 {
+		settings.RebuildInterval = 3600 // Default interval
+	}
+	return &SitemapService{
+		postRepo:  postRepo,
+		userRepo:  userRepo,
+		settings:  settings,
+		interval:  time.Duration(settings.RebuildInterval) * time.Second,
+		index:     newSiteIndex(),
+		usernames: make(map[string]string),
+		documents: make(map[string][]byte),
+	}
+}
+
+// Start rebuilds the sitemap in the background until ctx is cancelled
+func (s *SitemapService) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		s.rebuildAndLog(ctx)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.rebuildAndLog(ctx)
+			}
+		}
+	}()
+}
+
+func (s *SitemapService) rebuildAndLog(ctx context.Context) {
+	if err := s.Rebuild(ctx); err != nil {
+		log.Printf("Error rebuilding sitemap: %v", err)
+	}
+}
+
+// Rebuild reads every published post and replaces the sitemap.
+// Posts that change meanwhile are refreshed again once the new sitemap is in place.
+func (s *SitemapService) Rebuild(ctx context.Context) error {
+	s.mu.Lock()
+	s.rebuilding = true
+	s.pending = nil
+	s.mu.Unlock()
+
+	fresh := newSiteIndex()
+	err := s.readPublished(ctx, fresh)
+
+	s.mu.Lock()
+	s.rebuilding = false
+	pending := s.pending
+	s.pending = nil
+	if err == nil {
+		s.index = fresh
+		s.documents = make(map[string][]byte)
+	}
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	for _, postID := range pending {
+		s.refresh(ctx, postID)
+	}
+	return nil
+}
+
+func (s *SitemapService) readPublished(ctx context.Context, index *siteIndex) error {
+	for page := int64(1); ; page++ {
+		posts, err := s.postRepo.FindAll(ctx, entities.PaginationOptions{Page: page, Limit: rebuildBatchSize})
+		if err != nil {
+			return err
+		}
+		for _, post := range posts {
+			if post.IsListed() {
+				s.lookupUsername(ctx, post.AuthorID)
+				index.add(post)
+			}
+		}
+		if len(posts) < rebuildBatchSize {
+			return nil
+		}
+	}
+}
+
+// PostChanged updates the entries of one post; it implements postsvc.ChangeListener
+func (s *SitemapService) PostChanged(postID string) {
+	s.mu.Lock()
+	if s.rebuilding {
+		s.pending = append(s.pending, postID)
+	}
+	s.mu.Unlock()
+
+	s.refresh(context.Background(), postID)
+}
+
+// refresh re-reads a post: published, public posts are (re)listed, anything else is dropped
+func (s *SitemapService) refresh(ctx context.Context, postID string) {
+	post, err := s.postRepo.FindByID(ctx, postID)
+	if err != nil && err != AppError.ErrNotFound {
+		log.Printf("Error refreshing post %s in sitemap: %v", postID, err)
+		return
+	}
+	listed := err == nil && post.IsListed()
+	if listed {
+		s.lookupUsername(ctx, post.AuthorID)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.index.remove(postID, time.Now())
+	if listed {
+		s.index.add(post)
+	}
+	s.documents = make(map[string][]byte)
+}
+
+// lookupUsername remembers the username of an author the first time they are seen
+func (s *SitemapService) lookupUsername(ctx context.Context, authorID string) {
+	s.mu.Lock()
+	_, known := s.usernames[authorID]
+	s.mu.Unlock()
+	if known {
+		return
+	}
+
+	username := ""
+	if user, err := s.userRepo.GetUserByID(ctx, authorID); err == nil {
+		username = user.Username
+	}
+
+	s.mu.Lock()
+	s.usernames[authorID] = username
+	s.mu.Unlock()
+}
+
+// Document returns part of the sitemap for the given request origin. Part 0 is /sitemap.xml:
+// the whole sitemap, or an index of the numbered parts once it lists more than 50,000 URLs.
+func (s *SitemapService) Document(origin string, part int) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := origin + "|" + strconv.Itoa(part)
+	if doc, ok := s.documents[key]; ok {
+		return doc, nil
+	}
+
+	urls := s.urls(origin)
+	parts := (len(urls) + sitemap.MaxURLs - 1) / sitemap.MaxURLs
+
+	var doc []byte
+	var err error
+	switch {
+	case part == 0 && parts <= 1:
+		doc, err = sitemap.URLSet(urls)
+	case part == 0:
+		sitemaps := make([]sitemap.URL, parts)
+		for i := range sitemaps {
+			chunk := chunkOf(urls, i+1)
+			sitemaps[i] = sitemap.URL{Loc: fmt.Sprintf("%s/sitemaps/%d.xml", origin, i+1), LastMod: latest(chunk)}
+		}
+		doc, err = sitemap.Index(sitemaps)
+	case part >= 1 && part <= parts && parts > 1:
+		doc, err = sitemap.URLSet(chunkOf(urls, part))
+	default:
+		return nil, AppError.ErrNotFound
+	}
+	if err != nil {
+		return nil, AppError.ErrInternalServer
+	}
+
+	s.documents[key] = doc
+	return doc, nil
+}
+
+// urls lists posts, most recently updated first, then tags and authors by name
+func (s *SitemapService) urls(origin string) []sitemap.URL {
+	base := s.settings.SiteURL
+	if base == "" {
+		base = origin
+	}
+
+	postIDs := make([]string, 0, len(s.index.posts))
+	for postID := range s.index.posts {
+		postIDs = append(postIDs, postID)
+	}
+	sort.Slice(postIDs, func(i, j int) bool {
+		a, b := s.index.posts[postIDs[i]], s.index.posts[postIDs[j]]
+		if !a.lastMod.Equal(b.lastMod) {
+			return a.lastMod.After(b.lastMod)
+		}
+		return postIDs[i] < postIDs[j]
+	})
+
+	urls := make([]sitemap.URL, 0, len(postIDs)+len(s.index.tags)+len(s.index.authors))
+	for _, postID := range postIDs {
+		entry := s.index.posts[postID]
+		slug := entry.slug
+		if slug == "" {
+			slug = postID
+		}
+		loc := expand(base, s.settings.PostURL, "{id}", postID, "{slug}", slug)
+		urls = append(urls, sitemap.URL{Loc: loc, LastMod: entry.lastMod})
+	}
+
+	for _, tag := range sortedKeys(s.index.tags) {
+		loc := expand(base, s.settings.TagURL, "{tag}", tag)
+		urls = append(urls, sitemap.URL{Loc: loc, LastMod: s.index.tags[tag].lastMod})
+	}
+
+	authors := make(map[string]string, len(s.index.authors))
+	for authorID := range s.index.authors {
+		if username := s.usernames[authorID]; username != "" {
+			authors[username] = authorID
+		}
+	}
+	for _, username := range sortedKeys(authors) {
+		authorID := authors[username]
+		loc := expand(base, s.settings.AuthorURL, "{id}", authorID, "{username}", username)
+		urls = append(urls, sitemap.URL{Loc: loc, LastMod: s.index.authors[authorID].lastMod})
+	}
+	return urls
+}
+
+// expand fills the placeholders of a pattern with escaped values and resolves it against base
+func expand(base, pattern string, placeholders ...string) string {
+	for i := 1; i < len(placeholders); i += 2 {
+		placeholders[i] = url.PathEscape(placeholders[i])
+	}
+	loc := strings.NewReplacer(placeholders...).Replace(pattern)
+	if strings.HasPrefix(loc, "/") {
+		loc = strings.TrimSuffix(base, "/") + loc
+	}
+	return loc
+}
+
+func chunkOf(urls []sitemap.URL, part int) []sitemap.URL {
+	start := (part - 1) * sitemap.MaxURLs
+	end := min(start+sitemap.MaxURLs, len(urls))
+	return urls[start:end]
+}
+
+func latest(urls []sitemap.URL) time.Time {
+	var last time.Time
+	for _, u := range urls {
+		if u.LastMod.After(last) {
+			last = u.LastMod
+		}
+	}
+	return last
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
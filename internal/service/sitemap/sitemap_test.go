@@ -0,0 +1,165 @@
+package sitemapsvc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"anchor-blog/internal/domain/entities"
+	AppError "anchor-blog/internal/errors"
+	postsvc "anchor-blog/internal/service/post"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Mock post repository; only the reads used to list posts are implemented
+type MockPostRepository struct {
+	entities.IPostRepository
+	mock.Mock
+}
+
+func (m *MockPostRepository) FindAll(ctx context.Context, opts entities.PaginationOptions) ([]*entities.Post, error) {
+	args := m.Called(ctx, opts)
+	return args.Get(0).([]*entities.Post), args.Error(1)
+}
+
+func (m *MockPostRepository) FindByID(ctx context.Context, id string) (*entities.Post, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(*entities.Post), args.Error(1)
+}
+
+func (m *MockPostRepository) PublishScheduled(ctx context.Context, now time.Time) ([]string, error) {
+	args := m.Called(ctx, now)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+// Mock user repository; only the author lookup is used
+type MockUserRepository struct {
+	entities.IUserReaderRepository
+	mock.Mock
+}
+
+func (m *MockUserRepository) GetUserByID(ctx context.Context, id string) (*entities.User, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(*entities.User), args.Error(1)
+}
+
+var updated = time.Date(2025, 8, 7, 10, 30, 0, 0, time.UTC)
+
+func setupSite(t *testing.T) (*SitemapService, *MockPostRepository) {
+	postRepo := new(MockPostRepository)
+	userRepo := new(MockUserRepository)
+	service := NewSitemapService(postRepo, userRepo, Settings{SiteURL: "https://blog.example.com"})
+
+	postRepo.On("FindAll", mock.Anything, entities.PaginationOptions{Page: 1, Limit: rebuildBatchSize}).Return([]*entities.Post{
+		{ID: "post-1", Slug: "hello-go", AuthorID: "author-1", Tags: []string{"go"}, Status: entities.PostStatusPublished, UpdatedAt: updated},
+		{ID: "post-2", Slug: "draft", AuthorID: "author-2", Tags: []string{"secret"}, Status: entities.PostStatusDraft, UpdatedAt: updated},
+	}, nil)
+	userRepo.On("GetUserByID", mock.Anything, "author-1").Return(&entities.User{ID: "author-1", Username: "alice"}, nil)
+
+	require.NoError(t, service.Rebuild(context.Background()))
+	return service, postRepo
+}
+
+func TestSitemapService_Rebuild_ListsPublishedPosts(t *testing.T) {
+	service, _ := setupSite(t)
+
+	// Execute
+	doc, err := service.Document("http://localhost:8080", 0)
+
+	// Assert
+	assert.NoError(t, err)
+	xml := string(doc)
+	assert.Contains(t, xml, "<loc>https://blog.example.com/api/v1/posts/by-slug/hello-go</loc>")
+	assert.Contains(t, xml, "<lastmod>2025-08-07T10:30:00Z</lastmod>")
+	assert.Contains(t, xml, "<loc>https://blog.example.com/api/v1/posts/filter?tags=go</loc>")
+	assert.Contains(t, xml, "<loc>https://blog.example.com/api/v1/posts/search?q=author:alice</loc>")
+	assert.NotContains(t, xml, "draft")
+	assert.NotContains(t, xml, "secret")
+}
+
+func TestSitemapService_PostChanged_UpdatesIncrementally(t *testing.T) {
+	service, postRepo := setupSite(t)
+
+	postRepo.On("FindByID", mock.Anything, "post-1").Return(&entities.Post{
+		ID: "post-1", Slug: "hello-generics", AuthorID: "author-1", Tags: []string{"generics"},
+		Status: entities.PostStatusPublished, UpdatedAt: updated.Add(time.Hour),
+	}, nil)
+
+	// Execute
+	service.PostChanged("post-1")
+	doc, err := service.Document("http://localhost:8080", 0)
+
+	// Assert
+	assert.NoError(t, err)
+	xml := string(doc)
+	assert.Contains(t, xml, "by-slug/hello-generics</loc>")
+	assert.Contains(t, xml, "tags=generics</loc>")
+	assert.NotContains(t, xml, "by-slug/hello-go<")
+	assert.NotContains(t, xml, "tags=go<")
+	postRepo.AssertNumberOfCalls(t, "FindAll", 1)
+}
+
+func TestSitemapService_ScheduledPostGoesLive(t *testing.T) {
+	service, postRepo := setupSite(t)
+	postService := postsvc.NewPostService(postRepo, nil)
+	postService.AddChangeListener(service)
+	now := time.Now()
+
+	postRepo.On("PublishScheduled", mock.Anything, now).Return([]string{"post-3"}, nil)
+	postRepo.On("FindByID", mock.Anything, "post-3").Return(&entities.Post{
+		ID: "post-3", Slug: "release-notes", AuthorID: "author-1", Tags: []string{"news"},
+		Status: entities.PostStatusPublished, UpdatedAt: now,
+	}, nil)
+
+	// Execute
+	_, err := postService.PublishScheduled(context.Background(), now)
+	require.NoError(t, err)
+	doc, err := service.Document("http://localhost:8080", 0)
+
+	// Assert
+	assert.NoError(t, err)
+	xml := string(doc)
+	assert.Contains(t, xml, "by-slug/release-notes</loc>")
+	assert.Contains(t, xml, "tags=news</loc>")
+	postRepo.AssertNumberOfCalls(t, "FindAll", 1)
+}
+
+func TestSitemapService_PostChanged_Deleted(t *testing.T) {
+	service, postRepo := setupSite(t)
+
+	postRepo.On("FindByID", mock.Anything, "post-1").Return((*entities.Post)(nil), AppError.ErrNotFound)
+
+	// Execute
+	service.PostChanged("post-1")
+	doc, err := service.Document("http://localhost:8080", 0)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotContains(t, string(doc), "<url>")
+}
+
+func TestSitemapService_Document_SplitsLargeSites(t *testing.T) {
+	// Setup
+	service := NewSitemapService(nil, nil, Settings{})
+	for i := 0; i < 50001; i++ {
+		service.index.posts[fmt.Sprintf("post-%05d", i)] = postEntry{slug: fmt.Sprintf("post-%05d", i), lastMod: updated}
+	}
+
+	// Execute
+	index, err := service.Document("http://localhost:8080", 0)
+	require.NoError(t, err)
+	second, err := service.Document("http://localhost:8080", 2)
+	require.NoError(t, err)
+	_, missing := service.Document("http://localhost:8080", 3)
+
+	// Assert
+	assert.Contains(t, string(index), "<sitemapindex")
+	assert.Contains(t, string(index), "<loc>http://localhost:8080/sitemaps/2.xml</loc>")
+	assert.Equal(t, 1, strings.Count(string(second), "<url>"))
+	assert.Equal(t, AppError.ErrNotFound, missing)
+}
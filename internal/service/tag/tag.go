@@ -0,0 +1,358 @@
+package tagsvc
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+	"strings"
+
+	"anchor-blog/internal/domain/entities"
+	AppError "anchor-blog/internal/errors"
+	"anchor-blog/pkg/tagutil"
+)
+
+const (
+	maxDescriptionLength = 500
+
+	SortPopular = "popular"
+	SortName    = "name"
+)
+
+type TagService struct {
+	tagRepo  entities.ITagRepository
+	postRepo entities.IPostRepository
+	retagger PostRetagger
+}
+
+// PostRetagger rewrites tags on posts. The post service implements it, so every rewritten
+// post gets a revision and whatever is derived from it is refreshed.
+type PostRetagger interface {
+	ReplaceTags(ctx context.Context, from []string, to, editorID string) (int64, error)
+}
+
+// NewTagService creates a new tag service. Posts are read from postRepo and retagged through retagger.
+func NewTagService(tagRepo entities.ITagRepository, postRepo entities.IPostRepository, retagger PostRetagger) *TagService {
+	return &TagService{
+		tagRepo:  tagRepo,
+		postRepo: postRepo,
+		retagger: retagger,
+	}
+}
+
+// ResolveTags prepares the tags of a post: they are normalized, former names are
+// mapped to the tag they were renamed or merged into, and new ones are registered.
+func (s *TagService) ResolveTags(ctx context.Context, raw []string) ([]string, error) {
+	tags := tagutil.NormalizeList(raw)
+	if len(tags) > tagutil.MaxPerPost {
+		return nil, AppError.ErrValidationFailed
+	}
+	if len(tags) == 0 {
+		return tags, nil
+	}
+
+	current, err := s.aliasTargets(ctx, tags)
+	if err != nil {
+		return nil, err
+	}
+	for i, tag := range tags {
+		if name, ok := current[tag]; ok {
+			tags[i] = name
+		}
+	}
+	tags = tagutil.NormalizeList(tags)
+
+	if err := s.tagRepo.EnsureTags(ctx, tags); err != nil {
+		// The post is still correctly tagged; the registry catches up on the next use
+		log.Printf("Error registering tags %v: %v", tags, err)
+	}
+	return tags, nil
+}
+
+// ListTags returns every known tag with the number of published posts using it.
+// Tags found on posts but missing from the registry are included too.
+func (s *TagService) ListTags(ctx context.Context, sortBy string, limit int) ([]*entities.Tag, error) {
+	if sortBy == "" {
+		sortBy = SortPopular
+	}
+	if sortBy != SortPopular && sortBy != SortName {
+		return nil, AppError.ErrValidationFailed
+	}
+
+	registry, err := s.tagRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := s.postRepo.CountTags(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make([]*entities.Tag, 0, len(registry))
+	known := make(map[string]bool, len(registry))
+	for _, tag := range registry {
+		tag.PostCount = counts[tag.Name]
+		known[tag.Name] = true
+		tags = append(tags, tag)
+	}
+	for name, count := range counts {
+		if !known[name] {
+			tags = append(tags, &entities.Tag{Name: name, Aliases: []string{}, PostCount: count})
+		}
+	}
+
+	sort.Slice(tags, func(i, j int) bool {
+		if sortBy == SortPopular && tags[i].PostCount != tags[j].PostCount {
+			return tags[i].PostCount > tags[j].PostCount
+		}
+		return tags[i].Name < tags[j].Name
+	})
+
+	if limit > 0 && len(tags) > limit {
+		tags = tags[:limit]
+	}
+	return tags, nil
+}
+
+// GetTag looks a tag up by its name or a former name
+func (s *TagService) GetTag(ctx context.Context, name string) (*entities.Tag, error) {
+	name = tagutil.Normalize(name)
+	if name == "" {
+		return nil, AppError.ErrValidationFailed
+	}
+
+	tag, err := s.tagRepo.FindByName(ctx, name)
+	if errors.Is(err, AppError.ErrNotFound) {
+		var aliased []*entities.Tag
+		aliased, err = s.tagRepo.FindByAliases(ctx, []string{name})
+		if err == nil && len(aliased) > 0 {
+			tag = aliased[0]
+		} else if err == nil {
+			tag = &entities.Tag{Name: name, Aliases: []string{}}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := s.postRepo.CountTags(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	tag.PostCount = counts[tag.Name]
+	if tag.CreatedAt.IsZero() && tag.PostCount == 0 {
+		// neither registered nor used anywhere
+		return nil, AppError.ErrNotFound
+	}
+	return tag, nil
+}
+
+// UpdateDescription sets the description shown on a tag's page
+func (s *TagService) UpdateDescription(ctx context.Context, name, description string) (*entities.Tag, error) {
+	description = strings.TrimSpace(description)
+	if len(description) > maxDescriptionLength {
+		return nil, AppError.ErrValidationFailed
+	}
+	name = tagutil.Normalize(name)
+	if name == "" {
+		return nil, AppError.ErrValidationFailed
+	}
+
+	return s.tagRepo.UpdateDescription(ctx, name, description)
+}
+
+// RenameTag gives a tag a new name on every post. The old name becomes an alias,
+// so posts tagged with it later still end up under the new name.
+// The rewrite is recorded in the posts' history as an edit by editorID.
+// It returns the renamed tag and the number of posts rewritten.
+func (s *TagService) RenameTag(ctx context.Context, from, to, editorID string) (*entities.Tag, int64, error) {
+	source, target := tagutil.Normalize(from), tagutil.Normalize(to)
+	if source == "" || target == "" {
+		return nil, 0, AppError.ErrValidationFailed
+	}
+
+	if source != target {
+		if existing, err := s.tagRepo.FindByName(ctx, target); err == nil {
+			// taking over an existing tag is a merge, not a rename, unless
+			// it is this rename's own target left by an attempt that failed
+			if !contains(existing.Aliases, source) {
+				return nil, 0, AppError.ErrTagExists
+			}
+		} else if !errors.Is(err, AppError.ErrNotFound) {
+			return nil, 0, err
+		}
+	}
+
+	return s.merge(ctx, []string{from}, target, editorID)
+}
+
+// MergeTags folds the source tags into target on every post, removing the duplicates
+// this creates. Source names become aliases of target. The rewrite is recorded in the posts'
+// history as an edit by editorID.
+func (s *TagService) MergeTags(ctx context.Context, sources []string, target, editorID string) (*entities.Tag, int64, error) {
+	target = tagutil.Normalize(target)
+	if target == "" || len(tagutil.NormalizeList(sources)) == 0 {
+		return nil, 0, AppError.ErrValidationFailed
+	}
+
+	return s.merge(ctx, sources, target, editorID)
+}
+
+// NormalizeAll rewrites the tags stored before normalization existed (for example
+// "Go" and "go ") to their canonical names. It returns the number of posts rewritten.
+func (s *TagService) NormalizeAll(ctx context.Context) (int64, error) {
+	counts, err := s.postRepo.CountTags(ctx, false)
+	if err != nil {
+		return 0, err
+	}
+
+	normalized := make([]string, 0, len(counts))
+	for raw := range counts {
+		if name := tagutil.Normalize(raw); name != "" {
+			normalized = append(normalized, name)
+		}
+	}
+	current, err := s.aliasTargets(ctx, normalized)
+	if err != nil {
+		return 0, err
+	}
+
+	// group the stored spellings by the name they should have
+	rewrites := map[string][]string{}
+	for raw := range counts {
+		name := tagutil.Normalize(raw)
+		if target, ok := current[name]; ok {
+			name = target
+		}
+		if name != "" && name != raw {
+			rewrites[name] = append(rewrites[name], raw)
+		}
+	}
+
+	var total int64
+	targets := make([]string, 0, len(rewrites))
+	for target, from := range rewrites {
+		modified, err := s.retagger.ReplaceTags(ctx, from, target, "")
+		if err != nil {
+			return total, err
+		}
+		total += modified
+		targets = append(targets, target)
+	}
+
+	if err := s.tagRepo.EnsureTags(ctx, targets); err != nil {
+		return total, err
+	}
+	return total, nil
+}
+
+// merge updates the registry first and rewrites the posts second. Until the posts are
+// rewritten, their old tags are already aliases of target and resolve to it, so a failure
+// in between leaves nothing dangling, and repeating the rename or merge completes it.
+func (s *TagService) merge(ctx context.Context, sources []string, target, editorID string) (*entities.Tag, int64, error) {
+	names := tagutil.NormalizeList(sources)
+
+	// a target that is still a former name of some other tag would keep resolving to that tag
+	owners, err := s.tagRepo.FindByAliases(ctx, []string{target})
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, owner := range owners {
+		if !contains(names, owner.Name) {
+			return nil, 0, AppError.ErrTagExists
+		}
+	}
+
+	counts, err := s.postRepo.CountTags(ctx, false)
+	if err != nil {
+		return nil, 0, err
+	}
+	// match every stored spelling of the sources, not just the canonical one
+	var from []string
+	for raw := range counts {
+		if raw != target && (contains(names, tagutil.Normalize(raw)) || contains(sources, raw)) {
+			from = append(from, raw)
+		}
+	}
+	sort.Strings(from)
+
+	if len(from) == 0 {
+		// nothing to rewrite: the sources must at least exist in the registry
+		registered, err := s.registered(ctx, names, target)
+		if err != nil {
+			return nil, 0, err
+		}
+		if !registered {
+			return nil, 0, AppError.ErrNotFound
+		}
+	}
+
+	tag, err := s.tagRepo.Merge(ctx, names, target)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var modified int64
+	if len(from) > 0 {
+		modified, err = s.retagger.ReplaceTags(ctx, from, target, editorID)
+		if err != nil {
+			return nil, modified, err
+		}
+	}
+
+	counts, err = s.postRepo.CountTags(ctx, true)
+	if err == nil {
+		tag.PostCount = counts[tag.Name]
+	}
+	return tag, modified, nil
+}
+
+// registered reports whether any of the names is a tag of its own, or already an alias of target
+func (s *TagService) registered(ctx context.Context, names []string, target string) (bool, error) {
+	for _, name := range names {
+		if _, err := s.tagRepo.FindByName(ctx, name); err == nil {
+			return true, nil
+		} else if !errors.Is(err, AppError.ErrNotFound) {
+			return false, err
+		}
+	}
+
+	aliased, err := s.tagRepo.FindByAliases(ctx, names)
+	if err != nil {
+		return false, err
+	}
+	for _, tag := range aliased {
+		if tag.Name == target {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// aliasTargets maps each name that is a former name of a tag to that tag
+func (s *TagService) aliasTargets(ctx context.Context, names []string) (map[string]string, error) {
+	current := map[string]string{}
+	if len(names) == 0 {
+		return current, nil
+	}
+
+	aliased, err := s.tagRepo.FindByAliases(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+	for _, tag := range aliased {
+		for _, alias := range tag.Aliases {
+			current[alias] = tag.Name
+		}
+	}
+	return current, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,310 @@
+package tagsvc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"anchor-blog/internal/domain/entities"
+	AppError "anchor-blog/internal/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTagRepository struct {
+	mock.Mock
+}
+
+func (m *MockTagRepository) EnsureTags(ctx context.Context, names []string) error {
+	args := m.Called(ctx, names)
+	return args.Error(0)
+}
+
+func (m *MockTagRepository) FindByName(ctx context.Context, name string) (*entities.Tag, error) {
+	args := m.Called(ctx, name)
+	return args.Get(0).(*entities.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) FindAll(ctx context.Context) ([]*entities.Tag, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]*entities.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) FindByAliases(ctx context.Context, names []string) ([]*entities.Tag, error) {
+	args := m.Called(ctx, names)
+	return args.Get(0).([]*entities.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) UpdateDescription(ctx context.Context, name, description string) (*entities.Tag, error) {
+	args := m.Called(ctx, name, description)
+	return args.Get(0).(*entities.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) Merge(ctx context.Context, sources []string, target string) (*entities.Tag, error) {
+	args := m.Called(ctx, sources, target)
+	return args.Get(0).(*entities.Tag), args.Error(1)
+}
+
+// Mock post repository; only the tag methods are used by the tag service
+type MockPostRepository struct {
+	entities.IPostRepository
+	mock.Mock
+}
+
+func (m *MockPostRepository) CountTags(ctx context.Context, publishedOnly bool) (map[string]int, error) {
+	args := m.Called(ctx, publishedOnly)
+	return args.Get(0).(map[string]int), args.Error(1)
+}
+
+// Mock retagger standing in for the post service
+type MockPostRetagger struct {
+	mock.Mock
+}
+
+func (m *MockPostRetagger) ReplaceTags(ctx context.Context, from []string, to, editorID string) (int64, error) {
+	args := m.Called(ctx, from, to, editorID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func TestTagService_ResolveTags_NormalizesAndResolvesAliases(t *testing.T) {
+	// Setup
+	tagRepo := new(MockTagRepository)
+	service := NewTagService(tagRepo, new(MockPostRepository), nil)
+
+	tagRepo.On("FindByAliases", mock.Anything, []string{"golang", "web-dev"}).
+		Return([]*entities.Tag{{Name: "go", Aliases: []string{"golang"}}}, nil)
+	tagRepo.On("EnsureTags", mock.Anything, []string{"go", "web-dev"}).Return(nil)
+
+	// Execute
+	tags, err := service.ResolveTags(context.Background(), []string{" #Golang", "Web Dev", "golang"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"go", "web-dev"}, tags)
+	tagRepo.AssertExpectations(t)
+}
+
+func TestTagService_ResolveTags_TooMany(t *testing.T) {
+	// Setup
+	service := NewTagService(new(MockTagRepository), new(MockPostRepository), nil)
+	raw := []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k"}
+
+	// Execute
+	tags, err := service.ResolveTags(context.Background(), raw)
+
+	// Assert
+	assert.Equal(t, AppError.ErrValidationFailed, err)
+	assert.Nil(t, tags)
+}
+
+func TestTagService_ListTags_IncludesUnregisteredTags(t *testing.T) {
+	// Setup
+	tagRepo := new(MockTagRepository)
+	postRepo := new(MockPostRepository)
+	service := NewTagService(tagRepo, postRepo, nil)
+
+	tagRepo.On("FindAll", mock.Anything).Return([]*entities.Tag{
+		{Name: "go", Description: "The Go language"},
+		{Name: "rust"},
+	}, nil)
+	postRepo.On("CountTags", mock.Anything, true).Return(map[string]int{"go": 3, "web": 5}, nil)
+
+	// Execute
+	popular, err := service.ListTags(context.Background(), SortPopular, 0)
+	assert.NoError(t, err)
+	byName, err := service.ListTags(context.Background(), SortName, 2)
+	assert.NoError(t, err)
+
+	// Assert
+	assert.Len(t, popular, 3)
+	assert.Equal(t, "web", popular[0].Name)
+	assert.Equal(t, 5, popular[0].PostCount)
+	assert.Equal(t, "go", popular[1].Name)
+	assert.Equal(t, "The Go language", popular[1].Description)
+	assert.Equal(t, "rust", popular[2].Name)
+	assert.Equal(t, 0, popular[2].PostCount)
+
+	assert.Len(t, byName, 2)
+	assert.Equal(t, "go", byName[0].Name)
+	assert.Equal(t, "rust", byName[1].Name)
+}
+
+func TestTagService_ListTags_InvalidSort(t *testing.T) {
+	// Setup
+	service := NewTagService(new(MockTagRepository), new(MockPostRepository), nil)
+
+	// Execute
+	tags, err := service.ListTags(context.Background(), "random", 0)
+
+	// Assert
+	assert.Equal(t, AppError.ErrValidationFailed, err)
+	assert.Nil(t, tags)
+}
+
+func TestTagService_GetTag_ByAlias(t *testing.T) {
+	// Setup
+	tagRepo := new(MockTagRepository)
+	postRepo := new(MockPostRepository)
+	service := NewTagService(tagRepo, postRepo, nil)
+
+	tagRepo.On("FindByName", mock.Anything, "golang").Return((*entities.Tag)(nil), AppError.ErrNotFound)
+	tagRepo.On("FindByAliases", mock.Anything, []string{"golang"}).
+		Return([]*entities.Tag{{Name: "go", Aliases: []string{"golang"}, CreatedAt: time.Now()}}, nil)
+	postRepo.On("CountTags", mock.Anything, true).Return(map[string]int{"go": 2}, nil)
+
+	// Execute
+	tag, err := service.GetTag(context.Background(), "Golang")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "go", tag.Name)
+	assert.Equal(t, 2, tag.PostCount)
+}
+
+func TestTagService_GetTag_Unknown(t *testing.T) {
+	// Setup
+	tagRepo := new(MockTagRepository)
+	postRepo := new(MockPostRepository)
+	service := NewTagService(tagRepo, postRepo, nil)
+
+	tagRepo.On("FindByName", mock.Anything, "nope").Return((*entities.Tag)(nil), AppError.ErrNotFound)
+	tagRepo.On("FindByAliases", mock.Anything, []string{"nope"}).Return([]*entities.Tag{}, nil)
+	postRepo.On("CountTags", mock.Anything, true).Return(map[string]int{}, nil)
+
+	// Execute
+	tag, err := service.GetTag(context.Background(), "nope")
+
+	// Assert
+	assert.Equal(t, AppError.ErrNotFound, err)
+	assert.Nil(t, tag)
+}
+
+func TestTagService_RenameTag_TargetExists(t *testing.T) {
+	// Setup
+	tagRepo := new(MockTagRepository)
+	postRepo := new(MockPostRepository)
+	retagger := new(MockPostRetagger)
+	service := NewTagService(tagRepo, postRepo, retagger)
+
+	tagRepo.On("FindByName", mock.Anything, "go").Return(&entities.Tag{Name: "go"}, nil)
+
+	// Execute
+	tag, modified, err := service.RenameTag(context.Background(), "golang", "go", "admin-1")
+
+	// Assert
+	assert.Equal(t, AppError.ErrTagExists, err)
+	assert.Nil(t, tag)
+	assert.Zero(t, modified)
+	retagger.AssertNotCalled(t, "ReplaceTags", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTagService_MergeTags_RewritesEverySpelling(t *testing.T) {
+	// Setup
+	tagRepo := new(MockTagRepository)
+	postRepo := new(MockPostRepository)
+	retagger := new(MockPostRetagger)
+	service := NewTagService(tagRepo, postRepo, retagger)
+
+	merged := &entities.Tag{Name: "go", Aliases: []string{"golang", "go-lang"}}
+	tagRepo.On("FindByAliases", mock.Anything, []string{"go"}).Return([]*entities.Tag{}, nil)
+	postRepo.On("CountTags", mock.Anything, false).
+		Return(map[string]int{"Golang": 1, "golang": 4, "go-lang": 1, "go": 2, "web": 7}, nil)
+	retagger.On("ReplaceTags", mock.Anything, []string{"Golang", "go-lang", "golang"}, "go", "admin-1").Return(int64(6), nil)
+	tagRepo.On("Merge", mock.Anything, []string{"golang", "go-lang"}, "go").Return(merged, nil)
+	postRepo.On("CountTags", mock.Anything, true).Return(map[string]int{"go": 8, "web": 7}, nil)
+
+	// Execute
+	tag, modified, err := service.MergeTags(context.Background(), []string{"golang", "Go Lang"}, "Go", "admin-1")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(6), modified)
+	assert.Equal(t, "go", tag.Name)
+	assert.Equal(t, 8, tag.PostCount)
+	postRepo.AssertExpectations(t)
+	retagger.AssertExpectations(t)
+	tagRepo.AssertExpectations(t)
+}
+
+func TestTagService_RenameTag_ResumesAfterFailure(t *testing.T) {
+	// Setup
+	tagRepo := new(MockTagRepository)
+	postRepo := new(MockPostRepository)
+	retagger := new(MockPostRetagger)
+	service := NewTagService(tagRepo, postRepo, retagger)
+	ctx := context.Background()
+
+	renamed := &entities.Tag{Name: "go", Aliases: []string{"golang"}}
+	tagRepo.On("FindByName", mock.Anything, "go").Return((*entities.Tag)(nil), AppError.ErrNotFound).Once()
+	tagRepo.On("FindByAliases", mock.Anything, []string{"go"}).Return([]*entities.Tag{}, nil)
+	postRepo.On("CountTags", mock.Anything, false).Return(map[string]int{"golang": 3}, nil)
+	tagRepo.On("Merge", mock.Anything, []string{"golang"}, "go").Return(renamed, nil)
+	retagger.On("ReplaceTags", mock.Anything, []string{"golang"}, "go", "admin-1").
+		Return(int64(1), AppError.ErrInternalServer).Once()
+
+	// Execute: the registry is updated, then rewriting the posts fails partway
+	_, _, firstErr := service.RenameTag(ctx, "golang", "go", "admin-1")
+
+	// Assert: the posts left behind carry a name that already resolves to the new tag
+	assert.Equal(t, AppError.ErrInternalServer, firstErr)
+	tagRepo.On("FindByAliases", mock.Anything, []string{"golang"}).Return([]*entities.Tag{renamed}, nil)
+	tagRepo.On("EnsureTags", mock.Anything, []string{"go"}).Return(nil)
+	resolved, err := service.ResolveTags(ctx, []string{"golang"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"go"}, resolved)
+
+	// Execute: repeating the rename completes it instead of reporting the new name as taken
+	tagRepo.On("FindByName", mock.Anything, "go").Return(renamed, nil)
+	retagger.On("ReplaceTags", mock.Anything, []string{"golang"}, "go", "admin-1").Return(int64(2), nil).Once()
+	postRepo.On("CountTags", mock.Anything, true).Return(map[string]int{"go": 3}, nil)
+	tag, modified, err := service.RenameTag(ctx, "golang", "go", "admin-1")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), modified)
+	assert.Equal(t, 3, tag.PostCount)
+	retagger.AssertExpectations(t)
+	tagRepo.AssertNumberOfCalls(t, "Merge", 2)
+}
+
+func TestTagService_MergeTags_TargetIsAliasOfAnotherTag(t *testing.T) {
+	// Setup
+	tagRepo := new(MockTagRepository)
+	postRepo := new(MockPostRepository)
+	service := NewTagService(tagRepo, postRepo, nil)
+
+	tagRepo.On("FindByAliases", mock.Anything, []string{"golang"}).
+		Return([]*entities.Tag{{Name: "go", Aliases: []string{"golang"}}}, nil)
+
+	// Execute
+	tag, _, err := service.MergeTags(context.Background(), []string{"go-lang"}, "golang", "admin-1")
+
+	// Assert
+	assert.Equal(t, AppError.ErrTagExists, err)
+	assert.Nil(t, tag)
+	postRepo.AssertNotCalled(t, "CountTags", mock.Anything, mock.Anything)
+}
+
+func TestTagService_NormalizeAll(t *testing.T) {
+	// Setup
+	tagRepo := new(MockTagRepository)
+	postRepo := new(MockPostRepository)
+	retagger := new(MockPostRetagger)
+	service := NewTagService(tagRepo, postRepo, retagger)
+
+	postRepo.On("CountTags", mock.Anything, false).Return(map[string]int{"Go ": 1, "go": 3}, nil)
+	tagRepo.On("FindByAliases", mock.Anything, mock.Anything).Return([]*entities.Tag{}, nil)
+	retagger.On("ReplaceTags", mock.Anything, []string{"Go "}, "go", "").Return(int64(1), nil)
+	tagRepo.On("EnsureTags", mock.Anything, []string{"go"}).Return(nil)
+
+	// Execute
+	modified, err := service.NormalizeAll(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), modified)
+	postRepo.AssertExpectations(t)
+	retagger.AssertExpectations(t)
+	tagRepo.AssertExpectations(t)
+}
@@ -0,0 +1,292 @@
+package transfersvc
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"anchor-blog/internal/domain/entities"
+	AppError "anchor-blog/internal/errors"
+	postsvc "anchor-blog/internal/service/post"
+	"anchor-blog/pkg/frontmatter"
+)
+
+const (
+	exportBatchSize = 200
+	// limits on what a single archive may contain, so a crafted zip can't exhaust memory
+	MaxFiles    = 10000
+	MaxFileSize = 5 << 20
+)
+
+// FrontMatter is the YAML header of an exported post. The author is a username so
+// archives can move between installations where user ids differ.
+type FrontMatter struct {
+	Title      string    `yaml:"title"`
+	Slug       string    `yaml:"slug,omitempty"`
+	Author     string    `yaml:"author"`
+	Tags       []string  `yaml:"tags,omitempty"`
+	Status     string    `yaml:"status,omitempty"`
+	Visibility string    `yaml:"visibility,omitempty"`
+	PublishAt  time.Time `yaml:"publish_at,omitempty"`
+	CreatedAt  time.Time `yaml:"created_at"`
+	UpdatedAt  time.Time `yaml:"updated_at"`
+	Views      int       `yaml:"views"`
+}
+
+// FileResult is the outcome of importing one file of an archive
+type FileResult struct {
+	File   string `json:"file"`
+	PostID string `json:"post_id,omitempty"`
+	Slug   string `json:"slug,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// ImportReport lists the outcome of every Markdown file of an archive
+type ImportReport struct {
+	Imported int          `json:"imported"`
+	Failed   int          `json:"failed"`
+	Files    []FileResult `json:"files"`
+}
+
+type TransferService struct {
+	postService *postsvc.PostService
+	postRepo    entities.IPostRepository
+	userRepo    entities.IUserReaderRepository
+}
+
+// NewTransferService creates a new import/export service.
+// Imports go through the post service so that change listeners see the new posts.
+func NewTransferService(postService *postsvc.PostService, postRepo entities.IPostRepository, userRepo entities.IUserReaderRepository) *TransferService {
+	return &TransferService{postService: postService, postRepo: postRepo, userRepo: userRepo}
+}
+
+// Export writes the posts of every status as a zip of Markdown files, one per post.
+// A non-empty author username limits the export to that author's posts.
+func (s *TransferService) Export(ctx context.Context, w io.Writer, author string) (int, error) {
+	authorID := ""
+	if author != "" {
+		user, err := s.userRepo.GetUserByUsername(ctx, strings.TrimSpace(author))
+		if err != nil {
+			return 0, err
+		}
+		authorID = user.ID
+	}
+
+	archive := zip.NewWriter(w)
+	usernames := map[string]string{}
+	names := map[string]bool{}
+	exported := 0
+
+	for page := int64(1); ; page++ {
+		posts, err := s.postRepo.FindByAuthorAndStatus(ctx, authorID, "", entities.PaginationOptions{Page: page, Limit: exportBatchSize})
+		if err != nil {
+			return exported, err
+		}
+
+		for _, post := range posts {
+			username, ok := usernames[post.AuthorID]
+			if !ok {
+				username = s.lookupUsername(ctx, post.AuthorID)
+				usernames[post.AuthorID] = username
+			}
+
+			doc, err := frontmatter.Marshal(FrontMatter{
+				Title:      post.Title,
+				Slug:       post.Slug,
+				Author:     username,
+				Tags:       post.Tags,
+				Status:     post.Status,
+				Visibility: post.Visibility,
+				PublishAt:  post.PublishAt.UTC(),
+				CreatedAt:  post.CreatedAt.UTC(),
+				UpdatedAt:  post.UpdatedAt.UTC(),
+				Views:      post.ViewCount,
+			}, post.Content)
+			if err != nil {
+				return exported, AppError.ErrInternalServer
+			}
+
+			file, err := archive.CreateHeader(&zip.FileHeader{
+				Name:     fileName(post, names),
+				Method:   zip.Deflate,
+				Modified: post.UpdatedAt,
+			})
+			if err != nil {
+				return exported, err
+			}
+			if _, err := file.Write(doc); err != nil {
+				return exported, err
+			}
+			exported++
+		}
+
+		if len(posts) < exportBatchSize {
+			break
+		}
+	}
+
+	return exported, archive.Close()
+}
+
+// Import creates a post for every Markdown file of a zip archive. Files that can't be
+// imported are reported and skipped; only an unreadable archive fails the whole import.
+// Posts whose slug already exists are skipped, so re-running an import is safe.
+func (s *TransferService) Import(ctx context.Context, r io.ReaderAt, size int64) (*ImportReport, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, AppError.ErrValidationFailed
+	}
+
+	var files []*zip.File
+	for _, file := range archive.File {
+		if !file.FileInfo().IsDir() && strings.EqualFold(path.Ext(file.Name), ".md") {
+			files = append(files, file)
+		}
+	}
+	if len(files) > MaxFiles {
+		return nil, AppError.ErrValidationFailed
+	}
+
+	report := &ImportReport{Files: []FileResult{}}
+	authors := map[string]string{}
+	for _, file := range files {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+
+		result := FileResult{File: file.Name}
+		post, err := s.importFile(ctx, file, authors)
+		if err != nil {
+			result.Error = err.Error()
+			report.Failed++
+		} else {
+			result.PostID = post.ID
+			result.Slug = post.Slug
+			report.Imported++
+		}
+		report.Files = append(report.Files, result)
+	}
+
+	return report, nil
+}
+
+func (s *TransferService) importFile(ctx context.Context, file *zip.File, authors map[string]string) (*entities.Post, error) {
+	if file.UncompressedSize64 > MaxFileSize {
+		return nil, fmt.Errorf("file is larger than %d bytes", MaxFileSize)
+	}
+	rc, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("unreadable file: %w", err)
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(io.LimitReader(rc, MaxFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("unreadable file: %w", err)
+	}
+	if len(data) > MaxFileSize {
+		return nil, fmt.Errorf("file is larger than %d bytes", MaxFileSize)
+	}
+
+	var meta FrontMatter
+	content, err := frontmatter.Unmarshal(data, &meta)
+	if err != nil {
+		return nil, fmt.Errorf("invalid front matter: %w", err)
+	}
+	meta.Title = strings.TrimSpace(meta.Title)
+	if meta.Title == "" {
+		return nil, errors.New("title is required")
+	}
+	switch meta.Status {
+	case "", entities.PostStatusDraft, entities.PostStatusScheduled, entities.PostStatusPublished, entities.PostStatusArchived:
+	default:
+		return nil, fmt.Errorf("unknown status %q", meta.Status)
+	}
+	if meta.Visibility != "" && !entities.IsValidVisibility(meta.Visibility) {
+		return nil, fmt.Errorf("unknown visibility %q", meta.Visibility)
+	}
+	if meta.Views < 0 {
+		return nil, errors.New("views must not be negative")
+	}
+
+	authorID, err := s.resolveAuthor(ctx, meta.Author, authors)
+	if err != nil {
+		return nil, err
+	}
+
+	if meta.Slug != "" {
+		existing, err := s.postRepo.FindBySlug(ctx, meta.Slug)
+		if err == nil {
+			return nil, fmt.Errorf("a post with slug %q already exists (%s)", meta.Slug, existing.ID)
+		}
+		if err != AppError.ErrNotFound {
+			return nil, err
+		}
+	}
+
+	return s.postService.ImportPost(ctx, &entities.Post{
+		Title:      meta.Title,
+		Slug:       meta.Slug,
+		Content:    content,
+		AuthorID:   authorID,
+		Tags:       meta.Tags,
+		ViewCount:  meta.Views,
+		Status:     meta.Status,
+		Visibility: meta.Visibility,
+		PublishAt:  meta.PublishAt,
+		CreatedAt:  meta.CreatedAt,
+		UpdatedAt:  meta.UpdatedAt,
+	})
+}
+
+// resolveAuthor maps a username to a user id, remembering the answer for later files
+func (s *TransferService) resolveAuthor(ctx context.Context, username string, authors map[string]string) (string, error) {
+	username = strings.TrimSpace(username)
+	if username == "" {
+		return "", errors.New("author is required")
+	}
+	if id, ok := authors[username]; ok {
+		if id == "" {
+			return "", fmt.Errorf("unknown author %q", username)
+		}
+		return id, nil
+	}
+
+	user, err := s.userRepo.GetUserByUsername(ctx, username)
+	if err != nil {
+		if err != AppError.ErrUserNotFound && err != AppError.ErrNotFound {
+			return "", err
+		}
+		authors[username] = ""
+		return "", fmt.Errorf("unknown author %q", username)
+	}
+	authors[username] = user.ID
+	return user.ID, nil
+}
+
+func (s *TransferService) lookupUsername(ctx context.Context, userID string) string {
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil || user == nil {
+		return ""
+	}
+	return user.Username
+}
+
+// fileName names a post's file after its slug, falling back to the id for posts stored
+// before slugs existed
+func fileName(post *entities.Post, taken map[string]bool) string {
+	base := post.Slug
+	if base == "" {
+		base = post.ID
+	}
+	name := base + ".md"
+	for n := 2; taken[name]; n++ {
+		name = fmt.Sprintf("%s-%d.md", base, n)
+	}
+	taken[name] = true
+	return name
+}
@@ -0,0 +1,185 @@
+package transfersvc
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"anchor-blog/internal/domain/entities"
+	AppError "anchor-blog/internal/errors"
+	postsvc "anchor-blog/internal/service/post"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Mock post repository; only listing, slug lookups and imports are used
+type MockPostRepository struct {
+	entities.IPostRepository
+	mock.Mock
+}
+
+func (m *MockPostRepository) FindByAuthorAndStatus(ctx context.Context, authorID, status string, opts entities.PaginationOptions) ([]*entities.Post, error) {
+	args := m.Called(ctx, authorID, status, opts)
+	return args.Get(0).([]*entities.Post), args.Error(1)
+}
+
+func (m *MockPostRepository) FindBySlug(ctx context.Context, slug string) (*entities.Post, error) {
+	args := m.Called(ctx, slug)
+	return args.Get(0).(*entities.Post), args.Error(1)
+}
+
+func (m *MockPostRepository) Import(ctx context.Context, post *entities.Post) (*entities.Post, error) {
+	args := m.Called(ctx, post)
+	return args.Get(0).(*entities.Post), args.Error(1)
+}
+
+// Mock user repository; only the lookups between ids and usernames are used
+type MockUserRepository struct {
+	entities.IUserReaderRepository
+	mock.Mock
+}
+
+func (m *MockUserRepository) GetUserByID(ctx context.Context, id string) (*entities.User, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(*entities.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetUserByUsername(ctx context.Context, username string) (*entities.User, error) {
+	args := m.Called(ctx, username)
+	return args.Get(0).(*entities.User), args.Error(1)
+}
+
+var created = time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+
+func setupTransfer() (*TransferService, *MockPostRepository, *MockUserRepository) {
+	postRepo := new(MockPostRepository)
+	userRepo := new(MockUserRepository)
+	return NewTransferService(postsvc.NewPostService(postRepo, userRepo), postRepo, userRepo), postRepo, userRepo
+}
+
+func readArchive(t *testing.T, data []byte) map[string]string {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	files := map[string]string{}
+	for _, file := range archive.File {
+		rc, err := file.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+		files[file.Name] = string(content)
+	}
+	return files
+}
+
+func writeArchive(t *testing.T, files map[string]string) *bytes.Reader {
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := archive.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, archive.Close())
+	return bytes.NewReader(buf.Bytes())
+}
+
+func TestTransferService_Export_ByAuthor(t *testing.T) {
+	// Setup
+	service, postRepo, userRepo := setupTransfer()
+
+	userRepo.On("GetUserByUsername", mock.Anything, "alice").Return(&entities.User{ID: "author-1", Username: "alice"}, nil)
+	userRepo.On("GetUserByID", mock.Anything, "author-1").Return(&entities.User{ID: "author-1", Username: "alice"}, nil)
+	postRepo.On("FindByAuthorAndStatus", mock.Anything, "author-1", "", entities.PaginationOptions{Page: 1, Limit: exportBatchSize}).Return([]*entities.Post{
+		{ID: "post-1", Title: "Hello", Slug: "hello", AuthorID: "author-1", Tags: []string{"go"}, Content: "# Hi", Status: entities.PostStatusDraft, ViewCount: 7, CreatedAt: created, UpdatedAt: created.Add(time.Hour)},
+		{ID: "post-2", Title: "Legacy", AuthorID: "author-1", Content: "old", CreatedAt: created},
+	}, nil)
+
+	// Execute
+	var buf bytes.Buffer
+	exported, err := service.Export(context.Background(), &buf, "alice")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, exported)
+	files := readArchive(t, buf.Bytes())
+	assert.Len(t, files, 2)
+	assert.Contains(t, files["hello.md"], "title: Hello\n")
+	assert.Contains(t, files["hello.md"], "author: alice\n")
+	assert.Contains(t, files["hello.md"], "status: draft\n")
+	assert.Contains(t, files["hello.md"], "created_at: 2024-05-01T08:00:00Z\n")
+	assert.Contains(t, files["hello.md"], "updated_at: 2024-05-01T09:00:00Z\n")
+	assert.Contains(t, files["hello.md"], "views: 7\n")
+	assert.Contains(t, files["hello.md"], "---\n\n# Hi\n")
+	assert.NotContains(t, files["hello.md"], "publish_at")
+	assert.Contains(t, files, "post-2.md")
+	userRepo.AssertNumberOfCalls(t, "GetUserByID", 1)
+}
+
+func TestTransferService_Export_UnknownAuthor(t *testing.T) {
+	service, _, userRepo := setupTransfer()
+	userRepo.On("GetUserByUsername", mock.Anything, "nobody").Return(&entities.User{}, AppError.ErrNotFound)
+
+	_, err := service.Export(context.Background(), io.Discard, "nobody")
+
+	assert.Equal(t, AppError.ErrNotFound, err)
+}
+
+func TestTransferService_Import_ReportsPerFileErrors(t *testing.T) {
+	// Setup
+	service, postRepo, userRepo := setupTransfer()
+
+	archive := writeArchive(t, map[string]string{
+		"posts/hello.md": "---\ntitle: Hello\nslug: hello\nauthor: alice\ntags: [Go]\nstatus: published\n" +
+			"publish_at: 2024-05-02T08:00:00Z\ncreated_at: 2024-05-01T08:00:00Z\nupdated_at: 2024-05-03T08:00:00Z\nviews: 12\n---\n\nBody\n",
+		"taken.md":    "---\ntitle: Taken\nslug: taken\nauthor: alice\n---\nBody\n",
+		"stranger.md": "---\ntitle: Stranger\nauthor: bob\n---\nBody\n",
+		"untitled.md": "---\nauthor: alice\n---\nBody\n",
+		"plain.md":    "no front matter",
+		"image.png":   "not markdown",
+	})
+
+	userRepo.On("GetUserByUsername", mock.Anything, "alice").Return(&entities.User{ID: "author-1", Username: "alice"}, nil)
+	userRepo.On("GetUserByUsername", mock.Anything, "bob").Return(&entities.User{}, AppError.ErrNotFound)
+	postRepo.On("FindBySlug", mock.Anything, "hello").Return((*entities.Post)(nil), AppError.ErrNotFound)
+	postRepo.On("FindBySlug", mock.Anything, "taken").Return(&entities.Post{ID: "post-9"}, nil)
+	postRepo.On("Import", mock.Anything, mock.MatchedBy(func(p *entities.Post) bool {
+		return p.Title == "Hello" && p.Slug == "hello" && p.AuthorID == "author-1" && p.Content == "Body" &&
+			assert.ObjectsAreEqual([]string{"go"}, p.Tags) && p.ViewCount == 12 && p.Status == entities.PostStatusPublished &&
+			p.PublishAt.Equal(created.Add(24*time.Hour)) && p.CreatedAt.Equal(created) && p.UpdatedAt.Equal(created.Add(48*time.Hour))
+	})).Return(&entities.Post{ID: "post-1", Slug: "hello"}, nil)
+
+	// Execute
+	report, err := service.Import(context.Background(), archive, archive.Size())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.Imported)
+	assert.Equal(t, 4, report.Failed)
+	errs := map[string]string{}
+	for _, file := range report.Files {
+		errs[file.File] = file.Error
+	}
+	assert.Len(t, errs, 5)
+	assert.Empty(t, errs["posts/hello.md"])
+	assert.Contains(t, errs["taken.md"], "already exists")
+	assert.Contains(t, errs["stranger.md"], `unknown author "bob"`)
+	assert.Contains(t, errs["untitled.md"], "title is required")
+	assert.Contains(t, errs["plain.md"], "invalid front matter")
+	postRepo.AssertNumberOfCalls(t, "Import", 1)
+}
+
+func TestTransferService_Import_InvalidArchive(t *testing.T) {
+	service, _, _ := setupTransfer()
+	data := bytes.NewReader([]byte("not a zip"))
+
+	_, err := service.Import(context.Background(), data, data.Size())
+
+	assert.Equal(t, AppError.ErrValidationFailed, err)
+}
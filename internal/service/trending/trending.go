@@ -0,0 +1,244 @@
+package trendingsvc
+
+import (
+	"context"
+	"log"
+	"math"
+	"sort"
+	"time"
+
+	"anchor-blog/internal/domain/entities"
+	AppError "anchor-blog/internal/errors"
+)
+
+// Points a post earns for its activity within a window; a like weighs as much as five views
+const (
+	viewWeight    = 1.0
+	likeWeight    = 5.0
+	dislikeWeight = 5.0
+)
+
+const (
+	defaultGravity = 1.8
+	maxEntries     = 500 // posts kept per snapshot
+)
+
+// windowOrder fixes the order in which windows are recomputed
+var windowOrder = []string{entities.TrendingDay, entities.TrendingWeek, entities.TrendingMonth}
+
+type TrendingService struct {
+	trendingRepo  entities.ITrendingRepository
+	viewStatsRepo entities.IViewStatsRepository
+	reactionRepo  entities.IReactionRepository
+	postRepo      entities.IPostRepository
+	interval      time.Duration
+	gravity       float64
+}
+
+// NewTrendingService creates a new trending service.
+// gravity controls how fast a post sinks with age; zero falls back to 1.8 like Hacker News.
+func NewTrendingService(trendingRepo entities.ITrendingRepository, viewStatsRepo entities.IViewStatsRepository, reactionRepo entities.IReactionRepository, postRepo entities.IPostRepository, intervalSeconds int, gravity float64) *TrendingService {
+	if intervalSeconds <= 0 {
+		intervalSeconds = 300 // Default interval
+	}
+	if gravity <= 0 {
+		gravity = defaultGravity
+	}
+	return &TrendingService{
+		trendingRepo:  trendingRepo,
+		viewStatsRepo: viewStatsRepo,
+		reactionRepo:  reactionRepo,
+		postRepo:      postRepo,
+		interval:      time.Duration(intervalSeconds) * time.Second,
+		gravity:       gravity,
+	}
+}
+
+// TrendingPost is a post together with its trending score
+type TrendingPost struct {
+	Post  *entities.Post
+	Score float64
+}
+
+// TrendingPage is a page of a window's ranking
+type TrendingPage struct {
+	Window     string
+	ComputedAt time.Time
+	Posts      []*TrendingPost
+}
+
+// Score ranks a post by its recent activity, decayed by its age:
+// points / (hours + 2)^gravity. Posts with no positive points score zero.
+func Score(views, likes, dislikes int, age time.Duration, gravity float64) float64 {
+	points := viewWeight*float64(views) + likeWeight*float64(likes) - dislikeWeight*float64(dislikes)
+	if points <= 0 {
+		return 0
+	}
+	hours := math.Max(age.Hours(), 0)
+	return points / math.Pow(hours+2, gravity)
+}
+
+// Start recomputes the rankings in the background until ctx is cancelled
+func (s *TrendingService) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		s.Recompute(ctx)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.Recompute(ctx)
+			}
+		}
+	}()
+}
+
+// Recompute rebuilds the snapshot of every window. A failing window keeps its previous snapshot.
+func (s *TrendingService) Recompute(ctx context.Context) error {
+	var firstErr error
+	now := time.Now()
+	for _, window := range windowOrder {
+		snapshot, err := s.computeSnapshot(ctx, window, now)
+		if err == nil {
+			err = s.trendingRepo.SaveSnapshot(ctx, snapshot)
+		}
+		if err != nil {
+			log.Printf("Error computing %s trending posts: %v", window, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
+
+func (s *TrendingService) computeSnapshot(ctx context.Context, window string, now time.Time) (*entities.TrendingSnapshot, error) {
+	since := now.Add(-entities.TrendingWindows[window])
+
+	views, err := s.viewStatsRepo.CountSince(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+	likes, err := s.reactionRepo.CountSince(ctx, entities.ReactionLike, since)
+	if err != nil {
+		return nil, err
+	}
+	dislikes, err := s.reactionRepo.CountSince(ctx, entities.ReactionDislike, since)
+	if err != nil {
+		return nil, err
+	}
+
+	postIDs := activePostIDs(views, likes, dislikes)
+	posts, err := s.postRepo.FindByIDs(ctx, postIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]entities.TrendingEntry, 0, len(posts))
+	for _, post := range posts {
+		if !post.IsListed() {
+			continue
+		}
+		publishedAt := post.PublishAt
+		if publishedAt.IsZero() {
+			publishedAt = post.CreatedAt
+		}
+		score := Score(views[post.ID], likes[post.ID], dislikes[post.ID], now.Sub(publishedAt), s.gravity)
+		if score > 0 {
+			entries = append(entries, entities.TrendingEntry{PostID: post.ID, Score: score})
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Score != entries[j].Score {
+			return entries[i].Score > entries[j].Score
+		}
+		return entries[i].PostID < entries[j].PostID
+	})
+	if len(entries) > maxEntries {
+		entries = entries[:maxEntries]
+	}
+
+	return &entities.TrendingSnapshot{
+		Window:     window,
+		Entries:    entries,
+		ComputedAt: now,
+	}, nil
+}
+
+// GetTrending returns a page of the window's latest ranking. The window defaults to a day.
+// Posts that were unpublished or deleted since the ranking was computed are left out.
+func (s *TrendingService) GetTrending(ctx context.Context, window string, page, limit int64) (*TrendingPage, error) {
+	if window == "" {
+		window = entities.TrendingDay
+	}
+	if _, ok := entities.TrendingWindows[window]; !ok {
+		return nil, AppError.ErrValidationFailed
+	}
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = 20
+	}
+
+	result := &TrendingPage{Window: window, Posts: []*TrendingPost{}}
+
+	snapshot, err := s.trendingRepo.FindSnapshot(ctx, window)
+	if err == AppError.ErrNotFound {
+		// not computed yet
+		return result, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	result.ComputedAt = snapshot.ComputedAt
+
+	start := (page - 1) * limit
+	if start >= int64(len(snapshot.Entries)) {
+		return result, nil
+	}
+	end := min(start+limit, int64(len(snapshot.Entries)))
+	entries := snapshot.Entries[start:end]
+
+	postIDs := make([]string, len(entries))
+	for i, entry := range entries {
+		postIDs[i] = entry.PostID
+	}
+	posts, err := s.postRepo.FindByIDs(ctx, postIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]*entities.Post, len(posts))
+	for _, post := range posts {
+		byID[post.ID] = post
+	}
+	for _, entry := range entries {
+		post, ok := byID[entry.PostID]
+		if !ok || !post.IsListed() {
+			continue
+		}
+		result.Posts = append(result.Posts, &TrendingPost{Post: post, Score: entry.Score})
+	}
+
+	return result, nil
+}
+
+func activePostIDs(counts ...map[string]int) []string {
+	seen := make(map[string]bool)
+	postIDs := []string{}
+	for _, count := range counts {
+		for postID := range count {
+			if !seen[postID] {
+				seen[postID] = true
+				postIDs = append(postIDs, postID)
+			}
+		}
+	}
+	sort.Strings(postIDs)
+	return postIDs
+}
@@ -0,0 +1,160 @@
+package trendingsvc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"anchor-blog/internal/domain/entities"
+	AppError "anchor-blog/internal/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTrendingRepository struct {
+	mock.Mock
+}
+
+func (m *MockTrendingRepository) SaveSnapshot(ctx context.Context, snapshot *entities.TrendingSnapshot) error {
+	args := m.Called(ctx, snapshot)
+	return args.Error(0)
+}
+
+func (m *MockTrendingRepository) FindSnapshot(ctx context.Context, window string) (*entities.TrendingSnapshot, error) {
+	args := m.Called(ctx, window)
+	return args.Get(0).(*entities.TrendingSnapshot), args.Error(1)
+}
+
+type MockViewStatsRepository struct {
+	mock.Mock
+}
+
+func (m *MockViewStatsRepository) RecordView(ctx context.Context, postID string, at time.Time) error {
+	args := m.Called(ctx, postID, at)
+	return args.Error(0)
+}
+
+func (m *MockViewStatsRepository) CountSince(ctx context.Context, since time.Time) (map[string]int, error) {
+	args := m.Called(ctx, since)
+	return args.Get(0).(map[string]int), args.Error(1)
+}
+
+// Mock reaction repository; only the recent counts are used by the trending service
+type MockReactionRepository struct {
+	entities.IReactionRepository
+	mock.Mock
+}
+
+func (m *MockReactionRepository) CountSince(ctx context.Context, kind string, since time.Time) (map[string]int, error) {
+	args := m.Called(ctx, kind, since)
+	return args.Get(0).(map[string]int), args.Error(1)
+}
+
+// Mock post repository; only the bulk lookup is used by the trending service
+type MockPostRepository struct {
+	entities.IPostRepository
+	mock.Mock
+}
+
+func (m *MockPostRepository) FindByIDs(ctx context.Context, ids []string) ([]*entities.Post, error) {
+	args := m.Called(ctx, ids)
+	return args.Get(0).([]*entities.Post), args.Error(1)
+}
+
+func TestScore(t *testing.T) {
+	fresh := Score(10, 2, 0, time.Hour, defaultGravity)
+	old := Score(10, 2, 0, 48*time.Hour, defaultGravity)
+
+	assert.Greater(t, fresh, old)
+	assert.Greater(t, Score(10, 2, 0, time.Hour, defaultGravity), Score(10, 0, 0, time.Hour, defaultGravity))
+	assert.Greater(t, Score(10, 0, 0, time.Hour, defaultGravity), Score(10, 0, 1, time.Hour, defaultGravity))
+	assert.Zero(t, Score(2, 0, 1, time.Hour, defaultGravity))
+	assert.Equal(t, Score(5, 0, 0, 0, defaultGravity), Score(5, 0, 0, -time.Hour, defaultGravity))
+}
+
+func TestTrendingService_ComputeSnapshot_DecaysWithAge(t *testing.T) {
+	// Setup
+	viewStatsRepo := new(MockViewStatsRepository)
+	reactionRepo := new(MockReactionRepository)
+	postRepo := new(MockPostRepository)
+	service := NewTrendingService(new(MockTrendingRepository), viewStatsRepo, reactionRepo, postRepo, 0, 0)
+
+	now := time.Date(2025, 8, 7, 12, 0, 0, 0, time.UTC)
+	since := now.Add(-24 * time.Hour)
+	viewStatsRepo.On("CountSince", mock.Anything, since).Return(map[string]int{"evergreen": 100, "new-hit": 40, "draft": 500}, nil)
+	reactionRepo.On("CountSince", mock.Anything, entities.ReactionLike, since).Return(map[string]int{"new-hit": 5}, nil)
+	reactionRepo.On("CountSince", mock.Anything, entities.ReactionDislike, since).Return(map[string]int{"flop": 3}, nil)
+	postRepo.On("FindByIDs", mock.Anything, []string{"draft", "evergreen", "flop", "new-hit"}).Return([]*entities.Post{
+		{ID: "draft", Status: entities.PostStatusDraft},
+		{ID: "evergreen", Status: entities.PostStatusPublished, PublishAt: now.AddDate(-2, 0, 0)},
+		{ID: "flop", Status: entities.PostStatusPublished, PublishAt: now.Add(-time.Hour)},
+		{ID: "new-hit", Status: entities.PostStatusPublished, CreatedAt: now.Add(-3 * time.Hour)},
+	}, nil)
+
+	// Execute
+	snapshot, err := service.computeSnapshot(context.Background(), entities.TrendingDay, now)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, entities.TrendingDay, snapshot.Window)
+	assert.Equal(t, now, snapshot.ComputedAt)
+	assert.Len(t, snapshot.Entries, 2)
+	assert.Equal(t, "new-hit", snapshot.Entries[0].PostID)
+	assert.Equal(t, "evergreen", snapshot.Entries[1].PostID)
+}
+
+func TestTrendingService_GetTrending_InvalidWindow(t *testing.T) {
+	service := NewTrendingService(new(MockTrendingRepository), nil, nil, nil, 0, 0)
+
+	page, err := service.GetTrending(context.Background(), "year", 1, 10)
+
+	assert.Equal(t, AppError.ErrValidationFailed, err)
+	assert.Nil(t, page)
+}
+
+func TestTrendingService_GetTrending_NotComputedYet(t *testing.T) {
+	// Setup
+	trendingRepo := new(MockTrendingRepository)
+	service := NewTrendingService(trendingRepo, nil, nil, nil, 0, 0)
+
+	trendingRepo.On("FindSnapshot", mock.Anything, entities.TrendingDay).Return((*entities.TrendingSnapshot)(nil), AppError.ErrNotFound)
+
+	// Execute
+	page, err := service.GetTrending(context.Background(), "", 1, 10)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, entities.TrendingDay, page.Window)
+	assert.Empty(t, page.Posts)
+}
+
+func TestTrendingService_GetTrending_PagesThroughSnapshot(t *testing.T) {
+	// Setup
+	trendingRepo := new(MockTrendingRepository)
+	postRepo := new(MockPostRepository)
+	service := NewTrendingService(trendingRepo, nil, nil, postRepo, 0, 0)
+
+	trendingRepo.On("FindSnapshot", mock.Anything, entities.TrendingWeek).Return(&entities.TrendingSnapshot{
+		Window: entities.TrendingWeek,
+		Entries: []entities.TrendingEntry{
+			{PostID: "post-1", Score: 9},
+			{PostID: "post-2", Score: 5},
+			{PostID: "post-3", Score: 4},
+			{PostID: "post-4", Score: 1},
+		},
+	}, nil)
+	postRepo.On("FindByIDs", mock.Anything, []string{"post-3", "post-4"}).Return([]*entities.Post{
+		{ID: "post-3", Status: entities.PostStatusArchived},
+		{ID: "post-4", Status: entities.PostStatusPublished},
+	}, nil)
+
+	// Execute
+	page, err := service.GetTrending(context.Background(), entities.TrendingWeek, 2, 2)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, page.Posts, 1)
+	assert.Equal(t, "post-4", page.Posts[0].Post.ID)
+	assert.Equal(t, 1.0, page.Posts[0].Score)
+}
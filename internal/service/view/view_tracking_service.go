@@ -13,13 +13,15 @@ import (
 type ViewTrackingService struct {
 	redisClient     *redisclient.Client
 	postRepo        entities.IPostRepository
+	viewStatsRepo   entities.IViewStatsRepository
 	viewTrackingTTL time.Duration
 }
 
-func NewViewTrackingService(redisClient *redisclient.Client, postRepo entities.IPostRepository, ttlSeconds int) *ViewTrackingService {
+func NewViewTrackingService(redisClient *redisclient.Client, postRepo entities.IPostRepository, viewStatsRepo entities.IViewStatsRepository, ttlSeconds int) *ViewTrackingService {
 	return &ViewTrackingService{
 		redisClient:     redisClient,
 		postRepo:        postRepo,
+		viewStatsRepo:   viewStatsRepo,
 		viewTrackingTTL: time.Duration(ttlSeconds) * time.Second,
 	}
 }
@@ -52,6 +54,13 @@ func (vts *ViewTrackingService) TrackView(ctx context.Context, postID, ipAddress
 			return err
 		}
 
+		// Hourly buckets feed the trending ranking; losing one view there is not worth failing the request
+		if vts.viewStatsRepo != nil {
+			if err := vts.viewStatsRepo.RecordView(ctx, postID, time.Now()); err != nil {
+				log.Printf("Error recording view statistics: %v", err)
+			}
+		}
+
 		log.Printf("View tracked for post %s from IP %s", postID, ipAddress)
 	} else {
 		log.Printf("Duplicate view prevented for post %s from IP %s", postID, ipAddress)
@@ -0,0 +1,410 @@
+package wordpresssvc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"anchor-blog/internal/domain/entities"
+	AppError "anchor-blog/internal/errors"
+	postsvc "anchor-blog/internal/service/post"
+	"anchor-blog/pkg/htmlmd"
+	"anchor-blog/pkg/slugutil"
+	"anchor-blog/pkg/wxr"
+)
+
+// SourceWXR marks jobs importing a WordPress export file
+const SourceWXR = "wxr"
+
+const (
+	defaultDir = "data/imports"
+	// a running job that saved no progress for this long was interrupted
+	staleAfter = 2 * time.Minute
+	// how often a running job logs its progress, in items
+	logEvery = 100
+)
+
+var invalidUsernameChars = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+
+// WordPress post statuses and what they become here; anything else is skipped
+var statuses = map[string]string{
+	"publish": entities.PostStatusPublished,
+	"future":  entities.PostStatusScheduled,
+	"draft":   entities.PostStatusDraft,
+	"pending": entities.PostStatusDraft,
+	"private": entities.PostStatusDraft,
+}
+
+// errSkipped marks items that are deliberately not imported
+var errSkipped = errors.New("skipped")
+
+type ImportService struct {
+	jobRepo     entities.IImportJobRepository
+	postService *postsvc.PostService
+	postRepo    entities.IPostRepository
+	userRepo    entities.IUserRepository
+	dir         string
+}
+
+// NewImportService creates a new WordPress import service.
+// Uploaded export files are kept in dir until their job completes.
+func NewImportService(jobRepo entities.IImportJobRepository, postService *postsvc.PostService, postRepo entities.IPostRepository, userRepo entities.IUserRepository, dir string) *ImportService {
+	if dir == "" {
+		dir = defaultDir
+	}
+	return &ImportService{
+		jobRepo:     jobRepo,
+		postService: postService,
+		postRepo:    postRepo,
+		userRepo:    userRepo,
+		dir:         dir,
+	}
+}
+
+// StartImport stores an export file and imports it in the background.
+// The returned job can be polled for progress.
+func (s *ImportService) StartImport(ctx context.Context, createdBy, fileName string, file io.Reader) (*entities.ImportJob, error) {
+	job, err := s.CreateJob(ctx, createdBy, fileName, file)
+	if err != nil {
+		return nil, err
+	}
+	go s.runInBackground(job.ID)
+	return job, nil
+}
+
+// CreateJob stores an export file and queues a job for it without running it
+func (s *ImportService) CreateJob(ctx context.Context, createdBy, fileName string, file io.Reader) (*entities.ImportJob, error) {
+	if err := os.MkdirAll(s.dir, 0o750); err != nil {
+		log.Printf("Error creating import directory %s: %v", s.dir, err)
+		return nil, AppError.ErrInternalServer
+	}
+	stored, err := os.CreateTemp(s.dir, "wxr-*.xml")
+	if err != nil {
+		log.Printf("Error storing export file: %v", err)
+		return nil, AppError.ErrInternalServer
+	}
+	_, err = io.Copy(stored, file)
+	if closeErr := stored.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(stored.Name())
+		log.Printf("Error storing export file: %v", err)
+		return nil, AppError.ErrInternalServer
+	}
+
+	// reading the whole file up front rejects broken exports before a job exists
+	total, err := countItems(stored.Name())
+	if err != nil {
+		os.Remove(stored.Name())
+		return nil, AppError.ErrValidationFailed
+	}
+
+	job, err := s.jobRepo.Create(ctx, &entities.ImportJob{
+		Source:    SourceWXR,
+		FileName:  fileName,
+		FilePath:  stored.Name(),
+		CreatedBy: createdBy,
+		Status:    entities.ImportJobQueued,
+		Total:     total,
+		Errors:    []entities.ImportItemError{},
+	})
+	if err != nil {
+		os.Remove(stored.Name())
+		return nil, err
+	}
+	return job, nil
+}
+
+// GetJob returns a job with its progress
+func (s *ImportService) GetJob(ctx context.Context, id string) (*entities.ImportJob, error) {
+	return s.jobRepo.FindByID(ctx, id)
+}
+
+// ListJobs lists jobs, newest first
+func (s *ImportService) ListJobs(ctx context.Context, page, limit int64) ([]*entities.ImportJob, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 20
+	}
+	return s.jobRepo.FindAll(ctx, entities.PaginationOptions{Page: page, Limit: limit})
+}
+
+// Resume continues a failed or interrupted job in the background where it stopped
+func (s *ImportService) Resume(ctx context.Context, id string) (*entities.ImportJob, error) {
+	job, err := s.jobRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status == entities.ImportJobCompleted {
+		return nil, AppError.ErrValidationFailed
+	}
+	claimed, err := s.jobRepo.Claim(ctx, id, time.Now().Add(-staleAfter))
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, AppError.ErrImportJobRunning
+	}
+
+	job.Status = entities.ImportJobRunning
+	job.Error = ""
+	go func() {
+		if _, err := s.process(context.Background(), id); err != nil {
+			log.Printf("Import job %s stopped: %v", id, err)
+		}
+	}()
+	return job, nil
+}
+
+// ResumeInterrupted picks up the jobs that stopped making progress, e.g. because the
+// server was restarted while they ran. It is meant to be called on startup.
+func (s *ImportService) ResumeInterrupted(ctx context.Context) {
+	jobs, err := s.jobRepo.FindStale(ctx, time.Now().Add(-staleAfter))
+	if err != nil {
+		log.Printf("Error looking for interrupted import jobs: %v", err)
+		return
+	}
+	for _, job := range jobs {
+		log.Printf("Resuming import job %s at item %d of %d", job.ID, job.Processed, job.Total)
+		go s.runInBackground(job.ID)
+	}
+}
+
+// Run claims a job and imports it, returning once it completed or failed.
+// Items handled by an earlier run of the job are skipped.
+func (s *ImportService) Run(ctx context.Context, id string) (*entities.ImportJob, error) {
+	claimed, err := s.jobRepo.Claim(ctx, id, time.Now().Add(-staleAfter))
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, AppError.ErrImportJobRunning
+	}
+	return s.process(ctx, id)
+}
+
+func (s *ImportService) runInBackground(id string) {
+	if _, err := s.Run(context.Background(), id); err != nil {
+		log.Printf("Import job %s stopped: %v", id, err)
+	}
+}
+
+func (s *ImportService) process(ctx context.Context, id string) (*entities.ImportJob, error) {
+	job, err := s.jobRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(job.FilePath)
+	if err != nil {
+		return s.fail(ctx, job, "the export file is no longer available")
+	}
+	defer file.Close()
+
+	reader := wxr.NewReader(file)
+	users := map[string]string{}
+	for index := 0; ; index++ {
+		item, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return s.fail(ctx, job, err.Error())
+		}
+		if index < job.Processed {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return job, err
+		}
+
+		err = s.importItem(ctx, reader, item, users, job)
+		switch {
+		case err == errSkipped:
+			job.Skipped++
+		case err != nil:
+			job.Failed++
+			if len(job.Errors) < entities.MaxImportJobErrors {
+				job.Errors = append(job.Errors, entities.ImportItemError{Item: itemLabel(item), Error: err.Error()})
+			}
+		default:
+			job.Imported++
+		}
+		job.Processed++
+
+		// progress is saved after every item, so a resumed job never imports one twice
+		if err := s.jobRepo.Save(ctx, job); err != nil {
+			return job, err
+		}
+		if job.Processed%logEvery == 0 {
+			log.Printf("Import job %s: %d of %d items", job.ID, job.Processed, job.Total)
+		}
+	}
+
+	job.Status = entities.ImportJobCompleted
+	job.FinishedAt = time.Now()
+	path := job.FilePath
+	job.FilePath = ""
+	if err := s.jobRepo.Save(ctx, job); err != nil {
+		return job, err
+	}
+	os.Remove(path)
+	log.Printf("Import job %s completed: %d imported, %d skipped, %d failed", job.ID, job.Imported, job.Skipped, job.Failed)
+	return job, nil
+}
+
+func (s *ImportService) fail(ctx context.Context, job *entities.ImportJob, reason string) (*entities.ImportJob, error) {
+	job.Status = entities.ImportJobFailed
+	job.Error = reason
+	if err := s.jobRepo.Save(ctx, job); err != nil {
+		return job, err
+	}
+	return job, errors.New(reason)
+}
+
+func (s *ImportService) importItem(ctx context.Context, reader *wxr.Reader, item *wxr.Item, users map[string]string, job *entities.ImportJob) error {
+	status, ok := statuses[item.Status]
+	if item.Type != "post" || !ok {
+		return errSkipped
+	}
+	if item.Title == "" {
+		return errors.New("title is empty")
+	}
+
+	// the slug survives from the original site, finding it means the post was imported before
+	slug := ""
+	if item.Slug != "" {
+		slug = slugutil.Slugify(item.Slug)
+		_, err := s.postRepo.FindBySlug(ctx, slug)
+		if err == nil {
+			return errSkipped
+		}
+		if err != AppError.ErrNotFound {
+			return err
+		}
+	}
+
+	authorID, err := s.resolveAuthor(ctx, reader, item.Creator, users, job)
+	if err != nil {
+		return err
+	}
+
+	post := &entities.Post{
+		Title:     item.Title,
+		Slug:      slug,
+		Content:   htmlmd.Convert(item.Content),
+		AuthorID:  authorID,
+		Tags:      append(append([]string{}, item.Categories...), item.Tags...),
+		Status:    status,
+		CreatedAt: item.Published,
+		UpdatedAt: item.Modified,
+	}
+	if status != entities.PostStatusDraft {
+		post.PublishAt = item.Published
+	}
+	if post.UpdatedAt.Before(post.CreatedAt) {
+		post.UpdatedAt = post.CreatedAt
+	}
+	_, err = s.postService.ImportPost(ctx, post)
+	return err
+}
+
+// resolveAuthor finds the user for an author login by email address, then by username,
+// and creates one when neither matches
+func (s *ImportService) resolveAuthor(ctx context.Context, reader *wxr.Reader, login string, users map[string]string, job *entities.ImportJob) (string, error) {
+	if id, ok := users[login]; ok {
+		return id, nil
+	}
+	if login == "" {
+		return "", errors.New("the post has no author")
+	}
+
+	author, ok := reader.Author(login)
+	if !ok {
+		author = wxr.Author{Login: login}
+	}
+	email := strings.ToLower(strings.TrimSpace(author.Email))
+	username := usernameFor(login)
+
+	if email != "" {
+		if user, err := s.userRepo.GetUserByEmail(ctx, email); err == nil && user.ID != "" {
+			users[login] = user.ID
+			return user.ID, nil
+		}
+	}
+	if user, err := s.userRepo.GetUserByUsername(ctx, username); err == nil && user.ID != "" {
+		users[login] = user.ID
+		return user.ID, nil
+	}
+	if email == "" {
+		return "", fmt.Errorf("author %q has no email address to create a user with", login)
+	}
+
+	firstName, lastName := author.FirstName, author.LastName
+	if firstName == "" && lastName == "" {
+		firstName, lastName, _ = strings.Cut(strings.TrimSpace(author.DisplayName), " ")
+	}
+	now := time.Now()
+	id, err := s.userRepo.CreateUser(ctx, &entities.User{
+		Username:  username,
+		FirstName: firstName,
+		LastName:  lastName,
+		Email:     email,
+		Role:      entities.RoleUser,
+		Activated: true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	if err != nil {
+		return "", fmt.Errorf("creating user for author %q: %w", login, err)
+	}
+	job.UsersCreated++
+	users[login] = id
+	return id, nil
+}
+
+// usernameFor turns a WordPress login into a username valid here
+func usernameFor(login string) string {
+	username := strings.Trim(invalidUsernameChars.ReplaceAllString(login, "_"), "_")
+	if len(username) < 3 || !(username[0] >= 'a' && username[0] <= 'z' || username[0] >= 'A' && username[0] <= 'Z') {
+		username = "wp_" + username
+	}
+	return username
+}
+
+func itemLabel(item *wxr.Item) string {
+	if item.Title != "" {
+		return item.Title
+	}
+	return fmt.Sprintf("item %d", item.ID)
+}
+
+func countItems(path string) (int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	reader := wxr.NewReader(file)
+	count := 0
+	for {
+		_, err := reader.Next()
+		if err == io.EOF {
+			return count, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		count++
+	}
+}
@@ -0,0 +1,250 @@
+package wordpresssvc
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"anchor-blog/internal/domain/entities"
+	AppError "anchor-blog/internal/errors"
+	postsvc "anchor-blog/internal/service/post"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockImportJobRepository struct {
+	mock.Mock
+}
+
+func (m *MockImportJobRepository) Create(ctx context.Context, job *entities.ImportJob) (*entities.ImportJob, error) {
+	args := m.Called(ctx, job)
+	return args.Get(0).(*entities.ImportJob), args.Error(1)
+}
+
+func (m *MockImportJobRepository) FindByID(ctx context.Context, id string) (*entities.ImportJob, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(*entities.ImportJob), args.Error(1)
+}
+
+func (m *MockImportJobRepository) FindAll(ctx context.Context, opts entities.PaginationOptions) ([]*entities.ImportJob, error) {
+	args := m.Called(ctx, opts)
+	return args.Get(0).([]*entities.ImportJob), args.Error(1)
+}
+
+func (m *MockImportJobRepository) FindStale(ctx context.Context, before time.Time) ([]*entities.ImportJob, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).([]*entities.ImportJob), args.Error(1)
+}
+
+func (m *MockImportJobRepository) Claim(ctx context.Context, id string, staleBefore time.Time) (bool, error) {
+	args := m.Called(ctx, id, staleBefore)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockImportJobRepository) Save(ctx context.Context, job *entities.ImportJob) error {
+	args := m.Called(ctx, job)
+	return args.Error(0)
+}
+
+// Mock post repository; only slug lookups and imports are used
+type MockPostRepository struct {
+	entities.IPostRepository
+	mock.Mock
+}
+
+func (m *MockPostRepository) FindBySlug(ctx context.Context, slug string) (*entities.Post, error) {
+	args := m.Called(ctx, slug)
+	return args.Get(0).(*entities.Post), args.Error(1)
+}
+
+func (m *MockPostRepository) Import(ctx context.Context, post *entities.Post) (*entities.Post, error) {
+	args := m.Called(ctx, post)
+	return args.Get(0).(*entities.Post), args.Error(1)
+}
+
+// Mock user repository; only the lookups used to match authors and user creation are used
+type MockUserRepository struct {
+	entities.IUserRepository
+	mock.Mock
+}
+
+func (m *MockUserRepository) GetUserByEmail(ctx context.Context, email string) (*entities.User, error) {
+	args := m.Called(ctx, email)
+	return args.Get(0).(*entities.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetUserByUsername(ctx context.Context, username string) (*entities.User, error) {
+	args := m.Called(ctx, username)
+	return args.Get(0).(*entities.User), args.Error(1)
+}
+
+func (m *MockUserRepository) CreateUser(ctx context.Context, user *entities.User) (string, error) {
+	args := m.Called(ctx, user)
+	return args.String(0), args.Error(1)
+}
+
+const export = `<?xml version="1.0" encoding="UTF-8" ?>
+<rss version="2.0" xmlns:content="http://purl.org/rss/1.0/modules/content/"
+	xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:wp="http://wordpress.org/export/1.2/">
+<channel>
+	<wp:author><wp:author_login>jane</wp:author_login><wp:author_email>Jane@Example.com</wp:author_email></wp:author>
+	<wp:author><wp:author_login>bob.smith</wp:author_login><wp:author_email>bob@example.com</wp:author_email>
+		<wp:author_display_name>Bob Smith</wp:author_display_name></wp:author>
+	<item>
+		<title>About</title>
+		<dc:creator>jane</dc:creator>
+		<wp:post_id>1</wp:post_id>
+		<wp:status>publish</wp:status>
+		<wp:post_type>page</wp:post_type>
+	</item>
+	<item>
+		<title>Hello World</title>
+		<dc:creator>jane</dc:creator>
+		<content:encoded><![CDATA[<p>Hi <strong>there</strong></p>]]></content:encoded>
+		<wp:post_id>2</wp:post_id>
+		<wp:post_date_gmt>2019-03-01 10:00:00</wp:post_date_gmt>
+		<wp:post_modified_gmt>2019-03-02 10:00:00</wp:post_modified_gmt>
+		<wp:post_name>hello-world</wp:post_name>
+		<wp:status>publish</wp:status>
+		<wp:post_type>post</wp:post_type>
+		<category domain="category" nicename="news">News</category>
+		<category domain="post_tag" nicename="go">Go</category>
+	</item>
+	<item>
+		<title>Already here</title>
+		<dc:creator>jane</dc:creator>
+		<wp:post_id>3</wp:post_id>
+		<wp:post_name>already-here</wp:post_name>
+		<wp:status>publish</wp:status>
+		<wp:post_type>post</wp:post_type>
+	</item>
+	<item>
+		<title>Bob's draft</title>
+		<dc:creator>bob.smith</dc:creator>
+		<wp:post_id>4</wp:post_id>
+		<wp:post_date_gmt>0000-00-00 00:00:00</wp:post_date_gmt>
+		<wp:status>draft</wp:status>
+		<wp:post_type>post</wp:post_type>
+	</item>
+	<item>
+		<title>Ghost</title>
+		<dc:creator>ghost</dc:creator>
+		<wp:post_id>5</wp:post_id>
+		<wp:status>publish</wp:status>
+		<wp:post_type>post</wp:post_type>
+	</item>
+</channel>
+</rss>`
+
+func setupImport(t *testing.T) (*ImportService, *MockImportJobRepository, *MockPostRepository, *MockUserRepository) {
+	jobRepo := new(MockImportJobRepository)
+	postRepo := new(MockPostRepository)
+	userRepo := new(MockUserRepository)
+	service := NewImportService(jobRepo, postsvc.NewPostService(postRepo, userRepo), postRepo, userRepo, t.TempDir())
+	return service, jobRepo, postRepo, userRepo
+}
+
+func TestImportService_CreateJob(t *testing.T) {
+	// Setup
+	service, jobRepo, _, _ := setupImport(t)
+	jobRepo.On("Create", mock.Anything, mock.MatchedBy(func(job *entities.ImportJob) bool {
+		return job.Source == SourceWXR && job.Total == 5 && job.Status == entities.ImportJobQueued && job.FileName == "blog.xml"
+	})).Return(&entities.ImportJob{ID: "job-1"}, nil)
+
+	// Execute
+	job, err := service.CreateJob(context.Background(), "admin-1", "blog.xml", strings.NewReader(export))
+	_, invalid := service.CreateJob(context.Background(), "admin-1", "notes.txt", strings.NewReader("just text"))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "job-1", job.ID)
+	assert.Equal(t, AppError.ErrValidationFailed, invalid)
+	files, _ := os.ReadDir(service.dir)
+	assert.Len(t, files, 1, "the rejected file is removed")
+}
+
+func TestImportService_Run(t *testing.T) {
+	// Setup
+	service, jobRepo, postRepo, userRepo := setupImport(t)
+	path := service.dir + "/export.xml"
+	require.NoError(t, os.WriteFile(path, []byte(export), 0o600))
+
+	// resumed after the page was handled
+	job := &entities.ImportJob{ID: "job-1", FilePath: path, Total: 5, Processed: 1, Skipped: 1, Status: entities.ImportJobRunning}
+	jobRepo.On("Claim", mock.Anything, "job-1", mock.Anything).Return(true, nil)
+	jobRepo.On("FindByID", mock.Anything, "job-1").Return(job, nil)
+	jobRepo.On("Save", mock.Anything, job).Return(nil)
+
+	postRepo.On("FindBySlug", mock.Anything, "hello-world").Return((*entities.Post)(nil), AppError.ErrNotFound)
+	postRepo.On("FindBySlug", mock.Anything, "already-here").Return(&entities.Post{ID: "post-0"}, nil)
+	userRepo.On("GetUserByEmail", mock.Anything, "jane@example.com").Return(&entities.User{ID: "user-jane"}, nil)
+	userRepo.On("GetUserByEmail", mock.Anything, "bob@example.com").Return(&entities.User{}, AppError.ErrNotFound)
+	userRepo.On("GetUserByUsername", mock.Anything, "bob_smith").Return(&entities.User{}, AppError.ErrNotFound)
+	userRepo.On("GetUserByUsername", mock.Anything, "ghost").Return(&entities.User{}, AppError.ErrNotFound)
+	userRepo.On("CreateUser", mock.Anything, mock.MatchedBy(func(u *entities.User) bool {
+		return u.Username == "bob_smith" && u.Email == "bob@example.com" && u.FirstName == "Bob" && u.LastName == "Smith" && u.Role == entities.RoleUser
+	})).Return("user-bob", nil)
+
+	published := time.Date(2019, 3, 1, 10, 0, 0, 0, time.UTC)
+	postRepo.On("Import", mock.Anything, mock.MatchedBy(func(p *entities.Post) bool {
+		return p.Title == "Hello World" && p.Slug == "hello-world" && p.AuthorID == "user-jane" && p.Content == "Hi **there**" &&
+			assert.ObjectsAreEqual([]string{"news", "go"}, p.Tags) && p.Status == entities.PostStatusPublished &&
+			p.PublishAt.Equal(published) && p.CreatedAt.Equal(published) && p.UpdatedAt.Equal(published.Add(24*time.Hour))
+	})).Return(&entities.Post{ID: "post-1"}, nil)
+	postRepo.On("Import", mock.Anything, mock.MatchedBy(func(p *entities.Post) bool {
+		return p.Title == "Bob's draft" && p.AuthorID == "user-bob" && p.Status == entities.PostStatusDraft && p.PublishAt.IsZero()
+	})).Return(&entities.Post{ID: "post-2"}, nil)
+
+	// Execute
+	result, err := service.Run(context.Background(), "job-1")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, entities.ImportJobCompleted, result.Status)
+	assert.Equal(t, 5, result.Processed)
+	assert.Equal(t, 2, result.Imported)
+	assert.Equal(t, 2, result.Skipped)
+	assert.Equal(t, 1, result.Failed)
+	assert.Equal(t, 1, result.UsersCreated)
+	assert.Equal(t, []entities.ImportItemError{{Item: "Ghost", Error: `author "ghost" has no email address to create a user with`}}, result.Errors)
+	assert.Empty(t, result.FilePath)
+	jobRepo.AssertNumberOfCalls(t, "Save", 5)
+	postRepo.AssertNumberOfCalls(t, "Import", 2)
+	_, statErr := os.Stat(path)
+	assert.True(t, os.IsNotExist(statErr), "the export file is removed once the job completed")
+}
+
+func TestImportService_Run_AlreadyRunning(t *testing.T) {
+	service, jobRepo, _, _ := setupImport(t)
+	jobRepo.On("Claim", mock.Anything, "job-1", mock.Anything).Return(false, nil)
+
+	_, err := service.Run(context.Background(), "job-1")
+
+	assert.Equal(t, AppError.ErrImportJobRunning, err)
+	jobRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+}
+
+func TestImportService_Run_MissingFile(t *testing.T) {
+	service, jobRepo, _, _ := setupImport(t)
+	job := &entities.ImportJob{ID: "job-1", FilePath: service.dir + "/gone.xml", Status: entities.ImportJobRunning}
+	jobRepo.On("Claim", mock.Anything, "job-1", mock.Anything).Return(true, nil)
+	jobRepo.On("FindByID", mock.Anything, "job-1").Return(job, nil)
+	jobRepo.On("Save", mock.Anything, job).Return(nil)
+
+	result, err := service.Run(context.Background(), "job-1")
+
+	assert.Error(t, err)
+	assert.Equal(t, entities.ImportJobFailed, result.Status)
+	assert.NotEmpty(t, result.Error)
+}
+
+func TestUsernameFor(t *testing.T) {
+	assert.Equal(t, "jane_doe", usernameFor("jane.doe"))
+	assert.Equal(t, "wp_2cool", usernameFor("2cool"))
+	assert.Equal(t, "wp_al", usernameFor("al"))
+	assert.Equal(t, "mail_example_com", usernameFor("mail@example.com"))
+}
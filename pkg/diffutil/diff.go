@@ -0,0 +1,118 @@
+package diffutil
+
+import (
+	"strings"
+)
+
+const (
+	OpEqual  = "equal"
+	OpInsert = "insert"
+	OpDelete = "delete"
+)
+
+// MaxTableCells bounds the LCS table (old lines × new lines, after the common start and end
+// are taken off) to about 8 MB. Larger changes are shown as the old block replaced by the new one.
+const MaxTableCells = 2_000_000
+
+// Line is a single line of a line-level diff.
+type Line struct {
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+// Lines computes a line-level diff turning a into b using the longest common subsequence.
+// Lines shared at the start and end are matched directly, so only the changed middle is compared.
+func Lines(a, b string) []Line {
+	oldLines := splitLines(a)
+	newLines := splitLines(b)
+	result := make([]Line, 0, len(oldLines)+len(newLines))
+
+	prefix := 0
+	for prefix < len(oldLines) && prefix < len(newLines) && oldLines[prefix] == newLines[prefix] {
+		result = append(result, Line{Op: OpEqual, Text: oldLines[prefix]})
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(oldLines)-prefix && suffix < len(newLines)-prefix &&
+		oldLines[len(oldLines)-1-suffix] == newLines[len(newLines)-1-suffix] {
+		suffix++
+	}
+
+	result = appendMiddle(result, oldLines[prefix:len(oldLines)-suffix], newLines[prefix:len(newLines)-suffix])
+	for _, text := range oldLines[len(oldLines)-suffix:] {
+		result = append(result, Line{Op: OpEqual, Text: text})
+	}
+	return result
+}
+
+// appendMiddle diffs the changed part of the texts
+func appendMiddle(result []Line, oldLines, newLines []string) []Line {
+	n, m := len(oldLines), len(newLines)
+	if n == 0 || m == 0 || n*m > MaxTableCells {
+		for _, text := range oldLines {
+			result = append(result, Line{Op: OpDelete, Text: text})
+		}
+		for _, text := range newLines {
+			result = append(result, Line{Op: OpInsert, Text: text})
+		}
+		return result
+	}
+
+	// lcs[i][j] holds the LCS length of oldLines[i:] and newLines[j:]
+	cells := make([]int32, (n+1)*(m+1))
+	lcs := make([][]int32, n+1)
+	for i := range lcs {
+		lcs[i] = cells[i*(m+1) : (i+1)*(m+1)]
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if oldLines[i] == newLines[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case oldLines[i] == newLines[j]:
+			result = append(result, Line{Op: OpEqual, Text: oldLines[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			result = append(result, Line{Op: OpDelete, Text: oldLines[i]})
+			i++
+		default:
+			result = append(result, Line{Op: OpInsert, Text: newLines[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		result = append(result, Line{Op: OpDelete, Text: oldLines[i]})
+	}
+	for ; j < m; j++ {
+		result = append(result, Line{Op: OpInsert, Text: newLines[j]})
+	}
+
+	return result
+}
+
+// HasChanges reports whether a diff contains any insertions or deletions.
+func HasChanges(diff []Line) bool {
+	for _, line := range diff {
+		if line.Op != OpEqual {
+			return true
+		}
+	}
+	return false
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
@@ -0,0 +1,68 @@
+package diffutil
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLines_InsertAndDelete(t *testing.T) {
+	// Test data
+	a := "first\nsecond\nthird"
+	b := "first\nchanged\nthird\nfourth"
+
+	// Execute
+	diff := Lines(a, b)
+
+	// Assert
+	assert.Equal(t, []Line{
+		{Op: OpEqual, Text: "first"},
+		{Op: OpDelete, Text: "second"},
+		{Op: OpInsert, Text: "changed"},
+		{Op: OpEqual, Text: "third"},
+		{Op: OpInsert, Text: "fourth"},
+	}, diff)
+	assert.True(t, HasChanges(diff))
+}
+
+func TestLines_Identical(t *testing.T) {
+	// Execute
+	diff := Lines("same\ntext\n", "same\r\ntext")
+
+	// Assert
+	assert.Len(t, diff, 2)
+	assert.False(t, HasChanges(diff))
+}
+
+func TestLines_EmptyInput(t *testing.T) {
+	// Execute
+	diff := Lines("", "new line")
+
+	// Assert
+	assert.Equal(t, []Line{{Op: OpInsert, Text: "new line"}}, diff)
+}
+
+func TestLines_LargeChangeIsBounded(t *testing.T) {
+	// Test data: a shared header and footer around two unrelated blocks too large to compare
+	var oldText, newText strings.Builder
+	oldText.WriteString("header\n")
+	newText.WriteString("header\n")
+	for i := 0; i < 2000; i++ {
+		fmt.Fprintf(&oldText, "old %d\n", i)
+		fmt.Fprintf(&newText, "new %d\n", i)
+	}
+	oldText.WriteString("footer")
+	newText.WriteString("footer")
+
+	// Execute
+	diff := Lines(oldText.String(), newText.String())
+
+	// Assert: the block is replaced as a whole, the shared lines still match
+	assert.Len(t, diff, 4002)
+	assert.Equal(t, Line{Op: OpEqual, Text: "header"}, diff[0])
+	assert.Equal(t, Line{Op: OpDelete, Text: "old 0"}, diff[1])
+	assert.Equal(t, Line{Op: OpInsert, Text: "new 0"}, diff[2001])
+	assert.Equal(t, Line{Op: OpEqual, Text: "footer"}, diff[4001])
+}
@@ -0,0 +1,39 @@
+// Package feed encodes syndication feeds as RSS 2.0, Atom 1.0 and JSON Feed 1.1.
+//
+// A Feed is built once and handed to the encoder of the format a reader asked for,
+// so every format carries the same entries.
+package feed
+
+import (
+	"time"
+)
+
+// Content types of the encoded formats
+const (
+	RSSContentType  = "application/rss+xml; charset=utf-8"
+	AtomContentType = "application/atom+xml; charset=utf-8"
+	JSONContentType = "application/feed+json; charset=utf-8"
+)
+
+const generator = "anchor-blog"
+
+type Feed struct {
+	Title       string
+	Description string
+	SiteURL     string // the page the feed belongs to
+	FeedURL     string // the feed itself; doubles as its Atom id
+	Updated     time.Time
+	Items       []Item
+}
+
+type Item struct {
+	ID        string // permanent, never changes for an entry
+	URL       string
+	Title     string
+	Author    string
+	Tags      []string
+	Published time.Time
+	Updated   time.Time
+	Summary   string // plain text
+	HTML      string // full content; left out when empty
+}
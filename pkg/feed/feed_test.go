@@ -0,0 +1,123 @@
+package feed
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleFeed() *Feed {
+	published := time.Date(2025, 8, 7, 10, 30, 0, 0, time.UTC)
+	return &Feed{
+		Title:       "Anchor Blog",
+		Description: "Posts & notes",
+		SiteURL:     "https://blog.example.com",
+		FeedURL:     "https://blog.example.com/feed/rss",
+		Updated:     published.Add(time.Hour),
+		Items: []Item{
+			{
+				ID:        "https://blog.example.com/posts/1",
+				URL:       "https://blog.example.com/posts/1",
+				Title:     "Generics <in> Go",
+				Author:    "Alice Doe",
+				Tags:      []string{"go", "generics"},
+				Published: published,
+				Updated:   published.Add(time.Hour),
+				Summary:   "Type parameters at last.",
+				HTML:      "<p>Type parameters at <em>last</em>.</p>",
+			},
+			{
+				ID:        "https://blog.example.com/posts/2",
+				URL:       "https://blog.example.com/posts/2",
+				Title:     "Short note",
+				Published: published,
+				Updated:   published,
+				Summary:   "Nothing more.",
+			},
+		},
+	}
+}
+
+func TestRSS(t *testing.T) {
+	body, err := RSS(sampleFeed())
+	require.NoError(t, err)
+
+	var doc struct {
+		Version string `xml:"version,attr"`
+		Channel struct {
+			Title string `xml:"title"`
+			Items []struct {
+				Title    string   `xml:"title"`
+				GUID     string   `xml:"guid"`
+				PubDate  string   `xml:"pubDate"`
+				Category []string `xml:"category"`
+				Content  string   `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+				Creator  string   `xml:"http://purl.org/dc/elements/1.1/ creator"`
+			} `xml:"item"`
+		} `xml:"channel"`
+	}
+	require.NoError(t, xml.Unmarshal(body, &doc))
+
+	assert.Equal(t, "2.0", doc.Version)
+	assert.Equal(t, "Anchor Blog", doc.Channel.Title)
+	require.Len(t, doc.Channel.Items, 2)
+	assert.Equal(t, "Generics <in> Go", doc.Channel.Items[0].Title)
+	assert.Equal(t, "Thu, 07 Aug 2025 10:30:00 +0000", doc.Channel.Items[0].PubDate)
+	assert.Equal(t, []string{"go", "generics"}, doc.Channel.Items[0].Category)
+	assert.Equal(t, "<p>Type parameters at <em>last</em>.</p>", doc.Channel.Items[0].Content)
+	assert.Equal(t, "Alice Doe", doc.Channel.Items[0].Creator)
+	assert.Empty(t, doc.Channel.Items[1].Content)
+}
+
+func TestAtom(t *testing.T) {
+	body, err := Atom(sampleFeed())
+	require.NoError(t, err)
+
+	var doc struct {
+		XMLName xml.Name `xml:"http://www.w3.org/2005/Atom feed"`
+		ID      string   `xml:"id"`
+		Updated string   `xml:"updated"`
+		Entries []struct {
+			ID      string `xml:"id"`
+			Author  string `xml:"author>name"`
+			Content *struct {
+				Type  string `xml:"type,attr"`
+				Value string `xml:",chardata"`
+			} `xml:"content"`
+		} `xml:"entry"`
+	}
+	require.NoError(t, xml.Unmarshal(body, &doc))
+
+	assert.Equal(t, "https://blog.example.com/feed/rss", doc.ID)
+	assert.Equal(t, "2025-08-07T11:30:00Z", doc.Updated)
+	require.Len(t, doc.Entries, 2)
+	assert.Equal(t, "Alice Doe", doc.Entries[0].Author)
+	assert.Equal(t, "html", doc.Entries[0].Content.Type)
+	assert.Equal(t, "<p>Type parameters at <em>last</em>.</p>", doc.Entries[0].Content.Value)
+	assert.Nil(t, doc.Entries[1].Content)
+}
+
+func TestJSON(t *testing.T) {
+	body, err := JSON(sampleFeed())
+	require.NoError(t, err)
+
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal(body, &doc))
+
+	assert.Equal(t, "https://jsonfeed.org/version/1.1", doc["version"])
+	items := doc["items"].([]interface{})
+	require.Len(t, items, 2)
+
+	full := items[0].(map[string]interface{})
+	assert.Equal(t, "<p>Type parameters at <em>last</em>.</p>", full["content_html"])
+	assert.Equal(t, "2025-08-07T10:30:00Z", full["date_published"])
+
+	excerpt := items[1].(map[string]interface{})
+	assert.Nil(t, excerpt["content_html"])
+	assert.Equal(t, "Nothing more.", excerpt["content_text"])
+	assert.Nil(t, excerpt["authors"])
+}
@@ -0,0 +1,72 @@
+// Package frontmatter reads and writes Markdown documents that start with a YAML
+// front matter block delimited by "---" lines, as used by most static site generators.
+package frontmatter
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const delimiter = "---"
+
+// ErrMissing is returned for documents that don't start with a front matter block
+var ErrMissing = errors.New("document has no front matter")
+
+// Marshal renders meta as YAML front matter followed by the body
+func Marshal(meta any, body string) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(delimiter + "\n")
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(meta); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	buf.WriteString(delimiter + "\n")
+	if body != "" {
+		buf.WriteString("\n")
+		buf.WriteString(body)
+		if !strings.HasSuffix(body, "\n") {
+			buf.WriteString("\n")
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// Unmarshal decodes the front matter of doc into meta and returns the body after it.
+// The blank line separating the two and a trailing newline are not part of the body.
+func Unmarshal(doc []byte, meta any) (string, error) {
+	text := strings.ReplaceAll(string(bytes.TrimPrefix(doc, []byte("\ufeff"))), "\r\n", "\n")
+	if !strings.HasPrefix(text, delimiter+"\n") {
+		return "", ErrMissing
+	}
+	rest := text[len(delimiter)+1:]
+
+	var header, body string
+	switch {
+	case strings.HasPrefix(rest, delimiter+"\n") || rest == delimiter:
+		body = strings.TrimPrefix(rest, delimiter)
+	default:
+		end := strings.Index(rest, "\n"+delimiter+"\n")
+		if end < 0 {
+			if !strings.HasSuffix(rest, "\n"+delimiter) {
+				return "", ErrMissing
+			}
+			end = len(rest) - len(delimiter) - 1
+		}
+		header = rest[:end+1]
+		body = rest[end+1+len(delimiter):]
+	}
+
+	if err := yaml.Unmarshal([]byte(header), meta); err != nil {
+		return "", err
+	}
+	body = strings.TrimPrefix(body, "\n")
+	body = strings.TrimPrefix(body, "\n")
+	return strings.TrimSuffix(body, "\n"), nil
+}
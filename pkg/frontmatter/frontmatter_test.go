@@ -0,0 +1,65 @@
+package frontmatter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type meta struct {
+	Title   string    `yaml:"title"`
+	Tags    []string  `yaml:"tags,omitempty"`
+	Created time.Time `yaml:"created_at"`
+}
+
+func TestMarshalUnmarshal_RoundTrip(t *testing.T) {
+	in := meta{Title: "Hello: world", Tags: []string{"go", "yaml"}, Created: time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)}
+	body := "# Heading\n\n---\n\nSome *text*."
+
+	doc, err := Marshal(in, body)
+	require.NoError(t, err)
+
+	var out meta
+	got, err := Unmarshal(doc, &out)
+	require.NoError(t, err)
+	assert.Equal(t, in, out)
+	assert.Equal(t, body, got)
+}
+
+func TestUnmarshal(t *testing.T) {
+	tests := []struct {
+		name  string
+		doc   string
+		title string
+		body  string
+	}{
+		{"windows line endings", "---\r\ntitle: A\r\n---\r\n\r\nBody\r\n", "A", "Body"},
+		{"no blank line", "---\ntitle: A\n---\nBody", "A", "Body"},
+		{"empty front matter", "---\n---\nBody\n", "", "Body"},
+		{"no body", "---\ntitle: A\n---", "A", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var m meta
+			body, err := Unmarshal([]byte(tt.doc), &m)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.title, m.Title)
+			assert.Equal(t, tt.body, body)
+		})
+	}
+}
+
+func TestUnmarshal_Errors(t *testing.T) {
+	var m meta
+	_, err := Unmarshal([]byte("# Just markdown\n"), &m)
+	assert.ErrorIs(t, err, ErrMissing)
+
+	_, err = Unmarshal([]byte("---\ntitle: A\nno closing line\n"), &m)
+	assert.ErrorIs(t, err, ErrMissing)
+
+	_, err = Unmarshal([]byte("---\ntitle: [unclosed\n---\n"), &m)
+	assert.Error(t, err)
+}
@@ -0,0 +1,416 @@
+// Package htmlmd converts HTML, such as post bodies exported from WordPress, into Markdown.
+//
+// Headings, paragraphs, emphasis, strikethrough, code, links, images, lists, block
+// quotes and rules become their Markdown form. Elements Markdown has no syntax for,
+// like tables and embeds, are kept as raw HTML. Scripts and styles are dropped.
+package htmlmd
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var (
+	// WordPress wraps captioned images in a shortcode around the HTML
+	captionRe = regexp.MustCompile(`\[/?caption[^\]]*\]`)
+	// a line that Markdown would read as a heading, quote, list item or rule
+	blockStartRe = regexp.MustCompile(`^(#{1,6}(\s|$)|>|[-+*](\s|$)|=+\s*$|\d{1,9}[.)](\s|$))`)
+	languageRe   = regexp.MustCompile(`(?:lang(?:uage)?-|brush:\s*)([A-Za-z0-9_+#-]+)`)
+	spaceRe      = regexp.MustCompile(`[ \t\r\n\f]+`)
+	blankLineRe  = regexp.MustCompile(`\n[ \t]*\n\s*`)
+)
+
+const (
+	// markers left in inline text and resolved once a paragraph is complete
+	paragraphBreak = "\x00p"
+	lineBreak      = "\x00b"
+)
+
+var skipped = map[atom.Atom]bool{
+	atom.Script: true, atom.Style: true, atom.Noscript: true, atom.Template: true, atom.Head: true,
+}
+
+// kept as HTML since Markdown has no equivalent
+var raw = map[atom.Atom]bool{
+	atom.Table: true, atom.Iframe: true, atom.Video: true, atom.Audio: true, atom.Object: true,
+	atom.Embed: true, atom.Dl: true, atom.Details: true,
+}
+
+var containers = map[atom.Atom]bool{
+	atom.Html: true, atom.Body: true, atom.Div: true, atom.Section: true, atom.Article: true,
+	atom.Main: true, atom.Header: true, atom.Footer: true, atom.Aside: true, atom.Nav: true,
+	atom.Center: true, atom.Address: true, atom.Form: true, atom.Fieldset: true,
+}
+
+type converter struct {
+	// autop is set for content without paragraphs, where WordPress treats
+	// blank lines as paragraph breaks and single newlines as line breaks
+	autop bool
+}
+
+// Convert returns the Markdown form of an HTML fragment
+func Convert(src string) string {
+	src = captionRe.ReplaceAllString(src, "")
+	nodes, err := html.ParseFragment(strings.NewReader(src), &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body})
+	if err != nil {
+		return strings.TrimSpace(src)
+	}
+
+	root := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+	for _, n := range nodes {
+		root.AppendChild(n)
+	}
+	c := &converter{autop: !hasParagraphs(root)}
+	return strings.Join(c.blocks(root), "\n\n")
+}
+
+func hasParagraphs(n *html.Node) bool {
+	if n.Type == html.ElementNode && n.DataAtom == atom.P {
+		return true
+	}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if hasParagraphs(child) {
+			return true
+		}
+	}
+	return false
+}
+
+func isBlock(n *html.Node) bool {
+	if n.Type != html.ElementNode {
+		return false
+	}
+	switch n.DataAtom {
+	case atom.P, atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6, atom.Ul, atom.Ol, atom.Li,
+		atom.Blockquote, atom.Pre, atom.Hr, atom.Figure, atom.Figcaption:
+		return true
+	}
+	return containers[n.DataAtom] || raw[n.DataAtom] || skipped[n.DataAtom]
+}
+
+// blocks converts the children of n, grouping runs of inline content into paragraphs
+func (c *converter) blocks(n *html.Node) []string {
+	var out []string
+	var inline strings.Builder
+	flush := func() {
+		out = append(out, paragraphs(inline.String())...)
+		inline.Reset()
+	}
+
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if !isBlock(child) {
+			inline.WriteString(c.inline(child))
+			continue
+		}
+		flush()
+		out = append(out, c.block(child)...)
+	}
+	flush()
+	return out
+}
+
+func (c *converter) block(n *html.Node) []string {
+	switch {
+	case skipped[n.DataAtom]:
+		return nil
+	case raw[n.DataAtom]:
+		var b strings.Builder
+		if err := html.Render(&b, n); err != nil {
+			return nil
+		}
+		// a blank line would end the HTML block in Markdown
+		return []string{blankLineRe.ReplaceAllString(b.String(), "\n")}
+	}
+
+	switch n.DataAtom {
+	case atom.P, atom.Figcaption:
+		paras := paragraphs(c.children(n))
+		if n.DataAtom == atom.Figcaption {
+			for i, p := range paras {
+				paras[i] = "*" + p + "*"
+			}
+		}
+		return paras
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		text := strings.Join(strings.Fields(resolveBreaks(c.children(n), " ")), " ")
+		if text == "" {
+			return nil
+		}
+		return []string{strings.Repeat("#", int(n.Data[1]-'0')) + " " + text}
+	case atom.Ul, atom.Ol:
+		return c.list(n)
+	case atom.Li:
+		return c.list(&html.Node{FirstChild: n, DataAtom: atom.Ul})
+	case atom.Blockquote:
+		inner := strings.Join(c.blocks(n), "\n\n")
+		if inner == "" {
+			return nil
+		}
+		return []string{prefixLines(inner, "> ", "> ")}
+	case atom.Pre:
+		return []string{codeBlock(n)}
+	case atom.Hr:
+		return []string{"---"}
+	}
+	return c.blocks(n)
+}
+
+func (c *converter) list(n *html.Node) []string {
+	var items []string
+	number := 1
+	if start := attr(n, "start"); start != "" {
+		fmt.Sscanf(start, "%d", &number)
+	}
+
+	for li := n.FirstChild; li != nil; li = li.NextSibling {
+		var content string
+		if li.Type == html.ElementNode && li.DataAtom == atom.Li {
+			content = strings.Join(c.blocks(li), "\n")
+		} else if text := strings.TrimSpace(resolveBreaks(c.inline(li), " ")); text != "" {
+			content = text
+		} else {
+			continue
+		}
+
+		marker := "- "
+		if n.DataAtom == atom.Ol {
+			marker = fmt.Sprintf("%d. ", number)
+			number++
+		}
+		items = append(items, prefixLines(content, marker, strings.Repeat(" ", len(marker))))
+	}
+	if len(items) == 0 {
+		return nil
+	}
+	return []string{strings.Join(items, "\n")}
+}
+
+func (c *converter) children(n *html.Node) string {
+	var b strings.Builder
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		b.WriteString(c.inline(child))
+	}
+	return b.String()
+}
+
+func (c *converter) inline(n *html.Node) string {
+	switch n.Type {
+	case html.TextNode:
+		return c.text(n.Data)
+	case html.ElementNode:
+	default:
+		return ""
+	}
+	if skipped[n.DataAtom] {
+		return ""
+	}
+
+	switch n.DataAtom {
+	case atom.Br:
+		return lineBreak
+	case atom.Strong, atom.B:
+		return wrap(c.children(n), "**")
+	case atom.Em, atom.I, atom.Cite:
+		return wrap(c.children(n), "*")
+	case atom.Del, atom.S, atom.Strike:
+		return wrap(c.children(n), "~~")
+	case atom.Code, atom.Kbd, atom.Samp, atom.Tt:
+		return codeSpan(textContent(n))
+	case atom.Img:
+		src := attr(n, "src")
+		if src == "" {
+			return ""
+		}
+		return "![" + escape(attr(n, "alt")) + "](" + destination(src, attr(n, "title")) + ")"
+	case atom.A:
+		text := strings.TrimSpace(resolveBreaks(c.children(n), " "))
+		href := attr(n, "href")
+		if href == "" || strings.HasPrefix(strings.ToLower(href), "javascript:") {
+			return text
+		}
+		if text == "" {
+			text = escape(href)
+		}
+		return "[" + text + "](" + destination(href, attr(n, "title")) + ")"
+	}
+
+	if isBlock(n) {
+		// a block nested in inline content, e.g. a paragraph inside a link
+		return paragraphBreak + strings.Join(c.block(n), paragraphBreak) + paragraphBreak
+	}
+	return c.children(n)
+}
+
+func (c *converter) text(s string) string {
+	if !c.autop {
+		return escape(spaceRe.ReplaceAllString(s, " "))
+	}
+
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	var b strings.Builder
+	for i, para := range blankLineRe.Split(s, -1) {
+		if i > 0 {
+			b.WriteString(paragraphBreak)
+		}
+		for j, line := range strings.Split(para, "\n") {
+			if j > 0 {
+				b.WriteString(lineBreak)
+			}
+			b.WriteString(escape(spaceRe.ReplaceAllString(line, " ")))
+		}
+	}
+	return b.String()
+}
+
+// paragraphs turns collected inline content into Markdown paragraphs
+func paragraphs(inline string) []string {
+	var out []string
+	for _, para := range strings.Split(inline, paragraphBreak) {
+		var lines []string
+		for _, line := range strings.Split(para, lineBreak) {
+			line = strings.TrimSpace(line)
+			line = escapeBlockStart(line)
+			lines = append(lines, line)
+		}
+		// drop breaks at the edges, they have nothing to separate
+		for len(lines) > 0 && lines[0] == "" {
+			lines = lines[1:]
+		}
+		for len(lines) > 0 && lines[len(lines)-1] == "" {
+			lines = lines[:len(lines)-1]
+		}
+		if len(lines) > 0 {
+			out = append(out, strings.Join(lines, "\\\n"))
+		}
+	}
+	return out
+}
+
+// escapeBlockStart keeps a line from being read as a heading, quote, list item or rule
+func escapeBlockStart(line string) string {
+	if !blockStartRe.MatchString(line) {
+		return line
+	}
+	if i := strings.IndexAny(line, ".)"); line[0] >= '0' && line[0] <= '9' {
+		return line[:i] + `\` + line[i:]
+	}
+	return `\` + line
+}
+
+func resolveBreaks(s, with string) string {
+	s = strings.ReplaceAll(s, paragraphBreak, with)
+	return strings.ReplaceAll(s, lineBreak, with)
+}
+
+// wrap puts delimiters around inline content, keeping surrounding spaces outside them
+func wrap(s, delim string) string {
+	trimmed := strings.TrimSpace(s)
+	if trimmed == "" || strings.Contains(trimmed, paragraphBreak) {
+		return s
+	}
+	lead := s[:strings.Index(s, trimmed)]
+	trail := s[len(lead)+len(trimmed):]
+	return lead + delim + trimmed + delim + trail
+}
+
+func codeSpan(code string) string {
+	code = strings.Join(strings.Fields(code), " ")
+	if code == "" {
+		return ""
+	}
+	fence := strings.Repeat("`", longestRun(code, '`')+1)
+	if strings.HasPrefix(code, "`") || strings.HasSuffix(code, "`") {
+		return fence + " " + code + " " + fence
+	}
+	return fence + code + fence
+}
+
+func codeBlock(pre *html.Node) string {
+	code := strings.Trim(textContent(pre), "\n")
+	lang := ""
+	for _, n := range []*html.Node{pre, pre.FirstChild} {
+		if n == nil || n.Type != html.ElementNode {
+			continue
+		}
+		if m := languageRe.FindStringSubmatch(attr(n, "class")); m != nil {
+			lang = m[1]
+			break
+		}
+	}
+	fence := strings.Repeat("`", max(3, longestRun(code, '`')+1))
+	return fence + lang + "\n" + code + "\n" + fence
+}
+
+func textContent(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	if n.Type == html.ElementNode && n.DataAtom == atom.Br {
+		return "\n"
+	}
+	var b strings.Builder
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		b.WriteString(textContent(child))
+	}
+	return b.String()
+}
+
+func prefixLines(s, first, rest string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		prefix := rest
+		if i == 0 {
+			prefix = first
+		}
+		if line == "" {
+			lines[i] = strings.TrimRight(prefix, " ")
+		} else {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// destination formats a link target, escaping what would end it early
+func destination(url, title string) string {
+	url = strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29", "<", "%3C", ">", "%3E").Replace(strings.TrimSpace(url))
+	if title == "" {
+		return url
+	}
+	return url + ` "` + strings.ReplaceAll(title, `"`, `\"`) + `"`
+}
+
+var escaper = strings.NewReplacer(
+	`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`,
+	"<", `\<`, "&", `\&`, "~", `\~`,
+)
+
+func escape(s string) string {
+	return escaper.Replace(s)
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func longestRun(s string, c byte) int {
+	longest, run := 0, 0
+	for i := 0; i < len(s); i++ {
+		if s[i] == c {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	return longest
+}
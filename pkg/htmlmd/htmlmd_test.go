@@ -0,0 +1,72 @@
+package htmlmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			name: "paragraphs and emphasis",
+			html: "<p>Hello <strong>bold </strong>and <em>italic</em> text.</p>\n<p>Second <del>old</del> one</p>",
+			want: "Hello **bold** and *italic* text.\n\nSecond ~~old~~ one",
+		},
+		{
+			name: "headings and rule",
+			html: "<h2>Getting <em>started</em></h2><hr/><h3>Next</h3>",
+			want: "## Getting *started*\n\n---\n\n### Next",
+		},
+		{
+			name: "links and images",
+			html: `<p><a href="https://example.com/a b" title="Ex">site</a> <a href="/x"><img src="/i.png" alt="pic"></a> <a name="anchor">plain</a></p>`,
+			want: `[site](https://example.com/a%20b "Ex") [![pic](/i.png)](/x) plain`,
+		},
+		{
+			name: "nested lists",
+			html: "<ul><li>One<ul><li>One A</li></ul></li><li>Two</li></ul><ol start=\"3\"><li>Three</li><li>Four</li></ol>",
+			want: "- One\n  - One A\n- Two\n\n3. Three\n4. Four",
+		},
+		{
+			name: "block quote",
+			html: "<blockquote><p>Quoted</p><p>Twice</p></blockquote>",
+			want: "> Quoted\n>\n> Twice",
+		},
+		{
+			name: "code",
+			html: "<p>Use <code>go test</code></p><pre><code class=\"language-go\">func main() {\n\tfmt.Println(\"&lt;hi&gt;\")\n}\n</code></pre>",
+			want: "Use `go test`\n\n```go\nfunc main() {\n\tfmt.Println(\"<hi>\")\n}\n```",
+		},
+		{
+			name: "classic editor text without paragraphs",
+			html: "First line\nsecond line\n\n[caption id=\"a\"]<img src=\"/c.jpg\" alt=\"c\"> Caption[/caption]\n\nLast",
+			want: "First line\\\nsecond line\n\n![c](/c.jpg) Caption\n\nLast",
+		},
+		{
+			name: "markdown characters are escaped",
+			html: "<p>2 * 3 = 6, snake_case &amp; [brackets]</p><p># not a heading</p><p>1984. A year</p>",
+			want: "2 \\* 3 = 6, snake\\_case \\& \\[brackets\\]\n\n\\# not a heading\n\n1984\\. A year",
+		},
+		{
+			name: "figure, table and scripts",
+			html: "<figure><img src=\"/f.png\" alt=\"\"><figcaption>A figure</figcaption></figure><table>\n\n<tr><td>1</td></tr></table><script>alert(1)</script>",
+			want: "![](/f.png)\n\n*A figure*\n\n<table>\n<tbody><tr><td>1</td></tr></tbody></table>",
+		},
+		{
+			name: "gutenberg comments",
+			html: "<!-- wp:paragraph -->\n<p>Block</p>\n<!-- /wp:paragraph -->",
+			want: "Block",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Convert(tt.html))
+		})
+	}
+}
@@ -0,0 +1,126 @@
+// Package imageutil scales images down for thumbnails using the standard library only.
+package imageutil
+
+import (
+	"bytes"
+	"image"
+	"image/draw"
+	_ "image/gif" // registers the GIF decoder
+	"image/jpeg"
+	"image/png"
+)
+
+// JPEG quality of encoded thumbnails
+const jpegQuality = 85
+
+// Fit returns the size of a width×height image scaled down to fit a box×box square,
+// keeping its aspect ratio. Images that already fit keep their size.
+func Fit(width, height, box int) (int, int) {
+	if width <= box && height <= box {
+		return width, height
+	}
+	if width >= height {
+		return box, atLeastOne(height * box / width)
+	}
+	return atLeastOne(width * box / height), box
+}
+
+// Resize scales src to width×height. Each target pixel is the average of the source
+// pixels it covers, which keeps downscaled photos smooth without aliasing.
+// Colors are averaged premultiplied so transparent edges don't darken.
+func Resize(src image.Image, width, height int) *image.RGBA {
+	bounds := src.Bounds()
+	rgba, ok := src.(*image.RGBA)
+	if !ok || bounds.Min != (image.Point{}) {
+		rgba = image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+		draw.Draw(rgba, rgba.Bounds(), src, bounds.Min, draw.Src)
+	}
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0, y1 := span(y, height, srcH)
+		for x := 0; x < width; x++ {
+			x0, x1 := span(x, width, srcW)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				row := rgba.Pix[sy*rgba.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					p := row[sx*4 : sx*4+4]
+					r += uint64(p[0])
+					g += uint64(p[1])
+					b += uint64(p[2])
+					a += uint64(p[3])
+					n++
+				}
+			}
+
+			d := dst.Pix[y*dst.Stride+x*4:]
+			d[0] = uint8(r / n)
+			d[1] = uint8(g / n)
+			d[2] = uint8(b / n)
+			d[3] = uint8(a / n)
+		}
+	}
+	return dst
+}
+
+// Scaled is an encoded thumbnail
+type Scaled struct {
+	Box    int // the box×box square it was fitted into
+	Data   []byte
+	Width  int
+	Height int
+	JPEG   bool // encoded as JPEG; otherwise PNG
+}
+
+// Thumbnails decodes a JPEG, PNG or GIF image (the first frame of an animation) once and
+// scales it down to fit each box×box square. Boxes the image already fits in are skipped.
+// JPEG images give JPEG thumbnails; the other formats give PNG so transparency survives.
+func Thumbnails(data []byte, boxes []int) ([]Scaled, error) {
+	src, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	srcW, srcH := src.Bounds().Dx(), src.Bounds().Dy()
+
+	var thumbnails []Scaled
+	for _, box := range boxes {
+		if srcW <= box && srcH <= box {
+			continue
+		}
+		width, height := Fit(srcW, srcH, box)
+		thumb := Resize(src, width, height)
+
+		var buf bytes.Buffer
+		isJPEG := format == "jpeg"
+		if isJPEG {
+			err = jpeg.Encode(&buf, thumb, &jpeg.Options{Quality: jpegQuality})
+		} else {
+			err = png.Encode(&buf, thumb)
+		}
+		if err != nil {
+			return nil, err
+		}
+		thumbnails = append(thumbnails, Scaled{Box: box, Data: buf.Bytes(), Width: width, Height: height, JPEG: isJPEG})
+	}
+	return thumbnails, nil
+}
+
+// span maps target pixel i of n onto the source pixels [from, to) of total
+func span(i, n, total int) (int, int) {
+	from := i * total / n
+	to := (i + 1) * total / n
+	if to <= from {
+		to = from + 1
+	}
+	return from, to
+}
+
+func atLeastOne(v int) int {
+	if v < 1 {
+		return 1
+	}
+	return v
+}
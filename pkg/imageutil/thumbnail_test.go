@@ -0,0 +1,83 @@
+package imageutil
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFit(t *testing.T) {
+	tests := []struct {
+		width, height, box int
+		expected           image.Point
+	}{
+		{4000, 3000, 160, image.Pt(160, 120)},
+		{3000, 4000, 160, image.Pt(120, 160)},
+		{100, 80, 160, image.Pt(100, 80)},
+		{5000, 10, 160, image.Pt(160, 1)},
+	}
+
+	for _, tt := range tests {
+		width, height := Fit(tt.width, tt.height, tt.box)
+		assert.Equal(t, tt.expected, image.Pt(width, height), "%dx%d in %d", tt.width, tt.height, tt.box)
+	}
+}
+
+func TestResize_AveragesPixels(t *testing.T) {
+	// Test data: a 4x2 image, left half black, right half white
+	src := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 4; x++ {
+			if x >= 2 {
+				src.Set(x, y, color.White)
+			} else {
+				src.Set(x, y, color.Black)
+			}
+		}
+	}
+
+	// Execute
+	dst := Resize(src, 2, 1)
+	blended := Resize(src, 1, 1)
+
+	// Assert
+	assert.Equal(t, color.RGBA{0, 0, 0, 255}, dst.At(0, 0))
+	assert.Equal(t, color.RGBA{255, 255, 255, 255}, dst.At(1, 0))
+	assert.Equal(t, color.RGBA{127, 127, 127, 255}, blended.At(0, 0))
+}
+
+func TestThumbnails_KeepsFormatFamily(t *testing.T) {
+	// Test data
+	src := image.NewRGBA(image.Rect(0, 0, 640, 480))
+	var jpegData, pngData, gifData bytes.Buffer
+	require.NoError(t, jpeg.Encode(&jpegData, src, nil))
+	require.NoError(t, png.Encode(&pngData, src))
+	require.NoError(t, gif.Encode(&gifData, src, nil))
+
+	for name, data := range map[string][]byte{"jpeg": jpegData.Bytes(), "png": pngData.Bytes(), "gif": gifData.Bytes()} {
+		// Execute
+		thumbs, err := Thumbnails(data, []int{160, 480, 1200})
+
+		// Assert: the image already fits the largest box
+		require.NoError(t, err, name)
+		require.Len(t, thumbs, 2, name)
+		assert.Equal(t, image.Pt(160, 120), image.Pt(thumbs[0].Width, thumbs[0].Height), name)
+		assert.Equal(t, 480, thumbs[1].Width, name)
+		assert.Equal(t, name == "jpeg", thumbs[0].JPEG, name)
+		decoded, _, err := image.Decode(bytes.NewReader(thumbs[0].Data))
+		require.NoError(t, err, name)
+		assert.Equal(t, 160, decoded.Bounds().Dx(), name)
+	}
+}
+
+func TestThumbnails_RejectsGarbage(t *testing.T) {
+	_, err := Thumbnails([]byte("not an image"), []int{160})
+	assert.Error(t, err)
+}
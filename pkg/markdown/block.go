@@ -0,0 +1,506 @@
+package markdown
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type blockKind int
+
+const (
+	kindParagraph blockKind = iota
+	kindHeading
+	kindCode
+	kindQuote
+	kindList
+	kindRule
+	kindHTML
+	kindTable
+)
+
+type block struct {
+	kind     blockKind
+	level    int      // heading level
+	text     string   // inline source, code body or raw HTML
+	info     string   // fenced code language
+	children []*block // block quote content
+	items    [][]*block
+	ordered  bool
+	start    int
+	loose    bool       // list items separated by blank lines render their paragraphs
+	rows     [][]string // table cells, the header row first
+	align    []string   // table column alignment: left, center, right or empty
+}
+
+type linkRef struct {
+	url   string
+	title string
+}
+
+// renderer holds the state shared by one Render call
+type renderer struct {
+	refs       map[string]linkRef
+	ids        map[string]bool
+	toc        []Heading
+	paragraphs []string
+	depth      int // container nesting, paragraphs are only collected at depth 0
+}
+
+var (
+	atxHeadingRe  = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ ]+(.*?))?(?:[ ]+#+)?[ ]*$`)
+	thematicRe    = regexp.MustCompile(`^ {0,3}(?:(?:\*[ ]*){3,}|(?:-[ ]*){3,}|(?:_[ ]*){3,})$`)
+	setextRe      = regexp.MustCompile(`^ {0,3}(=+|-+)[ ]*$`)
+	fenceRe       = regexp.MustCompile("^( {0,3})(`{3,}|~{3,})(.*)$")
+	orderedRe     = regexp.MustCompile(`^( {0,3})(\d{1,9})([.)])( +|$)`)
+	bulletRe      = regexp.MustCompile(`^( {0,3})([-*+])( +|$)`)
+	linkDefRe     = regexp.MustCompile(`^ {0,3}\[([^\]]+)\]:[ ]*<?([^ >]+)>?(?:[ ]+(?:"([^"]*)"|'([^']*)'|\(([^)]*)\)))?[ ]*$`)
+	tableDelimRe  = regexp.MustCompile(`^ {0,3}\|?[ ]*:?-+:?[ ]*(?:\|[ ]*:?-+:?[ ]*)*\|?[ ]*$`)
+	htmlBlockRe   = regexp.MustCompile(`^ {0,3}<(?:!--|/?([a-zA-Z][a-zA-Z0-9]*)(?:[ />]|$))`)
+	htmlRawEndRes = map[string]*regexp.Regexp{
+		"script":   regexp.MustCompile(`(?i)</script>`),
+		"style":    regexp.MustCompile(`(?i)</style>`),
+		"pre":      regexp.MustCompile(`(?i)</pre>`),
+		"textarea": regexp.MustCompile(`(?i)</textarea>`),
+	}
+)
+
+// block-level tags that start a raw HTML block
+var htmlBlockTags = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true, "center": true,
+	"details": true, "dialog": true, "dd": true, "div": true, "dl": true, "dt": true,
+	"figcaption": true, "figure": true, "footer": true, "form": true, "h1": true, "h2": true,
+	"h3": true, "h4": true, "h5": true, "h6": true, "header": true, "hr": true, "iframe": true,
+	"li": true, "nav": true, "ol": true, "p": true, "pre": true, "script": true, "section": true,
+	"style": true, "summary": true, "table": true, "tbody": true, "td": true, "textarea": true,
+	"tfoot": true, "th": true, "thead": true, "tr": true, "ul": true,
+}
+
+func (r *renderer) parseBlocks(lines []string) []*block {
+	var blocks []*block
+	var para []string
+
+	flush := func() {
+		if len(para) > 0 {
+			blocks = append(blocks, &block{kind: kindParagraph, text: strings.Join(para, "\n")})
+			para = nil
+		}
+	}
+
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		indent := leadingSpaces(line)
+
+		switch {
+		case trimmed == "":
+			flush()
+			i++
+
+		case indent >= 4 && len(para) == 0:
+			var code []string
+			for i < len(lines) && (leadingSpaces(lines[i]) >= 4 || strings.TrimSpace(lines[i]) == "") {
+				code = append(code, stripIndent(lines[i], 4))
+				i++
+			}
+			for len(code) > 0 && strings.TrimSpace(code[len(code)-1]) == "" {
+				code = code[:len(code)-1]
+			}
+			blocks = append(blocks, &block{kind: kindCode, text: strings.Join(code, "\n") + "\n"})
+
+		case fenceRe.MatchString(line):
+			flush()
+			m := fenceRe.FindStringSubmatch(line)
+			fence, info := m[2], strings.TrimSpace(m[3])
+			if fence[0] == '`' && strings.Contains(info, "`") {
+				// backticks in the info string make it an inline code span instead
+				para = append(para, trimmed)
+				i++
+				continue
+			}
+			i++
+			var code []string
+			for i < len(lines) {
+				closing := strings.TrimSpace(lines[i])
+				if leadingSpaces(lines[i]) < 4 && strings.HasPrefix(closing, fence) && strings.Trim(closing, fence[:1]) == "" {
+					i++
+					break
+				}
+				code = append(code, stripIndent(lines[i], len(m[1])))
+				i++
+			}
+			body := strings.Join(code, "\n")
+			if len(code) > 0 {
+				body += "\n"
+			}
+			if fields := strings.Fields(info); len(fields) > 0 {
+				info = fields[0]
+			}
+			blocks = append(blocks, &block{kind: kindCode, text: body, info: info})
+
+		case atxHeadingRe.MatchString(line):
+			flush()
+			m := atxHeadingRe.FindStringSubmatch(line)
+			blocks = append(blocks, &block{kind: kindHeading, level: len(m[1]), text: strings.TrimSpace(m[2])})
+			i++
+
+		case len(para) > 0 && setextRe.MatchString(line):
+			level := 2
+			if strings.HasPrefix(trimmed, "=") {
+				level = 1
+			}
+			blocks = append(blocks, &block{kind: kindHeading, level: level, text: strings.Join(para, "\n")})
+			para = nil
+			i++
+
+		case thematicRe.MatchString(line):
+			flush()
+			blocks = append(blocks, &block{kind: kindRule})
+			i++
+
+		case indent < 4 && strings.HasPrefix(trimmed, ">"):
+			flush()
+			var inner []string
+			for i < len(lines) {
+				t := strings.TrimLeft(lines[i], " ")
+				if leadingSpaces(lines[i]) < 4 && strings.HasPrefix(t, ">") {
+					t = strings.TrimPrefix(t[1:], " ")
+				} else if strings.TrimSpace(lines[i]) == "" || len(inner) == 0 || strings.TrimSpace(inner[len(inner)-1]) == "" || r.startsBlock(lines[i]) {
+					break
+				}
+				// lines without '>' lazily continue the quoted paragraph
+				inner = append(inner, t)
+				i++
+			}
+			blocks = append(blocks, &block{kind: kindQuote, children: r.parseBlocks(inner)})
+
+		case isListItem(line):
+			flush()
+			var list *block
+			list, i = r.parseList(lines, i)
+			blocks = append(blocks, list)
+
+		case len(para) == 0 && isHTMLBlockStart(line):
+			var raw []string
+			end := htmlRawEndRes[htmlBlockTag(line)]
+			for i < len(lines) {
+				if end == nil && strings.TrimSpace(lines[i]) == "" {
+					break
+				}
+				raw = append(raw, lines[i])
+				i++
+				if end != nil && end.MatchString(raw[len(raw)-1]) {
+					break
+				}
+			}
+			blocks = append(blocks, &block{kind: kindHTML, text: strings.Join(raw, "\n")})
+
+		case len(para) == 0 && linkDefRe.MatchString(line):
+			m := linkDefRe.FindStringSubmatch(line)
+			label := normalizeLabel(m[1])
+			if _, exists := r.refs[label]; !exists {
+				r.refs[label] = linkRef{url: m[2], title: m[3] + m[4] + m[5]}
+			}
+			i++
+
+		case isTableStart(lines, i):
+			flush()
+			var table *block
+			table, i = r.parseTable(lines, i)
+			blocks = append(blocks, table)
+
+		default:
+			para = append(para, strings.TrimLeft(line, " "))
+			i++
+		}
+	}
+	flush()
+
+	return blocks
+}
+
+// isTableStart reports whether lines[i] is the header row of a GFM table: it must be
+// followed by a delimiter row with a pipe and the same number of cells
+func isTableStart(lines []string, i int) bool {
+	if i+1 >= len(lines) || !strings.Contains(lines[i+1], "|") || !tableDelimRe.MatchString(lines[i+1]) {
+		return false
+	}
+	return len(splitTableRow(lines[i])) == len(splitTableRow(lines[i+1]))
+}
+
+// parseTable consumes a table starting at its header row; it ends at a blank line or another block
+func (r *renderer) parseTable(lines []string, i int) (*block, int) {
+	header := splitTableRow(lines[i])
+	table := &block{kind: kindTable, rows: [][]string{header}}
+	for _, cell := range splitTableRow(lines[i+1]) {
+		switch {
+		case strings.HasPrefix(cell, ":") && strings.HasSuffix(cell, ":"):
+			table.align = append(table.align, "center")
+		case strings.HasPrefix(cell, ":"):
+			table.align = append(table.align, "left")
+		case strings.HasSuffix(cell, ":"):
+			table.align = append(table.align, "right")
+		default:
+			table.align = append(table.align, "")
+		}
+	}
+
+	for i += 2; i < len(lines) && strings.TrimSpace(lines[i]) != "" && !r.startsBlock(lines[i]); i++ {
+		// rows are cut or padded to the header's width
+		row := make([]string, len(header))
+		copy(row, splitTableRow(lines[i]))
+		table.rows = append(table.rows, row)
+	}
+	return table, i
+}
+
+// splitTableRow splits a row on its unescaped pipes; the outer pipes are optional
+func splitTableRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, "\\|") {
+		line = line[:len(line)-1]
+	}
+
+	var cells []string
+	var cell strings.Builder
+	for j := 0; j < len(line); j++ {
+		switch {
+		case line[j] == '\\' && j+1 < len(line) && line[j+1] == '|':
+			cell.WriteByte('|')
+			j++
+		case line[j] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[j])
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+// parseList consumes consecutive items of the same list type starting at lines[i]
+func (r *renderer) parseList(lines []string, i int) (*block, int) {
+	first := listMarkerOf(lines[i])
+	list := &block{kind: kindList, ordered: first.ordered, start: first.start}
+
+	blankBetween := false
+	for i < len(lines) {
+		marker := listMarkerOf(lines[i])
+		if !marker.ok || marker.ordered != first.ordered || marker.delim != first.delim {
+			break
+		}
+		if blankBetween {
+			list.loose = true
+		}
+
+		item := []string{lines[i][min(marker.contentIndent, len(lines[i])):]}
+		i++
+		sawBlank := false
+	collect:
+		for i < len(lines) {
+			l := lines[i]
+			switch {
+			case strings.TrimSpace(l) == "":
+				item = append(item, "")
+				sawBlank = true
+			case leadingSpaces(l) >= marker.contentIndent:
+				item = append(item, l[marker.contentIndent:])
+			case !sawBlank && !r.startsBlock(l):
+				// lazy continuation of the item's paragraph
+				item = append(item, strings.TrimLeft(l, " "))
+			default:
+				break collect
+			}
+			i++
+		}
+		trailing := 0
+		for len(item) > 0 && item[len(item)-1] == "" {
+			item = item[:len(item)-1]
+			trailing++
+		}
+		blankBetween = trailing > 0
+		for _, l := range item {
+			if l == "" {
+				list.loose = true
+			}
+		}
+		list.items = append(list.items, r.parseBlocks(item))
+	}
+
+	return list, i
+}
+
+// startsBlock reports whether a line would begin a new block rather than continue a paragraph
+func (r *renderer) startsBlock(line string) bool {
+	if leadingSpaces(line) >= 4 {
+		return false
+	}
+	trimmed := strings.TrimSpace(line)
+	return strings.HasPrefix(trimmed, ">") ||
+		fenceRe.MatchString(line) ||
+		atxHeadingRe.MatchString(line) ||
+		thematicRe.MatchString(line) ||
+		isListItem(line) ||
+		isHTMLBlockStart(line)
+}
+
+type listMarker struct {
+	ok            bool
+	ordered       bool
+	start         int
+	delim         byte
+	contentIndent int
+}
+
+func listMarkerOf(line string) listMarker {
+	if m := bulletRe.FindStringSubmatch(line); m != nil {
+		return listMarker{ok: true, delim: m[2][0], contentIndent: contentIndent(m)}
+	}
+	if m := orderedRe.FindStringSubmatch(line); m != nil {
+		start, _ := strconv.Atoi(m[2])
+		return listMarker{ok: true, ordered: true, start: start, delim: m[3][0], contentIndent: contentIndent(m)}
+	}
+	return listMarker{}
+}
+
+// contentIndent is the column where an item's content starts; a wide gap means indented code inside the item
+func contentIndent(m []string) int {
+	markerEnd := len(m[0]) - len(m[len(m)-1])
+	spaces := len(m[len(m)-1])
+	if spaces == 0 || spaces > 4 {
+		spaces = 1
+	}
+	return markerEnd + spaces
+}
+
+func isListItem(line string) bool {
+	return !thematicRe.MatchString(line) && listMarkerOf(line).ok
+}
+
+func isHTMLBlockStart(line string) bool {
+	m := htmlBlockRe.FindStringSubmatch(line)
+	if m == nil {
+		return false
+	}
+	return m[1] == "" || htmlBlockTags[strings.ToLower(m[1])]
+}
+
+func htmlBlockTag(line string) string {
+	if m := htmlBlockRe.FindStringSubmatch(line); m != nil {
+		return strings.ToLower(m[1])
+	}
+	return ""
+}
+
+func leadingSpaces(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+func stripIndent(line string, n int) string {
+	if leadingSpaces(line) >= n {
+		return line[n:]
+	}
+	return strings.TrimLeft(line, " ")
+}
+
+func normalizeLabel(label string) string {
+	return strings.ToLower(strings.Join(strings.Fields(label), " "))
+}
+
+func (r *renderer) renderBlocks(out *strings.Builder, blocks []*block, tight bool) {
+	for idx, b := range blocks {
+		switch b.kind {
+		case kindParagraph:
+			content := r.renderInline(b.text)
+			if r.depth == 0 {
+				r.paragraphs = append(r.paragraphs, PlainText(Sanitize(content)))
+			}
+			if tight {
+				// tight list items show their text without a paragraph
+				out.WriteString(content)
+				if idx < len(blocks)-1 {
+					out.WriteString("\n")
+				}
+			} else {
+				out.WriteString("<p>" + content + "</p>\n")
+			}
+
+		case kindHeading:
+			content := r.renderInline(b.text)
+			text := strings.TrimSpace(PlainText(content))
+			id := r.headingID(text)
+			r.toc = append(r.toc, Heading{Level: b.level, Text: text, ID: id})
+			level := strconv.Itoa(b.level)
+			out.WriteString("<h" + level + ` id="` + id + `">` + content + "</h" + level + ">\n")
+
+		case kindCode:
+			out.WriteString("<pre><code")
+			if b.info != "" {
+				out.WriteString(` class="language-` + html.EscapeString(b.info) + `"`)
+			}
+			out.WriteString(">" + html.EscapeString(b.text) + "</code></pre>\n")
+
+		case kindQuote:
+			out.WriteString("<blockquote>\n")
+			r.depth++
+			r.renderBlocks(out, b.children, false)
+			r.depth--
+			out.WriteString("</blockquote>\n")
+
+		case kindList:
+			tag := "ul"
+			if b.ordered {
+				tag = "ol"
+			}
+			out.WriteString("<" + tag)
+			if b.ordered && b.start != 1 {
+				out.WriteString(` start="` + strconv.Itoa(b.start) + `"`)
+			}
+			out.WriteString(">\n")
+			r.depth++
+			for _, item := range b.items {
+				out.WriteString("<li>")
+				r.renderBlocks(out, item, !b.loose)
+				out.WriteString("</li>\n")
+			}
+			r.depth--
+			out.WriteString("</" + tag + ">\n")
+
+		case kindRule:
+			out.WriteString("<hr>\n")
+
+		case kindHTML:
+			out.WriteString(b.text + "\n")
+
+		case kindTable:
+			out.WriteString("<table>\n<thead>\n")
+			for idx, row := range b.rows {
+				if idx == 1 {
+					out.WriteString("<tbody>\n")
+				}
+				cell := "td"
+				if idx == 0 {
+					cell = "th"
+				}
+				out.WriteString("<tr>\n")
+				for col, text := range row {
+					out.WriteString("<" + cell)
+					if b.align[col] != "" {
+						out.WriteString(` align="` + b.align[col] + `"`)
+					}
+					out.WriteString(">" + r.renderInline(text) + "</" + cell + ">\n")
+				}
+				out.WriteString("</tr>\n")
+				if idx == 0 {
+					out.WriteString("</thead>\n")
+				}
+			}
+			if len(b.rows) > 1 {
+				out.WriteString("</tbody>\n")
+			}
+			out.WriteString("</table>\n")
+		}
+	}
+}
@@ -0,0 +1,448 @@
+package markdown
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	autolinkRe   = regexp.MustCompile(`^<([a-zA-Z][a-zA-Z0-9+.-]{1,31}:[^ <>]*)>`)
+	emailLinkRe  = regexp.MustCompile(`^<([a-zA-Z0-9.!#$%&'*+/=?^_{|}~-]+@[a-zA-Z0-9](?:[a-zA-Z0-9-]*[a-zA-Z0-9])?(?:\.[a-zA-Z0-9](?:[a-zA-Z0-9-]*[a-zA-Z0-9])?)*)>`)
+	inlineHTMLRe = regexp.MustCompile(`^(?:<[a-zA-Z][a-zA-Z0-9-]*(?:\s+[a-zA-Z_:][a-zA-Z0-9_.:-]*(?:\s*=\s*(?:[^\s"'=<>` + "`" + `]+|'[^']*'|"[^"]*"))?)*\s*/?>|</[a-zA-Z][a-zA-Z0-9-]*\s*>|<!--[\s\S]*?-->)`)
+	entityRe     = regexp.MustCompile(`^&(?:#[xX][0-9a-fA-F]{1,6}|#[0-9]{1,7}|[a-zA-Z][a-zA-Z0-9]{1,31});`)
+)
+
+// renderInline converts the inline Markdown of a paragraph or heading to HTML.
+// Emphasis delimiters are collected on the way and only matched once the whole text
+// has been read, following the CommonMark delimiter run rules.
+func (r *renderer) renderInline(src string) string {
+	var out strings.Builder
+	var pieces []inlinePiece
+	var runs []*delimRun
+
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == '\\' && i+1 < len(src) && src[i+1] == '\n':
+			out.WriteString("<br>\n")
+			i += 2
+
+		case c == '\\' && i+1 < len(src) && isASCIIPunct(src[i+1]):
+			out.WriteString(html.EscapeString(src[i+1 : i+2]))
+			i += 2
+
+		case c == '`':
+			n := runLength(src, i, '`')
+			if end := findCodeSpanEnd(src, i+n, n); end >= 0 {
+				code := strings.ReplaceAll(src[i+n:end], "\n", " ")
+				if len(code) > 2 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.TrimSpace(code) != "" {
+					code = code[1 : len(code)-1]
+				}
+				out.WriteString("<code>" + html.EscapeString(code) + "</code>")
+				i = end + n
+			} else {
+				out.WriteString(src[i : i+n])
+				i += n
+			}
+
+		case c == '!' && i+1 < len(src) && src[i+1] == '[':
+			if rendered, next, ok := r.link(src, i+1, true); ok {
+				out.WriteString(rendered)
+				i = next
+			} else {
+				out.WriteString("!")
+				i++
+			}
+
+		case c == '[':
+			if rendered, next, ok := r.link(src, i, false); ok {
+				out.WriteString(rendered)
+				i = next
+			} else {
+				out.WriteString("[")
+				i++
+			}
+
+		case c == '<':
+			rest := src[i:]
+			if m := autolinkRe.FindStringSubmatch(rest); m != nil {
+				out.WriteString(`<a href="` + escapeURL(m[1]) + `">` + html.EscapeString(m[1]) + "</a>")
+				i += len(m[0])
+			} else if m := emailLinkRe.FindStringSubmatch(rest); m != nil {
+				out.WriteString(`<a href="mailto:` + escapeURL(m[1]) + `">` + html.EscapeString(m[1]) + "</a>")
+				i += len(m[0])
+			} else if m := inlineHTMLRe.FindString(rest); m != "" {
+				// raw HTML goes through as is, Sanitize decides what survives
+				out.WriteString(m)
+				i += len(m)
+			} else {
+				out.WriteString("&lt;")
+				i++
+			}
+
+		case c == '&':
+			if m := entityRe.FindString(src[i:]); m != "" {
+				out.WriteString(m)
+				i += len(m)
+			} else {
+				out.WriteString("&amp;")
+				i++
+			}
+
+		case c == '*' || c == '_' || c == '~':
+			n := runLength(src, i, c)
+			run := newDelimRun(src, i, n)
+			pieces = append(pieces, inlinePiece{html: out.String()}, inlinePiece{run: run})
+			runs = append(runs, run)
+			out.Reset()
+			i += n
+
+		case c == ' ':
+			n := runLength(src, i, ' ')
+			if i+n < len(src) && src[i+n] == '\n' {
+				// trailing spaces: two or more make a hard break
+				if n >= 2 {
+					out.WriteString("<br>")
+				}
+			} else if i+n < len(src) {
+				out.WriteString(src[i : i+n])
+			}
+			i += n
+
+		case c == '\n':
+			out.WriteString("\n")
+			i++
+			for i < len(src) && src[i] == ' ' {
+				i++
+			}
+
+		default:
+			_, size := utf8.DecodeRuneInString(src[i:])
+			out.WriteString(html.EscapeString(src[i : i+size]))
+			i += size
+		}
+	}
+
+	if len(runs) == 0 {
+		return out.String()
+	}
+	pieces = append(pieces, inlinePiece{html: out.String()})
+	matchEmphasis(runs)
+
+	var result strings.Builder
+	for _, piece := range pieces {
+		if piece.run != nil {
+			result.WriteString(piece.run.html())
+		} else {
+			result.WriteString(piece.html)
+		}
+	}
+	return result.String()
+}
+
+// inlinePiece is either rendered HTML or a delimiter run whose output depends on its matches
+type inlinePiece struct {
+	html string
+	run  *delimRun
+}
+
+// delimRun is a run of *, _ or ~ that may open or close emphasis
+type delimRun struct {
+	char     byte
+	length   int // as written, for the rule of 3
+	left     int // delimiters not used by any match
+	canOpen  bool
+	canClose bool
+	open     string // tags opened by this run, written after the unused delimiters
+	close    string // tags closed by this run, written before them
+}
+
+// newDelimRun classifies the run of n delimiters at src[i] by the characters around it.
+// Underscores don't open or close inside words, and only ~~ marks strikethrough.
+func newDelimRun(src string, i, n int) *delimRun {
+	c := src[i]
+	run := &delimRun{char: c, length: n, left: n}
+	if c == '~' && n != 2 {
+		return run
+	}
+
+	before, after := ' ', ' '
+	if i > 0 {
+		before, _ = utf8.DecodeLastRuneInString(src[:i])
+	}
+	if i+n < len(src) {
+		after, _ = utf8.DecodeRuneInString(src[i+n:])
+	}
+	leftFlanking := !unicode.IsSpace(after) &&
+		(!isPunct(after) || unicode.IsSpace(before) || isPunct(before))
+	rightFlanking := !unicode.IsSpace(before) &&
+		(!isPunct(before) || unicode.IsSpace(after) || isPunct(after))
+
+	if c == '_' {
+		run.canOpen = leftFlanking && (!rightFlanking || isPunct(before))
+		run.canClose = rightFlanking && (!leftFlanking || isPunct(after))
+	} else {
+		run.canOpen, run.canClose = leftFlanking, rightFlanking
+	}
+	return run
+}
+
+// opens reports whether the run can be the opener matching closer
+func (d *delimRun) opens(closer *delimRun) bool {
+	if !d.canOpen || d.left == 0 || d.char != closer.char {
+		return false
+	}
+	// rule of 3: a run that can both open and close doesn't pair up with one
+	// whose combined length is a multiple of 3, unless both lengths are
+	if (d.canClose || closer.canOpen) && (d.length+closer.length)%3 == 0 &&
+		(d.length%3 != 0 || closer.length%3 != 0) {
+		return false
+	}
+	return true
+}
+
+func (d *delimRun) html() string {
+	return d.close + strings.Repeat(string(d.char), d.left) + d.open
+}
+
+// matchEmphasis pairs closers with the nearest possible opener before them, innermost first.
+// *em*, **strong**, ***both*** and ~~strikethrough~~ nest the way CommonMark nests them.
+func matchEmphasis(runs []*delimRun) {
+	// bottoms remembers, per kind of closer, the index at or below which no opener matched
+	type closerKind struct {
+		char    byte
+		mod3    int
+		canOpen bool
+	}
+	bottoms := map[closerKind]int{}
+
+	for ci, closer := range runs {
+		for closer.canClose && closer.left > 0 {
+			kind := closerKind{closer.char, closer.length % 3, closer.canOpen}
+			bottom, ok := bottoms[kind]
+			if !ok {
+				bottom = -1
+			}
+			oi := ci - 1
+			for oi > bottom && !runs[oi].opens(closer) {
+				oi--
+			}
+			if oi <= bottom {
+				bottoms[kind] = ci - 1
+				break
+			}
+
+			opener := runs[oi]
+			used := 1
+			if opener.left >= 2 && closer.left >= 2 {
+				used = 2
+			}
+			tag := "em"
+			switch {
+			case closer.char == '~':
+				tag = "del"
+			case used == 2:
+				tag = "strong"
+			}
+			opener.left -= used
+			closer.left -= used
+			opener.open = "<" + tag + ">" + opener.open
+			closer.close += "</" + tag + ">"
+
+			// runs between the pair can no longer match anything
+			for _, between := range runs[oi+1 : ci] {
+				between.canOpen, between.canClose = false, false
+			}
+		}
+	}
+}
+
+// link renders [text](url "title"), [text][ref], [ref] and their image forms starting at the '[' in src[i]
+func (r *renderer) link(src string, i int, image bool) (string, int, bool) {
+	closeIdx := matchingBracket(src, i)
+	if closeIdx < 0 {
+		return "", 0, false
+	}
+	text := src[i+1 : closeIdx]
+	next := closeIdx + 1
+
+	var dest, title string
+	switch {
+	case next < len(src) && src[next] == '(':
+		d, t, end, ok := parseInlineDestination(src, next)
+		if !ok {
+			return "", 0, false
+		}
+		dest, title, next = d, t, end
+
+	default:
+		label := text
+		if next+1 < len(src) && src[next] == '[' {
+			if end := strings.IndexByte(src[next+1:], ']'); end >= 0 {
+				if ref := src[next+1 : next+1+end]; ref != "" {
+					label = ref
+				}
+				next = next + 1 + end + 1
+			}
+		}
+		ref, ok := r.refs[normalizeLabel(label)]
+		if !ok {
+			return "", 0, false
+		}
+		dest, title = ref.url, ref.title
+	}
+
+	var out strings.Builder
+	if image {
+		out.WriteString(`<img src="` + escapeURL(dest) + `" alt="` + html.EscapeString(PlainText(r.renderInline(text))) + `"`)
+		if title != "" {
+			out.WriteString(` title="` + html.EscapeString(title) + `"`)
+		}
+		out.WriteString(">")
+	} else {
+		out.WriteString(`<a href="` + escapeURL(dest) + `"`)
+		if title != "" {
+			out.WriteString(` title="` + html.EscapeString(title) + `"`)
+		}
+		out.WriteString(">" + r.renderInline(text) + "</a>")
+	}
+	return out.String(), next, true
+}
+
+// parseInlineDestination reads (url "title") starting at the '(' in src[i]
+func parseInlineDestination(src string, i int) (dest, title string, next int, ok bool) {
+	j := skipSpaces(src, i+1)
+
+	if j < len(src) && src[j] == '<' {
+		end := strings.IndexAny(src[j+1:], ">\n")
+		if end < 0 || src[j+1+end] != '>' {
+			return "", "", 0, false
+		}
+		dest = src[j+1 : j+1+end]
+		j = j + 1 + end + 1
+	} else {
+		start, depth := j, 0
+		for ; j < len(src); j++ {
+			ch := src[j]
+			if ch == '\\' && j+1 < len(src) {
+				j++
+				continue
+			}
+			if ch == '(' {
+				depth++
+			} else if ch == ')' {
+				if depth == 0 {
+					break
+				}
+				depth--
+			} else if ch == ' ' || ch == '\n' {
+				break
+			}
+		}
+		dest = unescapePunct(src[start:j])
+	}
+
+	j = skipSpaces(src, j)
+	if j < len(src) && (src[j] == '"' || src[j] == '\'' || src[j] == '(') {
+		closer := src[j]
+		if closer == '(' {
+			closer = ')'
+		}
+		end := strings.IndexByte(src[j+1:], closer)
+		if end < 0 {
+			return "", "", 0, false
+		}
+		title = unescapePunct(src[j+1 : j+1+end])
+		j = skipSpaces(src, j+1+end+1)
+	}
+
+	if j >= len(src) || src[j] != ')' {
+		return "", "", 0, false
+	}
+	return dest, title, j + 1, true
+}
+
+// matchingBracket finds the ']' closing the '[' at src[i], skipping code spans and escapes
+func matchingBracket(src string, i int) int {
+	depth := 0
+	for j := i; j < len(src); j++ {
+		switch src[j] {
+		case '\\':
+			j++
+		case '`':
+			n := runLength(src, j, '`')
+			if end := findCodeSpanEnd(src, j+n, n); end >= 0 {
+				j = end + n - 1
+			} else {
+				j += n - 1
+			}
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return j
+			}
+		}
+	}
+	return -1
+}
+
+// findCodeSpanEnd finds a backtick run of exactly n starting at or after from
+func findCodeSpanEnd(src string, from, n int) int {
+	for j := from; j < len(src); {
+		if src[j] != '`' {
+			j++
+			continue
+		}
+		run := runLength(src, j, '`')
+		if run == n {
+			return j
+		}
+		j += run
+	}
+	return -1
+}
+
+// escapeURL prepares a link destination for an attribute value
+func escapeURL(url string) string {
+	return html.EscapeString(strings.ReplaceAll(url, " ", "%20"))
+}
+
+func unescapePunct(s string) string {
+	var out strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) && isASCIIPunct(s[i+1]) {
+			i++
+		}
+		out.WriteByte(s[i])
+	}
+	return out.String()
+}
+
+func runLength(src string, i int, c byte) int {
+	n := 0
+	for i+n < len(src) && src[i+n] == c {
+		n++
+	}
+	return n
+}
+
+func skipSpaces(src string, i int) int {
+	for i < len(src) && (src[i] == ' ' || src[i] == '\n') {
+		i++
+	}
+	return i
+}
+
+// isPunct reports whether r counts as punctuation for emphasis flanking
+func isPunct(r rune) bool {
+	return unicode.IsPunct(r) || unicode.IsSymbol(r)
+}
+
+func isASCIIPunct(c byte) bool {
+	return strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", c) >= 0
+}
@@ -0,0 +1,117 @@
+// Package markdown renders post Markdown into sanitized HTML.
+//
+// It covers the CommonMark constructs posts and the AI generator actually use:
+// headings, paragraphs, emphasis, code, links, images, lists, block quotes,
+// thematic breaks and reference links, plus GFM tables and ~~strikethrough~~.
+// Emphasis follows the CommonMark delimiter run rules, so nesting such as
+// *a **b* c** renders the way other CommonMark renderers show it.
+//
+// Not supported: footnotes, task lists, bare-URL autolinks, entity decoding inside
+// link destinations and HTML blocks that start inside a paragraph. These render
+// as plain text or raw HTML instead.
+//
+// Raw HTML is allowed but every render is passed through Sanitize, so scripts
+// and unsafe attributes never reach clients.
+package markdown
+
+import (
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"anchor-blog/pkg/slugutil"
+)
+
+// Version changes whenever the output changes, so cached renders can be refreshed.
+const Version = 2
+
+const (
+	// WordsPerMinute is the reading speed used for the reading time estimate
+	WordsPerMinute = 200
+	// ExcerptLength is the maximum number of characters in an excerpt, ellipsis excluded
+	ExcerptLength = 200
+)
+
+// Heading is a table of contents entry; ID is the anchor set on the heading element
+type Heading struct {
+	Level int
+	Text  string
+	ID    string
+}
+
+// Document is the rendered form of a Markdown source
+type Document struct {
+	HTML        string
+	TOC         []Heading
+	Excerpt     string
+	WordCount   int
+	ReadingTime int // minutes, at least 1 for non-empty documents
+}
+
+// Render converts Markdown to sanitized HTML and derives the table of contents,
+// excerpt and reading time from it.
+func Render(src string) Document {
+	r := &renderer{refs: map[string]linkRef{}, ids: map[string]bool{}}
+	blocks := r.parseBlocks(splitLines(src))
+
+	var out strings.Builder
+	r.renderBlocks(&out, blocks, false)
+
+	doc := Document{
+		HTML: Sanitize(out.String()),
+		TOC:  r.toc,
+	}
+
+	text := PlainText(doc.HTML)
+	doc.WordCount = len(strings.Fields(text))
+	if doc.WordCount > 0 {
+		doc.ReadingTime = int(math.Ceil(float64(doc.WordCount) / WordsPerMinute))
+	}
+	doc.Excerpt = excerpt(r.paragraphText())
+	return doc
+}
+
+// ToHTML is a shorthand for Render(src).HTML
+func ToHTML(src string) string {
+	return Render(src).HTML
+}
+
+// headingID returns a unique anchor for a heading text
+func (r *renderer) headingID(text string) string {
+	base := slugutil.Slugify(text)
+	for n := 1; ; n++ {
+		id := slugutil.WithSuffix(base, n)
+		if !r.ids[id] {
+			r.ids[id] = true
+			return id
+		}
+	}
+}
+
+// paragraphText is the plain text of the top-level paragraphs, in order
+func (r *renderer) paragraphText() string {
+	return strings.Join(r.paragraphs, " ")
+}
+
+// excerpt cuts text at a word boundary so it fits ExcerptLength
+func excerpt(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if utf8.RuneCountInString(text) <= ExcerptLength {
+		return text
+	}
+
+	runes := []rune(text)
+	cut := string(runes[:ExcerptLength])
+	if idx := strings.LastIndex(cut, " "); idx > ExcerptLength/2 {
+		cut = cut[:idx]
+	}
+	return strings.TrimRight(cut, " ,;:.-") + "…"
+}
+
+// splitLines normalizes line endings and expands tabs so indentation can be counted in spaces
+func splitLines(src string) []string {
+	src = strings.ReplaceAll(src, "\r\n", "\n")
+	src = strings.ReplaceAll(src, "\r", "\n")
+	src = strings.ReplaceAll(src, "\t", "    ")
+	return strings.Split(src, "\n")
+}
@@ -0,0 +1,121 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender_BasicBlocks(t *testing.T) {
+	src := "# Title\n\nSome **bold**, *em*, ~~gone~~ and `x < y`.\n\n- one\n- two\n\n1. first\n2. second\n\n> quoted\n\n---\n"
+
+	html := ToHTML(src)
+
+	assert.Contains(t, html, `<h1 id="title">Title</h1>`)
+	assert.Contains(t, html, "<p>Some <strong>bold</strong>, <em>em</em>, <del>gone</del> and <code>x &lt; y</code>.</p>")
+	assert.Contains(t, html, "<ul>\n<li>one</li>\n<li>two</li>\n</ul>")
+	assert.Contains(t, html, "<ol>\n<li>first</li>\n<li>second</li>\n</ol>")
+	assert.Contains(t, html, "<blockquote>\n<p>quoted</p>\n</blockquote>")
+	assert.Contains(t, html, "<hr>")
+}
+
+func TestRender_EmphasisNesting(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{"*a **b* c**", "<p><em>a <em><em>b</em> c</em></em></p>"},
+		{"*foo**bar**baz*", "<p><em>foo<strong>bar</strong>baz</em></p>"},
+		{"***a* b**", "<p><strong><em>a</em> b</strong></p>"},
+		{"***both***", "<p><em><strong>both</strong></em></p>"},
+		{"**foo*", "<p>*<em>foo</em></p>"},
+		{"*foo**bar*", "<p><em>foo**bar</em></p>"},
+		{"~~a ~~b~~ c~~", "<p><del>a <del>b</del> c</del></p>"},
+		{"snake_case_name and a * b * c", "<p>snake_case_name and a * b * c</p>"},
+		{"`*x*` *y*", "<p><code>*x*</code> <em>y</em></p>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			assert.Equal(t, tt.want+"\n", ToHTML(tt.src))
+		})
+	}
+}
+
+func TestRender_Tables(t *testing.T) {
+	src := "Prices\n| Name | Price |\n|:-----|------:|\n| `a\\|b` | **1** |\n| c |\n\nafter\n"
+
+	html := ToHTML(src)
+
+	assert.Contains(t, html, "<p>Prices</p>\n<table>\n<thead>\n<tr>\n<th align=\"left\">Name</th>\n<th align=\"right\">Price</th>\n</tr>\n</thead>")
+	assert.Contains(t, html, "<td align=\"left\"><code>a|b</code></td>\n<td align=\"right\"><strong>1</strong></td>")
+	assert.Contains(t, html, "<td align=\"left\">c</td>\n<td align=\"right\"></td>")
+	assert.Contains(t, html, "</tbody>\n</table>\n<p>after</p>")
+	assert.Equal(t, "<h2 id=\"a\">a</h2>\n", ToHTML("a\n---\n"), "a setext heading is not a table")
+}
+
+func TestRender_CodeBlocks(t *testing.T) {
+	html := ToHTML("```go\nfmt.Println(\"<b>\")\n```\n\n    indented <i>\n")
+
+	assert.Contains(t, html, `<pre><code class="language-go">fmt.Println(&#34;&lt;b&gt;&#34;)`)
+	assert.Contains(t, html, "<pre><code>indented &lt;i&gt;\n</code></pre>")
+}
+
+func TestRender_Links(t *testing.T) {
+	html := ToHTML("[Go](https://go.dev \"The Go site\") ![logo](/logo.png) [docs][ref] <https://example.com>\n\n[ref]: https://pkg.go.dev\n")
+
+	assert.Contains(t, html, `<a href="https://go.dev" title="The Go site" rel="nofollow noopener">Go</a>`)
+	assert.Contains(t, html, `<img src="/logo.png" alt="logo">`)
+	assert.Contains(t, html, `<a href="https://pkg.go.dev" rel="nofollow noopener">docs</a>`)
+	assert.Contains(t, html, `<a href="https://example.com" rel="nofollow noopener">https://example.com</a>`)
+}
+
+func TestRender_StripsUnsafeHTML(t *testing.T) {
+	src := "Hello <script>alert(1)</script><b onclick=\"steal()\">there</b>\n\n" +
+		"[click](javascript:alert(1)) <a href=\"java&#x09;script:alert(1)\">tab</a> ![x](data:image/png;base64,AAAA)\n\n" +
+		"<div style=\"x\"><iframe src=\"https://evil.example\"></iframe><p>kept</p></div>\n"
+
+	html := ToHTML(src)
+
+	assert.NotContains(t, html, "script")
+	assert.NotContains(t, html, "alert")
+	assert.NotContains(t, html, "onclick")
+	assert.NotContains(t, html, "iframe")
+	assert.NotContains(t, html, "style")
+	assert.NotContains(t, html, "data:")
+	assert.Contains(t, html, "<b>there</b>")
+	assert.Contains(t, html, "<div><p>kept</p></div>")
+}
+
+func TestSanitize_ClosesDanglingTags(t *testing.T) {
+	assert.Equal(t, "<p><em>open</em></p>", Sanitize("<p><em>open</p>"))
+	assert.Equal(t, "stray", Sanitize("stray</div>"))
+}
+
+func TestRender_TOCAndUniqueAnchors(t *testing.T) {
+	doc := Render("# Intro\n\n## Setup *fast*\n\nText\n\n## Setup fast\n\nSub\n---\n")
+
+	assert.Equal(t, []Heading{
+		{Level: 1, Text: "Intro", ID: "intro"},
+		{Level: 2, Text: "Setup fast", ID: "setup-fast"},
+		{Level: 2, Text: "Setup fast", ID: "setup-fast-2"},
+		{Level: 2, Text: "Sub", ID: "sub"},
+	}, doc.TOC)
+	assert.Contains(t, doc.HTML, `<h2 id="setup-fast-2">Setup fast</h2>`)
+}
+
+func TestRender_ReadingTimeAndExcerpt(t *testing.T) {
+	long := strings.Repeat("word ", 450)
+	doc := Render("# Heading\n\n" + long + "\n\n```\nnot counted not counted\n```\n")
+
+	assert.Equal(t, 450, doc.WordCount-1) // plus the heading
+	assert.Equal(t, 3, doc.ReadingTime)
+	assert.True(t, strings.HasSuffix(doc.Excerpt, "…"))
+	assert.LessOrEqual(t, len([]rune(doc.Excerpt)), ExcerptLength+1)
+	assert.NotContains(t, doc.Excerpt, "Heading")
+
+	empty := Render("")
+	assert.Equal(t, 0, empty.ReadingTime)
+	assert.Equal(t, "", empty.Excerpt)
+}
@@ -0,0 +1,238 @@
+package markdown
+
+import (
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+
+	nethtml "golang.org/x/net/html"
+)
+
+// allowedTags maps every element kept by Sanitize to the attributes it may carry
+var allowedTags = map[string]map[string]bool{
+	"a":          {"href": true, "title": true},
+	"abbr":       {"title": true},
+	"b":          {},
+	"blockquote": {},
+	"br":         {},
+	"code":       {"class": true},
+	"dd":         {},
+	"del":        {},
+	"details":    {},
+	"div":        {},
+	"dl":         {},
+	"dt":         {},
+	"em":         {},
+	"figcaption": {},
+	"figure":     {},
+	"h1":         {"id": true},
+	"h2":         {"id": true},
+	"h3":         {"id": true},
+	"h4":         {"id": true},
+	"h5":         {"id": true},
+	"h6":         {"id": true},
+	"hr":         {},
+	"i":          {},
+	"img":        {"src": true, "alt": true, "title": true, "width": true, "height": true},
+	"kbd":        {},
+	"li":         {},
+	"mark":       {},
+	"ol":         {"start": true},
+	"p":          {},
+	"pre":        {},
+	"s":          {},
+	"span":       {},
+	"strong":     {},
+	"sub":        {},
+	"summary":    {},
+	"sup":        {},
+	"table":      {},
+	"tbody":      {},
+	"td":         {"align": true},
+	"tfoot":      {},
+	"th":         {"align": true},
+	"thead":      {},
+	"tr":         {},
+	"u":          {},
+	"ul":         {},
+}
+
+// droppedTags are removed together with everything inside them
+var droppedTags = map[string]bool{
+	"script": true, "style": true, "iframe": true, "object": true, "embed": true,
+	"noscript": true, "template": true, "textarea": true, "select": true, "title": true,
+	"svg": true, "math": true, "frame": true, "frameset": true, "applet": true,
+}
+
+var voidTags = map[string]bool{"br": true, "hr": true, "img": true}
+
+var (
+	codeClassRe = regexp.MustCompile(`^language-[a-zA-Z0-9_+#.-]+$`)
+	numberRe    = regexp.MustCompile(`^[0-9]{1,5}$`)
+	idRe        = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,100}$`)
+)
+
+// Sanitize keeps only allowlisted elements and attributes, drops scripts and other
+// active content with their bodies, and rejects link targets with unsafe schemes.
+// Unknown elements are unwrapped so their text survives.
+func Sanitize(fragment string) string {
+	var out strings.Builder
+	var open []string
+	skipDepth := 0
+	skipTag := ""
+
+	z := nethtml.NewTokenizer(strings.NewReader(fragment))
+	for {
+		tt := z.Next()
+		if tt == nethtml.ErrorToken {
+			break
+		}
+		token := z.Token()
+
+		if skipDepth > 0 {
+			switch {
+			case tt == nethtml.StartTagToken && token.Data == skipTag:
+				skipDepth++
+			case tt == nethtml.EndTagToken && token.Data == skipTag:
+				skipDepth--
+			}
+			continue
+		}
+
+		switch tt {
+		case nethtml.TextToken:
+			out.WriteString(html.EscapeString(token.Data))
+
+		case nethtml.StartTagToken, nethtml.SelfClosingTagToken:
+			if droppedTags[token.Data] {
+				if tt == nethtml.StartTagToken {
+					skipDepth, skipTag = 1, token.Data
+				}
+				continue
+			}
+			attrs, ok := allowedTags[token.Data]
+			if !ok {
+				continue
+			}
+			out.WriteString("<" + token.Data)
+			for _, attr := range token.Attr {
+				if value, ok := safeAttribute(token.Data, attr, attrs); ok {
+					out.WriteString(" " + attr.Key + `="` + html.EscapeString(value) + `"`)
+				}
+			}
+			if token.Data == "a" {
+				out.WriteString(` rel="nofollow noopener"`)
+			}
+			out.WriteString(">")
+			if !voidTags[token.Data] && tt == nethtml.StartTagToken {
+				open = append(open, token.Data)
+			}
+
+		case nethtml.EndTagToken:
+			// close the element only if it is open, closing anything left inside it
+			for idx := len(open) - 1; idx >= 0; idx-- {
+				if open[idx] == token.Data {
+					for len(open) > idx {
+						out.WriteString("</" + open[len(open)-1] + ">")
+						open = open[:len(open)-1]
+					}
+					break
+				}
+			}
+		}
+		// comments and doctypes are dropped
+	}
+
+	for len(open) > 0 {
+		out.WriteString("</" + open[len(open)-1] + ">")
+		open = open[:len(open)-1]
+	}
+	return out.String()
+}
+
+// safeAttribute validates an attribute of an allowed element and returns the value to write
+func safeAttribute(tag string, attr nethtml.Attribute, allowed map[string]bool) (string, bool) {
+	if attr.Namespace != "" || !allowed[attr.Key] {
+		return "", false
+	}
+
+	switch attr.Key {
+	case "href":
+		return attr.Val, isSafeURL(attr.Val, tag == "a")
+	case "src":
+		return attr.Val, isSafeURL(attr.Val, false)
+	case "class":
+		return attr.Val, codeClassRe.MatchString(attr.Val)
+	case "id":
+		return attr.Val, idRe.MatchString(attr.Val)
+	case "start", "width", "height":
+		return attr.Val, numberRe.MatchString(attr.Val)
+	case "align":
+		switch attr.Val {
+		case "left", "right", "center":
+			return attr.Val, true
+		}
+		return "", false
+	}
+	return attr.Val, true
+}
+
+// isSafeURL accepts relative URLs and http(s) ones; links may also use mailto
+func isSafeURL(raw string, allowMailto bool) bool {
+	raw = strings.TrimSpace(raw)
+	for _, r := range raw {
+		if r < 0x20 || r == 0x7f {
+			// browsers ignore control characters, so "java\tscript:" would still run
+			return false
+		}
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "":
+		// protocol-relative and plain relative links; a colon before any slash would be read as a scheme
+		first := strings.IndexAny(raw, "/?#")
+		colon := strings.IndexByte(raw, ':')
+		return colon < 0 || (first >= 0 && first < colon)
+	case "http", "https":
+		return true
+	case "mailto":
+		return allowMailto
+	}
+	return false
+}
+
+// PlainText returns the text content of an HTML fragment, leaving out code blocks
+func PlainText(fragment string) string {
+	var out strings.Builder
+	inPre := 0
+
+	z := nethtml.NewTokenizer(strings.NewReader(fragment))
+	for {
+		tt := z.Next()
+		switch tt {
+		case nethtml.ErrorToken:
+			return strings.TrimSpace(out.String())
+		case nethtml.TextToken:
+			if inPre == 0 {
+				out.Write(z.Text())
+			}
+		case nethtml.StartTagToken, nethtml.EndTagToken:
+			name, _ := z.TagName()
+			switch string(name) {
+			case "pre":
+				if tt == nethtml.StartTagToken {
+					inPre++
+				} else if inPre > 0 {
+					inPre--
+				}
+			case "p", "li", "br", "h1", "h2", "h3", "h4", "h5", "h6", "blockquote", "td", "th", "div":
+				out.WriteString(" ")
+			}
+		}
+	}
+}
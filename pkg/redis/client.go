@@ -8,6 +8,9 @@ import (
 	"github.com/redis/go-redis/v9"
 )
 
+// ErrMiss is returned by Get for keys that don't exist
+var ErrMiss = redis.Nil
+
 type Client struct {
 	rdb *redis.Client
 }
@@ -0,0 +1,66 @@
+// Package sitemap encodes XML sitemaps and sitemap indexes as described on sitemaps.org.
+package sitemap
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// MaxURLs is the most URLs a single sitemap may list; larger sites need an index
+const MaxURLs = 50000
+
+const namespace = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+// URL is a page of the site. A zero LastMod is left out.
+type URL struct {
+	Loc     string
+	LastMod time.Time
+}
+
+type urlSet struct {
+	XMLName xml.Name   `xml:"urlset"`
+	XMLNS   string     `xml:"xmlns,attr"`
+	URLs    []location `xml:"url"`
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name   `xml:"sitemapindex"`
+	XMLNS    string     `xml:"xmlns,attr"`
+	Sitemaps []location `xml:"sitemap"`
+}
+
+type location struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// URLSet encodes a sitemap listing the URLs
+func URLSet(urls []URL) ([]byte, error) {
+	doc := urlSet{XMLNS: namespace, URLs: locations(urls)}
+	return encode(doc)
+}
+
+// Index encodes a sitemap index; each URL points at one sitemap
+func Index(sitemaps []URL) ([]byte, error) {
+	doc := sitemapIndex{XMLNS: namespace, Sitemaps: locations(sitemaps)}
+	return encode(doc)
+}
+
+func locations(urls []URL) []location {
+	locs := make([]location, len(urls))
+	for i, url := range urls {
+		locs[i] = location{Loc: url.Loc}
+		if !url.LastMod.IsZero() {
+			locs[i].LastMod = url.LastMod.UTC().Format(time.RFC3339)
+		}
+	}
+	return locs
+}
+
+func encode(doc interface{}) ([]byte, error) {
+	body, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
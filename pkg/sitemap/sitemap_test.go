@@ -0,0 +1,34 @@
+package sitemap
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestURLSet(t *testing.T) {
+	body, err := URLSet([]URL{
+		{Loc: "https://blog.example.com/posts/filter?tags=go&page=1", LastMod: time.Date(2025, 8, 7, 10, 30, 0, 0, time.UTC)},
+		{Loc: "https://blog.example.com/"},
+	})
+	require.NoError(t, err)
+
+	xml := string(body)
+	assert.True(t, strings.HasPrefix(xml, `<?xml version="1.0" encoding="UTF-8"?>`))
+	assert.Contains(t, xml, `<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`)
+	assert.Contains(t, xml, "<loc>https://blog.example.com/posts/filter?tags=go&amp;page=1</loc>")
+	assert.Contains(t, xml, "<lastmod>2025-08-07T10:30:00Z</lastmod>")
+	assert.Equal(t, 1, strings.Count(xml, "<lastmod>"))
+}
+
+func TestIndex(t *testing.T) {
+	body, err := Index([]URL{{Loc: "https://blog.example.com/sitemaps/1.xml"}, {Loc: "https://blog.example.com/sitemaps/2.xml"}})
+	require.NoError(t, err)
+
+	xml := string(body)
+	assert.Contains(t, xml, `<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`)
+	assert.Equal(t, 2, strings.Count(xml, "<sitemap>"))
+}
@@ -0,0 +1,44 @@
+package slugutil
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlugify(t *testing.T) {
+	tests := map[string]string{
+		"Getting Started with Go":     "getting-started-with-go",
+		"  Go:  The   Good Parts!  ":  "go-the-good-parts",
+		"Crème Brûlée à la Française": "creme-brulee-a-la-francaise",
+		"Straße & Smørrebrød":         "strasse-and-smorrebrod",
+		"Привет, мир":                 "privet-mir",
+		"Don't Panic":                 "dont-panic",
+		"C++ vs. Go (2025)":           "c-vs-go-2025",
+		"日本語":                         "post",
+		"":                            "post",
+	}
+
+	for title, expected := range tests {
+		assert.Equal(t, expected, Slugify(title), "title: %q", title)
+	}
+}
+
+func TestSlugify_TruncatesLongTitles(t *testing.T) {
+	// Test data
+	title := strings.Repeat("word ", 40)
+
+	// Execute
+	slug := Slugify(title)
+
+	// Assert
+	assert.LessOrEqual(t, len(slug), MaxLength)
+	assert.False(t, strings.HasSuffix(slug, "-"))
+	assert.True(t, strings.HasSuffix(slug, "word"))
+}
+
+func TestWithSuffix(t *testing.T) {
+	assert.Equal(t, "title", WithSuffix("title", 1))
+	assert.Equal(t, "title-3", WithSuffix("title", 3))
+}
@@ -0,0 +1,31 @@
+// Package storage keeps uploaded files in a pluggable backend: the local filesystem
+// or any S3-compatible object store.
+package storage
+
+import (
+	"context"
+	"io"
+	"path"
+	"strings"
+)
+
+// Storage stores objects under slash-separated keys such as "6501.../original.jpg".
+// Open reports a missing object with an error wrapping fs.ErrNotExist; Delete ignores them.
+type Storage interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	// URL is where clients download the object from
+	URL(key string) string
+}
+
+// ValidKey reports whether a key is a clean relative path that stays inside the storage root
+func ValidKey(key string) bool {
+	return key != "" && path.Clean(key) == key && !path.IsAbs(key) &&
+		key != ".." && !strings.HasPrefix(key, "../") && !strings.Contains(key, "\\")
+}
+
+// joinURL appends a key to a base URL
+func joinURL(base, key string) string {
+	return strings.TrimRight(base, "/") + "/" + key
+}
@@ -0,0 +1,84 @@
+package tagutil
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	// MaxLength caps the length of a tag, in characters
+	MaxLength = 32
+	// MaxPerPost is the most tags a single post may carry
+	MaxPerPost = 10
+)
+
+// Normalize brings a tag to its canonical form:
+//   - surrounding whitespace and a leading '#' are removed
+//   - letters are lowercased
+//   - whitespace and underscores become single hyphens
+//   - only letters, digits and the symbols - + # . are kept, so "C++", "C#" and "Node.js" survive
+//
+// The result may be empty when nothing usable is left.
+func Normalize(tag string) string {
+	tag = strings.TrimSpace(tag)
+	tag = strings.TrimLeft(tag, "#")
+
+	var b strings.Builder
+	pendingHyphen := false
+	length := 0
+	for _, r := range strings.ToLower(tag) {
+		if length >= MaxLength {
+			break
+		}
+		switch {
+		case unicode.IsSpace(r) || r == '_' || r == '-':
+			pendingHyphen = b.Len() > 0
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '+' || r == '#' || r == '.':
+			if pendingHyphen {
+				b.WriteRune('-')
+				length++
+				pendingHyphen = false
+			}
+			b.WriteRune(r)
+			length++
+		}
+	}
+
+	return strings.Trim(b.String(), "-.")
+}
+
+// NormalizeList normalizes every tag, dropping empty ones and duplicates while keeping the order
+func NormalizeList(tags []string) []string {
+	result := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = Normalize(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		result = append(result, tag)
+	}
+	return result
+}
+
+// Replace swaps every tag listed in from for to, keeping the order and dropping the duplicates
+// this creates. The tags are taken as stored; they are not normalized.
+func Replace(tags, from []string, to string) []string {
+	result := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		for _, old := range from {
+			if tag == old {
+				tag = to
+				break
+			}
+		}
+		if seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		result = append(result, tag)
+	}
+	return result
+}
@@ -0,0 +1,47 @@
+package tagutil
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]string{
+		"Go":               "go",
+		"go ":              "go",
+		"#golang":          "golang",
+		"Machine Learning": "machine-learning",
+		"web_dev":          "web-dev",
+		"C++":              "c++",
+		"C#":               "c#",
+		"Node.js":          "node.js",
+		"  --rust--  ":     "rust",
+		"Ünïcode":          "ünïcode",
+		"日本語":              "日本語",
+		"!!!":              "",
+	}
+
+	for raw, expected := range tests {
+		assert.Equal(t, expected, Normalize(raw), "tag: %q", raw)
+	}
+}
+
+func TestNormalize_TruncatesLongTags(t *testing.T) {
+	tag := Normalize(strings.Repeat("a", 100))
+
+	assert.Len(t, tag, MaxLength)
+}
+
+func TestNormalizeList_DeduplicatesInOrder(t *testing.T) {
+	result := NormalizeList([]string{"Go", "web", "go ", "#Go", "", "  ", "API"})
+
+	assert.Equal(t, []string{"go", "web", "api"}, result)
+}
+
+func TestReplace_DeduplicatesInOrder(t *testing.T) {
+	result := Replace([]string{"web", "golang", "Go", "go", "api"}, []string{"golang", "Go"}, "go")
+
+	assert.Equal(t, []string{"web", "go", "api"}, result)
+}
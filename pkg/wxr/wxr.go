@@ -0,0 +1,210 @@
+// Package wxr reads WordPress eXtended RSS (WXR) export files.
+//
+// The file is streamed item by item, so exports of any size can be read without
+// loading them into memory. Elements are matched by local name, which keeps the
+// reader working across WXR versions 1.0 to 1.2.
+package wxr
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const contentNamespace = "http://purl.org/rss/1.0/modules/content/"
+
+// wordpress writes dates in this layout; unset ones are all zeros
+const dateLayout = "2006-01-02 15:04:05"
+
+// ErrNotWXR is returned when the file isn't an RSS document
+var ErrNotWXR = errors.New("not a WordPress export file")
+
+// Author is a user listed in the export
+type Author struct {
+	Login       string `xml:"author_login"`
+	Email       string `xml:"author_email"`
+	DisplayName string `xml:"author_display_name"`
+	FirstName   string `xml:"author_first_name"`
+	LastName    string `xml:"author_last_name"`
+}
+
+// Item is a post, page, attachment or other entry of the export
+type Item struct {
+	ID         int
+	Type       string // post, page, attachment, ...
+	Status     string // publish, draft, pending, private, future, trash, ...
+	Title      string
+	Slug       string
+	Link       string
+	Creator    string // login of the author
+	Content    string // HTML
+	Published  time.Time
+	Modified   time.Time
+	Categories []string
+	Tags       []string
+}
+
+type category struct {
+	Domain   string `xml:"domain,attr"`
+	Nicename string `xml:"nicename,attr"`
+	Name     string `xml:",chardata"`
+}
+
+type item struct {
+	Title       string     `xml:"title"`
+	Link        string     `xml:"link"`
+	PubDate     string     `xml:"pubDate"`
+	Creator     string     `xml:"creator"`
+	Content     string     `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+	PostID      int        `xml:"post_id"`
+	PostDate    string     `xml:"post_date"`
+	PostDateGMT string     `xml:"post_date_gmt"`
+	ModifiedGMT string     `xml:"post_modified_gmt"`
+	Modified    string     `xml:"post_modified"`
+	PostName    string     `xml:"post_name"`
+	Status      string     `xml:"status"`
+	PostType    string     `xml:"post_type"`
+	Categories  []category `xml:"category"`
+}
+
+// Reader returns the items of an export one at a time
+type Reader struct {
+	dec     *xml.Decoder
+	authors map[string]Author
+	started bool
+}
+
+// NewReader reads an export from r
+func NewReader(r io.Reader) *Reader {
+	dec := xml.NewDecoder(r)
+	dec.Strict = false
+	dec.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		// exports are UTF-8, some declare it under another name
+		return input, nil
+	}
+	return &Reader{dec: dec, authors: map[string]Author{}}
+}
+
+// Author looks up an author by login. WordPress lists authors before the items,
+// so every author an item refers to is known by the time the item is returned.
+func (r *Reader) Author(login string) (Author, bool) {
+	author, ok := r.authors[login]
+	return author, ok
+}
+
+// Next returns the next item, or io.EOF after the last one
+func (r *Reader) Next() (*Item, error) {
+	for {
+		tok, err := r.dec.Token()
+		if err == io.EOF {
+			if !r.started {
+				return nil, ErrNotWXR
+			}
+			return nil, io.EOF
+		}
+		if err != nil {
+			return nil, fmt.Errorf("malformed export: %w", err)
+		}
+
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch start.Name.Local {
+		case "rss":
+			r.started = true
+		case "author":
+			if !r.started || !strings.Contains(start.Name.Space, "wordpress.org/export") {
+				continue
+			}
+			var author Author
+			if err := r.dec.DecodeElement(&author, &start); err != nil {
+				return nil, fmt.Errorf("malformed author: %w", err)
+			}
+			if author.Login != "" {
+				r.authors[author.Login] = author
+			}
+		case "item":
+			if !r.started {
+				return nil, ErrNotWXR
+			}
+			var raw item
+			if err := r.dec.DecodeElement(&raw, &start); err != nil {
+				return nil, fmt.Errorf("malformed item: %w", err)
+			}
+			return raw.toItem(), nil
+		}
+	}
+}
+
+func (raw *item) toItem() *Item {
+	it := &Item{
+		ID:        raw.PostID,
+		Type:      strings.TrimSpace(raw.PostType),
+		Status:    strings.TrimSpace(raw.Status),
+		Title:     strings.TrimSpace(html.UnescapeString(raw.Title)),
+		Slug:      strings.TrimSpace(raw.PostName),
+		Link:      strings.TrimSpace(raw.Link),
+		Creator:   strings.TrimSpace(raw.Creator),
+		Content:   raw.Content,
+		Published: firstDate(parseDate(raw.PostDateGMT), parseRSSDate(raw.PubDate), parseDate(raw.PostDate)),
+		Modified:  firstDate(parseDate(raw.ModifiedGMT), parseDate(raw.Modified)),
+	}
+	// non-ASCII slugs are stored percent-encoded
+	if slug, err := url.PathUnescape(it.Slug); err == nil {
+		it.Slug = slug
+	}
+	if it.Type == "" {
+		it.Type = "post"
+	}
+
+	for _, c := range raw.Categories {
+		name := strings.TrimSpace(c.Name)
+		if name == "" {
+			name = c.Nicename
+		}
+		switch c.Domain {
+		case "category":
+			// every uncategorized post carries the default category, it says nothing about the post
+			if c.Nicename != "uncategorized" {
+				it.Categories = append(it.Categories, name)
+			}
+		case "post_tag":
+			it.Tags = append(it.Tags, name)
+		}
+	}
+	return it
+}
+
+// parseDate reads a wp:post_date style date, taken to be UTC
+func parseDate(s string) time.Time {
+	t, err := time.Parse(dateLayout, strings.TrimSpace(s))
+	if err != nil || t.Year() < 1 {
+		return time.Time{}
+	}
+	return t
+}
+
+func parseRSSDate(s string) time.Time {
+	s = strings.TrimSpace(s)
+	for _, layout := range []string{time.RFC1123Z, time.RFC1123} {
+		if t, err := time.Parse(layout, s); err == nil && t.Year() > 1 {
+			return t.UTC()
+		}
+	}
+	return time.Time{}
+}
+
+func firstDate(dates ...time.Time) time.Time {
+	for _, d := range dates {
+		if !d.IsZero() {
+			return d
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,100 @@
+package wxr
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const export = `<?xml version="1.0" encoding="UTF-8" ?>
+<rss version="2.0"
+	xmlns:excerpt="http://wordpress.org/export/1.2/excerpt/"
+	xmlns:content="http://purl.org/rss/1.0/modules/content/"
+	xmlns:dc="http://purl.org/dc/elements/1.1/"
+	xmlns:wp="http://wordpress.org/export/1.2/">
+<channel>
+	<title>My Blog</title>
+	<wp:author>
+		<wp:author_id>1</wp:author_id>
+		<wp:author_login><![CDATA[jane]]></wp:author_login>
+		<wp:author_email><![CDATA[jane@example.com]]></wp:author_email>
+		<wp:author_display_name><![CDATA[Jane Doe]]></wp:author_display_name>
+		<wp:author_first_name><![CDATA[Jane]]></wp:author_first_name>
+		<wp:author_last_name><![CDATA[Doe]]></wp:author_last_name>
+	</wp:author>
+	<item>
+		<title>Tom&amp;#8217;s first post</title>
+		<link>https://old.example.com/2019/03/first/</link>
+		<pubDate>Fri, 01 Mar 2019 10:00:00 +0000</pubDate>
+		<dc:creator><![CDATA[jane]]></dc:creator>
+		<content:encoded><![CDATA[<p>Hello <b>world</b></p>]]></content:encoded>
+		<excerpt:encoded><![CDATA[Short]]></excerpt:encoded>
+		<wp:post_id>42</wp:post_id>
+		<wp:post_date><![CDATA[2019-03-01 11:00:00]]></wp:post_date>
+		<wp:post_date_gmt><![CDATA[2019-03-01 10:00:00]]></wp:post_date_gmt>
+		<wp:post_modified_gmt><![CDATA[2019-04-02 08:30:00]]></wp:post_modified_gmt>
+		<wp:post_name><![CDATA[caf%c3%a9-first]]></wp:post_name>
+		<wp:status><![CDATA[publish]]></wp:status>
+		<wp:post_type><![CDATA[post]]></wp:post_type>
+		<category domain="category" nicename="uncategorized"><![CDATA[Uncategorized]]></category>
+		<category domain="category" nicename="news"><![CDATA[News]]></category>
+		<category domain="post_tag" nicename="go"><![CDATA[Go]]></category>
+	</item>
+	<item>
+		<title>Draft</title>
+		<dc:creator><![CDATA[jane]]></dc:creator>
+		<content:encoded><![CDATA[]]></content:encoded>
+		<wp:post_id>43</wp:post_id>
+		<wp:post_date><![CDATA[2019-05-01 09:00:00]]></wp:post_date>
+		<wp:post_date_gmt><![CDATA[0000-00-00 00:00:00]]></wp:post_date_gmt>
+		<wp:status><![CDATA[draft]]></wp:status>
+		<wp:post_type><![CDATA[page]]></wp:post_type>
+	</item>
+</channel>
+</rss>`
+
+func TestReader(t *testing.T) {
+	r := NewReader(strings.NewReader(export))
+
+	first, err := r.Next()
+	require.NoError(t, err)
+	assert.Equal(t, &Item{
+		ID:         42,
+		Type:       "post",
+		Status:     "publish",
+		Title:      "Tom’s first post",
+		Slug:       "café-first",
+		Link:       "https://old.example.com/2019/03/first/",
+		Creator:    "jane",
+		Content:    "<p>Hello <b>world</b></p>",
+		Published:  time.Date(2019, 3, 1, 10, 0, 0, 0, time.UTC),
+		Modified:   time.Date(2019, 4, 2, 8, 30, 0, 0, time.UTC),
+		Categories: []string{"News"},
+		Tags:       []string{"Go"},
+	}, first)
+
+	author, ok := r.Author("jane")
+	assert.True(t, ok)
+	assert.Equal(t, Author{Login: "jane", Email: "jane@example.com", DisplayName: "Jane Doe", FirstName: "Jane", LastName: "Doe"}, author)
+
+	second, err := r.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "page", second.Type)
+	assert.Equal(t, time.Date(2019, 5, 1, 9, 0, 0, 0, time.UTC), second.Published, "falls back to the local date")
+	assert.True(t, second.Modified.IsZero())
+
+	_, err = r.Next()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestReader_NotWXR(t *testing.T) {
+	_, err := NewReader(strings.NewReader(`<html><body>hi</body></html>`)).Next()
+	assert.Equal(t, ErrNotWXR, err)
+
+	_, err = NewReader(strings.NewReader(`<rss><channel><item><title>x</ti`)).Next()
+	assert.Error(t, err)
+}